@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/a-novel/bunovel"
 	"github.com/a-novel/go-apis"
+	apiclients "github.com/a-novel/go-apis/clients"
 	"github.com/a-novel/votes-service/config"
 	"github.com/a-novel/votes-service/migrations"
 	"github.com/a-novel/votes-service/pkg/adapters"
@@ -12,7 +13,10 @@ import (
 	"github.com/a-novel/votes-service/pkg/handlers"
 	"github.com/a-novel/votes-service/pkg/models"
 	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/samber/lo"
 	"io/fs"
+	"net/http"
+	"time"
 )
 
 func main() {
@@ -36,21 +40,163 @@ func main() {
 	}()
 
 	votesDAO := dao.NewVotesRepository(postgres)
+	voteEventsDAO := dao.NewVoteEventsRepository(postgres)
+	outboxDAO := dao.NewOutboxRepository(postgres)
+	voteFlagsDAO := dao.NewVoteFlagsRepository(postgres)
+	targetLocksDAO := dao.NewTargetLocksRepository(postgres)
+	pollsDAO := dao.NewPollsRepository(postgres)
 
-	votesClients := map[string]models.CheckVoteClient{
-		"improveRequest":    adapters.NewImproveRequestVoteClient(forumClient, permissionsClient),
-		"improveSuggestion": adapters.NewImproveSuggestionVoteClient(forumClient, permissionsClient),
+	targetsDefinitions := make([]adapters.TargetDefinition, len(config.Targets.Definitions))
+	for i, item := range config.Targets.Definitions {
+		targetsDefinitions[i] = adapters.TargetDefinition{
+			Name:             item.Name,
+			Scope:            apiclients.Scope(item.Scope),
+			Values:           lo.Map(item.Values, func(value string, _ int) models.VoteValue { return models.VoteValue(value) }),
+			CallbackKind:     item.Callback.Kind,
+			CallbackURL:      item.Callback.URL,
+			CallbackSecret:   item.Callback.Secret,
+			Open:             item.Open,
+			AuthorsSeeVoters: item.AuthorsSeeVoters,
+		}
+
+		if item.Rating != nil {
+			targetsDefinitions[i].Rating = &models.RatingScale{Min: item.Rating.Min, Max: item.Rating.Max}
+		}
+
+		if len(item.Weights) > 0 {
+			weights := make([]adapters.ScopeWeight, len(item.Weights))
+			for j, weight := range item.Weights {
+				weights[j] = adapters.ScopeWeight{Scope: apiclients.Scope(weight.Scope), Weight: weight.Weight}
+			}
+
+			targetsDefinitions[i].WeightResolver, err = adapters.NewScopeWeightResolver(permissionsClient, weights)
+			if err != nil {
+				logger.Fatal().Err(err).Str("target", item.Name).Msg("error loading target weights")
+			}
+		}
 	}
 
-	castVoteService := services.NewCastVoteService(votesDAO, authClient, votesClients)
+	targets, err := adapters.NewTargetsRegistry(targetsDefinitions, map[string]adapters.TargetHandlerFactory{
+		"none": func(definition adapters.TargetDefinition) (models.TargetHandler, error) {
+			return adapters.NewSilentTargetHandler(permissionsClient, definition.Scope), nil
+		},
+		"improveRequest": func(definition adapters.TargetDefinition) (models.TargetHandler, error) {
+			return adapters.NewImproveRequestTargetHandler(forumClient, permissionsClient, definition.Scope), nil
+		},
+		"improveSuggestion": func(definition adapters.TargetDefinition) (models.TargetHandler, error) {
+			return adapters.NewImproveSuggestionTargetHandler(forumClient, permissionsClient, definition.Scope), nil
+		},
+		"webhook": func(definition adapters.TargetDefinition) (models.TargetHandler, error) {
+			return adapters.NewWebhookTargetHandler(permissionsClient, definition.Scope, http.DefaultClient, adapters.WebhookConfig{
+				URL:         definition.CallbackURL,
+				Secret:      definition.CallbackSecret,
+				Timeout:     config.Webhooks.Timeout,
+				MaxAttempts: config.Webhooks.MaxAttempts,
+				Backoff:     config.Webhooks.Backoff,
+			})
+		},
+	})
+	if err != nil {
+		logger.Fatal().Err(err).Msg("error loading targets")
+	}
+
+	var rateLimiter models.RateLimiter
+	switch config.RateLimits.Backend {
+	case config.RateLimitsBackendMemory:
+		rateLimiter = adapters.NewMemoryRateLimiter()
+	case config.RateLimitsBackendPostgres:
+		rateLimiter = adapters.NewPostgresRateLimiter(dao.NewRateLimitsRepository(postgres))
+	default:
+		logger.Fatal().Str("backend", config.RateLimits.Backend).Msg("unknown rate limits backend")
+	}
+
+	castVoteService := services.NewCastVoteService(votesDAO, authClient, targets, rateLimiter, services.CastVoteConfig{
+		IdempotencyTTL: config.Idempotency.TTL,
+		UserRateLimit: models.RateLimit{
+			Burst:    config.RateLimits.Cast.User.Burst,
+			Interval: config.RateLimits.Cast.User.Interval,
+		},
+		TargetRateLimit: models.RateLimit{
+			Burst:    config.RateLimits.Cast.Target.Burst,
+			Interval: config.RateLimits.Cast.Target.Interval,
+		},
+	})
 	getUserVoteService := services.NewGetUserVoteService(votesDAO, authClient)
+	getUserVotesService := services.NewGetUserVotesService(votesDAO, authClient)
 	getVotesSummaryService := services.NewGetVotesSummaryService(votesDAO)
+	getVotesSummariesService := services.NewGetVotesSummariesService(votesDAO)
 	listUserVotesService := services.NewListUserVotesService(votesDAO, authClient)
+	listVoteEventsService := services.NewListVoteEventsService(
+		voteEventsDAO, authClient, permissionsClient, apiclients.Scope(config.Permissions.ModerationScope),
+	)
+	listTargetVotesService := services.NewListTargetVotesService(
+		votesDAO, authClient, permissionsClient, targets, apiclients.Scope(config.Permissions.ModerationScope),
+	)
+	listVoteFlagsService := services.NewListVoteFlagsService(
+		voteFlagsDAO, authClient, permissionsClient, apiclients.Scope(config.Permissions.ModerationScope),
+	)
+	reviewVoteFlagService := services.NewReviewVoteFlagService(
+		voteFlagsDAO, authClient, permissionsClient, apiclients.Scope(config.Permissions.ModerationScope),
+	)
+	invalidateVotesService := services.NewInvalidateVotesService(
+		votesDAO, authClient, permissionsClient, targets, apiclients.Scope(config.Permissions.ModerationScope),
+	)
+	restoreVotesService := services.NewRestoreVotesService(
+		votesDAO, authClient, permissionsClient, targets, apiclients.Scope(config.Permissions.ModerationScope),
+	)
+	lockTargetService := services.NewLockTargetService(
+		targetLocksDAO, authClient, permissionsClient, targets, apiclients.Scope(config.Permissions.ModerationScope),
+	)
+	unlockTargetService := services.NewUnlockTargetService(
+		targetLocksDAO, authClient, permissionsClient, targets, apiclients.Scope(config.Permissions.ModerationScope),
+	)
+	listHotTargetsService := services.NewListHotTargetsService(votesDAO, targets, services.HotRankingConfig{
+		Gravity: config.Ranking.Hot.Gravity,
+		Window:  config.Ranking.Hot.Window,
+	})
+	listTopRatedTargetsService := services.NewListTopRatedTargetsService(votesDAO, targets, services.TopRatedRankingConfig{
+		PriorWeight: config.Ranking.TopRated.PriorWeight,
+	})
+
+	dispatchOutboxService := services.NewDispatchOutboxService(outboxDAO, targets, services.OutboxConfig{
+		BatchSize:   config.Outbox.BatchSize,
+		Lease:       config.Outbox.Lease,
+		MaxAttempts: config.Outbox.MaxAttempts,
+		MinBackoff:  config.Outbox.Backoff.Min,
+		MaxBackoff:  config.Outbox.Backoff.Max,
+	})
+	purgeIdempotencyKeysService := services.NewPurgeIdempotencyKeysService(votesDAO)
+	createPollService := services.NewCreatePollService(
+		pollsDAO, authClient, permissionsClient, apiclients.Scope(config.Permissions.PollScope),
+	)
+	getPollService := services.NewGetPollService(pollsDAO)
+	castBallotService := services.NewCastBallotService(pollsDAO, authClient)
+	getPollResultsService := services.NewGetPollResultsService(pollsDAO)
+	castRankingService := services.NewCastRankingService(pollsDAO, authClient)
+	getRunoffResultsService := services.NewGetRunoffResultsService(pollsDAO)
 
 	castVoteHandler := handlers.NewCastVoteHandler(castVoteService)
 	getUserVoteHandler := handlers.NewGetUserVoteHandler(getUserVoteService)
+	getUserVotesHandler := handlers.NewGetUserVotesHandler(getUserVotesService)
 	getVotesSummaryHandler := handlers.NewGetVotesSummaryHandler(getVotesSummaryService)
+	getVotesSummariesHandler := handlers.NewGetVotesSummariesHandler(getVotesSummariesService)
 	listUserVotesHandler := handlers.NewListUserVotesHandler(listUserVotesService)
+	listTargetVotesHandler := handlers.NewListTargetVotesHandler(listTargetVotesService)
+	listHotTargetsHandler := handlers.NewListHotTargetsHandler(listHotTargetsService)
+	listTopRatedTargetsHandler := handlers.NewListTopRatedTargetsHandler(listTopRatedTargetsService)
+	listVoteEventsHandler := handlers.NewListVoteEventsHandler(listVoteEventsService)
+	listVoteFlagsHandler := handlers.NewListVoteFlagsHandler(listVoteFlagsService)
+	reviewVoteFlagHandler := handlers.NewReviewVoteFlagHandler(reviewVoteFlagService)
+	invalidateVotesHandler := handlers.NewInvalidateVotesHandler(invalidateVotesService)
+	restoreVotesHandler := handlers.NewRestoreVotesHandler(restoreVotesService)
+	lockTargetHandler := handlers.NewLockTargetHandler(lockTargetService)
+	unlockTargetHandler := handlers.NewUnlockTargetHandler(unlockTargetService)
+	createPollHandler := handlers.NewCreatePollHandler(createPollService)
+	getPollHandler := handlers.NewGetPollHandler(getPollService)
+	castBallotHandler := handlers.NewCastBallotHandler(castBallotService)
+	getPollResultsHandler := handlers.NewGetPollResultsHandler(getPollResultsService)
+	castRankingHandler := handlers.NewCastRankingHandler(castRankingService)
+	getRunoffResultsHandler := handlers.NewGetRunoffResultsHandler(getRunoffResultsService)
 
 	router := apis.GetRouter(apis.RouterConfig{
 		Logger:    logger,
@@ -73,10 +219,62 @@ func main() {
 		},
 	})
 
+	// Deliver the updated counters to the targets, in the background.
+	go func() {
+		ticker := time.NewTicker(config.Outbox.Interval)
+		defer ticker.Stop()
+
+		for now := range ticker.C {
+			if _, err := dispatchOutboxService.Dispatch(ctx, now); err != nil {
+				logger.Error().Err(err).Msg("error dispatching outbox messages")
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(config.Idempotency.PurgeInterval)
+		defer ticker.Stop()
+
+		for now := range ticker.C {
+			if _, err := purgeIdempotencyKeysService.Purge(ctx, now); err != nil {
+				logger.Error().Err(err).Msg("error purging expired idempotency keys")
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(config.RateLimits.PurgeInterval)
+		defer ticker.Stop()
+
+		for now := range ticker.C {
+			if err := rateLimiter.Purge(ctx, now); err != nil {
+				logger.Error().Err(err).Msg("error purging rate limit buckets")
+			}
+		}
+	}()
+
 	router.POST("/vote", castVoteHandler.Handle)
 	router.GET("/vote", getUserVoteHandler.Handle)
+	router.POST("/vote/batch", getUserVotesHandler.Handle)
 	router.GET("/votes/post", getVotesSummaryHandler.Handle)
+	router.POST("/votes/post/batch", getVotesSummariesHandler.Handle)
 	router.GET("/votes/user", listUserVotesHandler.Handle)
+	router.GET("/votes/voters", listTargetVotesHandler.Handle)
+	router.GET("/votes/ranking", listHotTargetsHandler.Handle)
+	router.GET("/votes/ranking/rated", listTopRatedTargetsHandler.Handle)
+	router.GET("/admin/votes/events", listVoteEventsHandler.Handle)
+	router.GET("/admin/votes/flags", listVoteFlagsHandler.Handle)
+	router.POST("/admin/votes/flags/review", reviewVoteFlagHandler.Handle)
+	router.POST("/admin/votes/invalidate", invalidateVotesHandler.Handle)
+	router.POST("/admin/votes/restore", restoreVotesHandler.Handle)
+	router.POST("/admin/votes/lock", lockTargetHandler.Handle)
+	router.POST("/admin/votes/unlock", unlockTargetHandler.Handle)
+	router.GET("/poll", getPollHandler.Handle)
+	router.POST("/poll/ballot", castBallotHandler.Handle)
+	router.GET("/poll/results", getPollResultsHandler.Handle)
+	router.POST("/poll/ranking", castRankingHandler.Handle)
+	router.GET("/poll/runoff", getRunoffResultsHandler.Handle)
+	router.POST("/admin/polls", createPollHandler.Handle)
 
 	if err := router.Run(fmt.Sprintf(":%d", config.API.Port)); err != nil {
 		logger.Fatal().Err(err).Msg("a fatal error occurred while running the API, and the server had to shut down")
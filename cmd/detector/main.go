@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/votes-service/config"
+	"github.com/a-novel/votes-service/migrations"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/services"
+	"io/fs"
+	"time"
+)
+
+// Scans the recent votes for suspicious patterns, and flags them for the moderators. The job runs once and exits, so
+// it can be scheduled by any cron-like runner.
+func main() {
+	ctx := context.Background()
+	logger := config.GetLogger()
+
+	postgres, sql, err := bunovel.NewClient(ctx, bunovel.Config{
+		Driver:                &bunovel.PGDriver{DSN: config.Postgres.DSN, AppName: config.App.Name},
+		Migrations:            &bunovel.MigrateConfig{Files: []fs.FS{migrations.Migrations}},
+		DiscardUnknownColumns: true,
+	})
+	if err != nil {
+		logger.Fatal().Err(err).Msg("error connecting to postgres")
+	}
+	defer func() {
+		_ = postgres.Close()
+		_ = sql.Close()
+	}()
+
+	detectVoteFlagsService := services.NewDetectVoteFlagsService(dao.NewVoteFlagsRepository(postgres), services.VoteFlagsConfig{
+		Lookback:          config.VoteFlags.Lookback,
+		CoVotingWindow:    config.VoteFlags.CoVoting.Window,
+		MinCoVotedTargets: config.VoteFlags.CoVoting.MinTargets,
+		MinCoVotingGroup:  config.VoteFlags.CoVoting.MinGroup,
+		NewVoterAge:       config.VoteFlags.NewVoters.Age,
+		MinNewVoters:      config.VoteFlags.NewVoters.MinVoters,
+		MinFlips:          config.VoteFlags.FlipBurst.MinFlips,
+	})
+
+	flagged, err := detectVoteFlagsService.Detect(ctx, time.Now())
+	if err != nil {
+		logger.Fatal().Err(err).Msg("error detecting suspicious votes")
+	}
+
+	logger.Info().Int("flagged", flagged).Msg("detection of suspicious votes complete")
+}
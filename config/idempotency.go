@@ -0,0 +1,27 @@
+package config
+
+import (
+	_ "embed"
+	"log"
+	"time"
+)
+
+//go:embed idempotency.yml
+var idempotencyFile []byte
+
+type IdempotencyConfig struct {
+	TTL           time.Duration `yaml:"ttl"`
+	PurgeInterval time.Duration `yaml:"purgeInterval"`
+}
+
+var Idempotency *IdempotencyConfig
+
+func init() {
+	cfg := new(IdempotencyConfig)
+
+	if err := loadEnv(EnvLoader{DefaultENV: idempotencyFile}, cfg); err != nil {
+		log.Fatalf("error loading idempotency configuration: %v\n", err)
+	}
+
+	Idempotency = cfg
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	_ "embed"
+	"log"
+	"time"
+)
+
+//go:embed outbox.yml
+var outboxFile []byte
+
+type OutboxConfig struct {
+	Interval    time.Duration `yaml:"interval"`
+	BatchSize   int           `yaml:"batchSize"`
+	Lease       time.Duration `yaml:"lease"`
+	MaxAttempts int           `yaml:"maxAttempts"`
+	Backoff     struct {
+		Min time.Duration `yaml:"min"`
+		Max time.Duration `yaml:"max"`
+	} `yaml:"backoff"`
+}
+
+var Outbox *OutboxConfig
+
+func init() {
+	cfg := new(OutboxConfig)
+
+	if err := loadEnv(EnvLoader{DefaultENV: outboxFile}, cfg); err != nil {
+		log.Fatalf("error loading outbox configuration: %v\n", err)
+	}
+
+	Outbox = cfg
+}
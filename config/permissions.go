@@ -0,0 +1,26 @@
+package config
+
+import (
+	_ "embed"
+	"log"
+)
+
+//go:embed permissions.yml
+var permissionsFile []byte
+
+type PermissionsConfig struct {
+	ModerationScope string `yaml:"moderationScope"`
+	PollScope       string `yaml:"pollScope"`
+}
+
+var Permissions *PermissionsConfig
+
+func init() {
+	cfg := new(PermissionsConfig)
+
+	if err := loadEnv(EnvLoader{DefaultENV: permissionsFile}, cfg); err != nil {
+		log.Fatalf("error loading permissions configuration: %v\n", err)
+	}
+
+	Permissions = cfg
+}
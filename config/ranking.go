@@ -0,0 +1,32 @@
+package config
+
+import (
+	_ "embed"
+	"log"
+	"time"
+)
+
+//go:embed ranking.yml
+var rankingFile []byte
+
+type RankingConfig struct {
+	Hot struct {
+		Gravity float64       `yaml:"gravity"`
+		Window  time.Duration `yaml:"window"`
+	} `yaml:"hot"`
+	TopRated struct {
+		PriorWeight float64 `yaml:"priorWeight"`
+	} `yaml:"topRated"`
+}
+
+var Ranking *RankingConfig
+
+func init() {
+	cfg := new(RankingConfig)
+
+	if err := loadEnv(EnvLoader{DefaultENV: rankingFile}, cfg); err != nil {
+		log.Fatalf("error loading ranking configuration: %v\n", err)
+	}
+
+	Ranking = cfg
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	_ "embed"
+	"log"
+	"time"
+)
+
+//go:embed rate_limits.yml
+var rateLimitsFile []byte
+
+const (
+	RateLimitsBackendMemory   = "memory"
+	RateLimitsBackendPostgres = "postgres"
+)
+
+type RateLimitConfig struct {
+	Burst    int           `yaml:"burst"`
+	Interval time.Duration `yaml:"interval"`
+}
+
+type RateLimitsConfig struct {
+	Backend       string        `yaml:"backend"`
+	PurgeInterval time.Duration `yaml:"purgeInterval"`
+	Cast          struct {
+		User   RateLimitConfig `yaml:"user"`
+		Target RateLimitConfig `yaml:"target"`
+	} `yaml:"cast"`
+}
+
+var RateLimits *RateLimitsConfig
+
+func init() {
+	cfg := new(RateLimitsConfig)
+
+	if err := loadEnv(EnvLoader{DefaultENV: rateLimitsFile}, cfg); err != nil {
+		log.Fatalf("error loading rate limits configuration: %v\n", err)
+	}
+
+	RateLimits = cfg
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	_ "embed"
+	"log"
+)
+
+//go:embed targets.yml
+var targetsFile []byte
+
+type TargetConfig struct {
+	Name   string   `yaml:"name"`
+	Scope  string   `yaml:"scope"`
+	Values []string `yaml:"values"`
+	Rating *struct {
+		Min int `yaml:"min"`
+		Max int `yaml:"max"`
+	} `yaml:"rating"`
+	Callback struct {
+		Kind   string `yaml:"kind"`
+		URL    string `yaml:"url"`
+		Secret string `yaml:"secret"`
+	} `yaml:"callback"`
+	Open             bool `yaml:"open"`
+	AuthorsSeeVoters bool `yaml:"authorsSeeVoters"`
+	Weights          []struct {
+		Scope  string `yaml:"scope"`
+		Weight int    `yaml:"weight"`
+	} `yaml:"weights"`
+}
+
+type TargetsConfig struct {
+	Definitions []TargetConfig `yaml:"targets"`
+}
+
+var Targets *TargetsConfig
+
+func init() {
+	cfg := new(TargetsConfig)
+
+	if err := loadEnv(EnvLoader{DefaultENV: targetsFile}, cfg); err != nil {
+		log.Fatalf("error loading targets configuration: %v\n", err)
+	}
+
+	Targets = cfg
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	_ "embed"
+	"log"
+	"time"
+)
+
+//go:embed vote_flags.yml
+var voteFlagsFile []byte
+
+type VoteFlagsConfig struct {
+	Lookback time.Duration `yaml:"lookback"`
+	CoVoting struct {
+		Window     time.Duration `yaml:"window"`
+		MinTargets int           `yaml:"minTargets"`
+		MinGroup   int           `yaml:"minGroup"`
+	} `yaml:"coVoting"`
+	NewVoters struct {
+		Age       time.Duration `yaml:"age"`
+		MinVoters int           `yaml:"minVoters"`
+	} `yaml:"newVoters"`
+	FlipBurst struct {
+		MinFlips int `yaml:"minFlips"`
+	} `yaml:"flipBurst"`
+}
+
+var VoteFlags *VoteFlagsConfig
+
+func init() {
+	cfg := new(VoteFlagsConfig)
+
+	if err := loadEnv(EnvLoader{DefaultENV: voteFlagsFile}, cfg); err != nil {
+		log.Fatalf("error loading vote flags configuration: %v\n", err)
+	}
+
+	VoteFlags = cfg
+}
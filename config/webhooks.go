@@ -0,0 +1,28 @@
+package config
+
+import (
+	_ "embed"
+	"log"
+	"time"
+)
+
+//go:embed webhooks.yml
+var webhooksFile []byte
+
+type WebhooksConfig struct {
+	Timeout     time.Duration `yaml:"timeout"`
+	MaxAttempts int           `yaml:"maxAttempts"`
+	Backoff     time.Duration `yaml:"backoff"`
+}
+
+var Webhooks *WebhooksConfig
+
+func init() {
+	cfg := new(WebhooksConfig)
+
+	if err := loadEnv(EnvLoader{DefaultENV: webhooksFile}, cfg); err != nil {
+		log.Fatalf("error loading webhooks configuration: %v\n", err)
+	}
+
+	Webhooks = cfg
+}
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS votes_summary;
+
+--bun:split
+
+CREATE VIEW votes_summary AS
+    SELECT
+        target_id,
+        target,
+        SUM(CASE WHEN vote = 'up' THEN 1 ELSE 0 END) AS up_votes,
+        SUM(CASE WHEN vote = 'down' THEN 1 ELSE 0 END) AS down_votes
+    FROM votes
+    GROUP BY target_id, target;
@@ -0,0 +1,25 @@
+DROP VIEW IF EXISTS votes_summary;
+
+--bun:split
+
+CREATE TABLE IF NOT EXISTS votes_summary (
+    target_id uuid NOT NULL,
+    target TEXT NOT NULL,
+    up_votes INTEGER NOT NULL DEFAULT 0,
+    down_votes INTEGER NOT NULL DEFAULT 0,
+
+    /* Counters are maintained by the votes repository, in the same transaction as the vote itself. */
+    PRIMARY KEY (target_id, target)
+);
+
+--bun:split
+
+INSERT INTO votes_summary (target_id, target, up_votes, down_votes)
+    SELECT
+        target_id,
+        target,
+        SUM(CASE WHEN vote = 'up' THEN 1 ELSE 0 END) AS up_votes,
+        SUM(CASE WHEN vote = 'down' THEN 1 ELSE 0 END) AS down_votes
+    FROM votes
+    GROUP BY target_id, target
+ON CONFLICT (target_id, target) DO NOTHING;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS votes_activity_idx;
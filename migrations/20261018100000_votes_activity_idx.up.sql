@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS votes_activity_idx ON votes (target, (COALESCE(updated_at, created_at)));
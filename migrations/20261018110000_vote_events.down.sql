@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS vote_events_user_idx;
+DROP INDEX IF EXISTS vote_events_target_idx;
+
+--bun:split
+
+DROP TABLE IF EXISTS vote_events;
+
+--bun:split
+
+DROP TYPE IF EXISTS vote_event;
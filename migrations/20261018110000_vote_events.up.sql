@@ -0,0 +1,21 @@
+CREATE TYPE vote_event AS ENUM ('cast', 'flip', 'retract');
+
+--bun:split
+
+/* Append-only history of the votes. Rows are never updated nor deleted. */
+CREATE TABLE IF NOT EXISTS vote_events (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL,
+
+    event vote_event NOT NULL,
+    user_id uuid NOT NULL,
+    target_id uuid NOT NULL,
+    target TEXT NOT NULL,
+    old_vote vote,
+    new_vote vote
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS vote_events_target_idx ON vote_events (target_id, target, created_at DESC);
+CREATE INDEX IF NOT EXISTS vote_events_user_idx ON vote_events (user_id, created_at DESC);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS vote_outbox_next_attempt_idx;
+DROP INDEX IF EXISTS vote_outbox_pending_target_idx;
+
+--bun:split
+
+DROP TABLE IF EXISTS vote_outbox;
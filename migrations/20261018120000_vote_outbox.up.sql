@@ -0,0 +1,27 @@
+/*
+    Pending updates of the targets counters. Rows are written in the same transaction as the votes, and delivered
+    asynchronously. Delivered rows are deleted, while rows that failed too many times are kept with a dead_at date.
+*/
+CREATE TABLE IF NOT EXISTS vote_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL,
+    /* Incremented every time the message is overwritten by a newer vote. */
+    version INTEGER NOT NULL DEFAULT 0,
+
+    target TEXT NOT NULL,
+    target_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    up_votes INTEGER NOT NULL,
+    down_votes INTEGER NOT NULL,
+
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error TEXT,
+    dead_at TIMESTAMPTZ
+);
+
+--bun:split
+
+/* Only the latest counters of a target need to be delivered. */
+CREATE UNIQUE INDEX IF NOT EXISTS vote_outbox_pending_target_idx ON vote_outbox (target_id, target) WHERE dead_at IS NULL;
+CREATE INDEX IF NOT EXISTS vote_outbox_next_attempt_idx ON vote_outbox (next_attempt_at) WHERE dead_at IS NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS user_votes_activity_idx;
@@ -0,0 +1,2 @@
+/* Supports the keyset pagination of the votes of a user. */
+CREATE INDEX IF NOT EXISTS user_votes_activity_idx ON votes (user_id, target, (COALESCE(updated_at, created_at)) DESC, id DESC);
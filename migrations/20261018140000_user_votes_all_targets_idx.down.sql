@@ -0,0 +1 @@
+DROP INDEX IF EXISTS user_votes_all_targets_activity_idx;
@@ -0,0 +1,2 @@
+/* Supports listing the votes of a user on every target at once. */
+CREATE INDEX IF NOT EXISTS user_votes_all_targets_activity_idx ON votes (user_id, (COALESCE(updated_at, created_at)) DESC, id DESC);
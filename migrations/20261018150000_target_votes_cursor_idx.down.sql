@@ -0,0 +1 @@
+DROP INDEX IF EXISTS target_votes_activity_idx;
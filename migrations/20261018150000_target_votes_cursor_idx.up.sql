@@ -0,0 +1,2 @@
+/* Supports the keyset pagination of the voters of a target. */
+CREATE INDEX IF NOT EXISTS target_votes_activity_idx ON votes (target_id, target, (COALESCE(updated_at, created_at)) DESC, id DESC);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idempotency_keys_expires_at_idx;
+
+--bun:split
+
+DROP TABLE IF EXISTS idempotency_keys;
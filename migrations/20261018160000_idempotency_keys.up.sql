@@ -0,0 +1,20 @@
+/*
+    Responses of the requests sent with an Idempotency-Key header. A retried request with the same key gets the
+    stored response back, instead of being executed again. Keys are scoped to the user that sent them.
+*/
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id uuid NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+
+    /* Hash of the request the key was first used with. The key cannot be reused for a different request. */
+    request_hash TEXT NOT NULL,
+    response JSONB,
+
+    PRIMARY KEY (user_id, idempotency_key)
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
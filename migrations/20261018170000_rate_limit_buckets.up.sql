@@ -0,0 +1,14 @@
+/*
+    Token buckets of the rate limiter, when they are shared across the instances of the service. Buckets are deleted
+    once they refilled completely, as they are then equivalent to new buckets.
+*/
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    full_at TIMESTAMPTZ NOT NULL
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS rate_limit_buckets_full_at_idx ON rate_limit_buckets (full_at);
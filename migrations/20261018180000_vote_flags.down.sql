@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS vote_flags_review_idx;
+
+--bun:split
+
+DROP TABLE IF EXISTS vote_flags;
+
+--bun:split
+
+DROP TYPE IF EXISTS vote_flag_status;
+DROP TYPE IF EXISTS vote_flag_kind;
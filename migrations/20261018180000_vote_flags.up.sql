@@ -0,0 +1,28 @@
+CREATE TYPE vote_flag_kind AS ENUM ('co_voting', 'new_voters', 'flip_burst');
+CREATE TYPE vote_flag_status AS ENUM ('pending', 'confirmed', 'dismissed');
+
+--bun:split
+
+/*
+    Suspicious voting patterns found by the detection job, for the moderators to review.
+*/
+CREATE TABLE IF NOT EXISTS vote_flags (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMPTZ NOT NULL,
+
+    kind vote_flag_kind NOT NULL,
+    target TEXT NOT NULL,
+    target_ids uuid[] NOT NULL,
+    user_ids uuid[] NOT NULL,
+    occurrences INTEGER NOT NULL,
+    /* Identifies the finding, so successive runs of the job don't flag it again. */
+    fingerprint TEXT NOT NULL UNIQUE,
+
+    status vote_flag_status NOT NULL DEFAULT 'pending',
+    reviewed_by uuid,
+    reviewed_at TIMESTAMPTZ
+);
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS vote_flags_review_idx ON vote_flags (status, created_at DESC);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS votes_invalidated_idx;
+
+--bun:split
+
+ALTER TABLE votes
+    DROP COLUMN IF EXISTS invalidation_reason,
+    DROP COLUMN IF EXISTS invalidated_by,
+    DROP COLUMN IF EXISTS invalidated_at;
@@ -0,0 +1,9 @@
+/* Invalidated votes are kept, but excluded from the summaries until a moderator restores them. */
+ALTER TABLE votes
+    ADD COLUMN IF NOT EXISTS invalidated_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS invalidated_by uuid,
+    ADD COLUMN IF NOT EXISTS invalidation_reason TEXT;
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS votes_invalidated_idx ON votes (user_id) WHERE invalidated_at IS NOT NULL;
@@ -0,0 +1,13 @@
+/*
+    Targets that stop accepting votes, either right away or at a scheduled date. Their summaries remain readable.
+*/
+CREATE TABLE IF NOT EXISTS target_locks (
+    target_id uuid NOT NULL,
+    target TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    locked_by uuid NOT NULL,
+    /* Votes are rejected from this date on. */
+    closes_at TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (target_id, target)
+);
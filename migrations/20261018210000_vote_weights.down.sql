@@ -0,0 +1,14 @@
+ALTER TABLE vote_outbox
+    DROP COLUMN IF EXISTS weighted_down_votes,
+    DROP COLUMN IF EXISTS weighted_up_votes;
+
+--bun:split
+
+ALTER TABLE votes_summary
+    DROP COLUMN IF EXISTS weighted_down_votes,
+    DROP COLUMN IF EXISTS weighted_up_votes;
+
+--bun:split
+
+ALTER TABLE votes
+    DROP COLUMN IF EXISTS weight;
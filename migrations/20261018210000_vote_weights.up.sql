@@ -0,0 +1,24 @@
+/* Weight of the vote, resolved when it was cast. Votes on targets without weighting always weigh 1. */
+ALTER TABLE votes
+    ADD COLUMN IF NOT EXISTS weight INTEGER NOT NULL DEFAULT 1;
+
+--bun:split
+
+ALTER TABLE votes_summary
+    ADD COLUMN IF NOT EXISTS weighted_up_votes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS weighted_down_votes INTEGER NOT NULL DEFAULT 0;
+
+--bun:split
+
+/* Existing votes all weigh 1. */
+UPDATE votes_summary SET weighted_up_votes = up_votes, weighted_down_votes = down_votes;
+
+--bun:split
+
+ALTER TABLE vote_outbox
+    ADD COLUMN IF NOT EXISTS weighted_up_votes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS weighted_down_votes INTEGER NOT NULL DEFAULT 0;
+
+--bun:split
+
+UPDATE vote_outbox SET weighted_up_votes = up_votes, weighted_down_votes = down_votes;
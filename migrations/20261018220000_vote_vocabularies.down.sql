@@ -0,0 +1,29 @@
+ALTER TABLE vote_outbox DROP COLUMN IF EXISTS counts;
+
+--bun:split
+
+ALTER TABLE votes_summary DROP COLUMN IF EXISTS counts;
+
+--bun:split
+
+DROP FUNCTION IF EXISTS jsonb_add_counts(JSONB, JSONB);
+
+--bun:split
+
+CREATE TYPE vote AS ENUM ('up', 'down');
+
+--bun:split
+
+/* Values other than up and down cannot be represented by the enum. They never counted in up_votes and down_votes. */
+DELETE FROM votes WHERE vote NOT IN ('up', 'down');
+DELETE FROM vote_events WHERE old_vote NOT IN ('up', 'down') OR new_vote NOT IN ('up', 'down');
+
+--bun:split
+
+ALTER TABLE votes ALTER COLUMN vote TYPE vote USING vote::vote;
+
+--bun:split
+
+ALTER TABLE vote_events
+    ALTER COLUMN old_vote TYPE vote USING old_vote::vote,
+    ALTER COLUMN new_vote TYPE vote USING new_vote::vote;
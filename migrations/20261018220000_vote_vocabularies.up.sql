@@ -0,0 +1,54 @@
+/*
+    Targets declare their own vote values in their configuration, so the values are stored as text instead of an enum
+    that would need a migration for every new value.
+*/
+ALTER TABLE votes ALTER COLUMN vote TYPE TEXT USING vote::TEXT;
+
+--bun:split
+
+ALTER TABLE vote_events
+    ALTER COLUMN old_vote TYPE TEXT USING old_vote::TEXT,
+    ALTER COLUMN new_vote TYPE TEXT USING new_vote::TEXT;
+
+--bun:split
+
+DROP TYPE IF EXISTS vote;
+
+--bun:split
+
+/* Adds the counters of two objects mapping vote values to counts. Values that end up at 0 are removed. */
+CREATE OR REPLACE FUNCTION jsonb_add_counts(a JSONB, b JSONB) RETURNS JSONB AS $$
+    SELECT COALESCE(jsonb_object_agg(key, total), '{}'::JSONB) FROM (
+        SELECT key, SUM(value::INTEGER) AS total FROM (
+            SELECT * FROM jsonb_each_text(a)
+            UNION ALL
+            SELECT * FROM jsonb_each_text(b)
+        ) AS entries
+        GROUP BY key
+    ) AS totals
+    WHERE total <> 0;
+$$ LANGUAGE SQL IMMUTABLE;
+
+--bun:split
+
+/* Number of votes per value, invalidated votes excluded. */
+ALTER TABLE votes_summary ADD COLUMN IF NOT EXISTS counts JSONB NOT NULL DEFAULT '{}'::JSONB;
+
+--bun:split
+
+UPDATE votes_summary SET counts = totals.counts
+    FROM (
+        SELECT target_id, target, jsonb_object_agg(vote, total) AS counts
+        FROM (
+            SELECT target_id, target, vote, COUNT(*) AS total
+            FROM votes
+            WHERE invalidated_at IS NULL
+            GROUP BY target_id, target, vote
+        ) AS per_value
+        GROUP BY target_id, target
+    ) AS totals
+    WHERE votes_summary.target_id = totals.target_id AND votes_summary.target = totals.target;
+
+--bun:split
+
+ALTER TABLE vote_outbox ADD COLUMN IF NOT EXISTS counts JSONB NOT NULL DEFAULT '{}'::JSONB;
@@ -0,0 +1,23 @@
+ALTER TABLE vote_outbox DROP COLUMN IF EXISTS ratings;
+
+--bun:split
+
+DROP INDEX IF EXISTS votes_summary_ratings_idx;
+
+--bun:split
+
+ALTER TABLE votes_summary
+    DROP COLUMN IF EXISTS rating_sum,
+    DROP COLUMN IF EXISTS rating_count,
+    DROP COLUMN IF EXISTS ratings;
+
+--bun:split
+
+ALTER TABLE vote_events
+    DROP COLUMN IF EXISTS new_score,
+    DROP COLUMN IF EXISTS old_score;
+
+--bun:split
+
+ALTER TABLE votes
+    DROP COLUMN IF EXISTS score;
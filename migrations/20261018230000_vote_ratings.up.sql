@@ -0,0 +1,29 @@
+/* Score of the rating votes. It is only set on targets users rate, rather than vote on. */
+ALTER TABLE votes
+    ADD COLUMN IF NOT EXISTS score INTEGER;
+
+--bun:split
+
+ALTER TABLE vote_events
+    ADD COLUMN IF NOT EXISTS old_score INTEGER,
+    ADD COLUMN IF NOT EXISTS new_score INTEGER;
+
+--bun:split
+
+/*
+    Number of ratings per score, invalidated ratings excluded. The count and sum of the scores are kept alongside, so
+    the rated targets can be ranked without unpacking the histogram.
+*/
+ALTER TABLE votes_summary
+    ADD COLUMN IF NOT EXISTS ratings JSONB NOT NULL DEFAULT '{}'::JSONB,
+    ADD COLUMN IF NOT EXISTS rating_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS rating_sum INTEGER NOT NULL DEFAULT 0;
+
+--bun:split
+
+CREATE INDEX IF NOT EXISTS votes_summary_ratings_idx ON votes_summary (target) WHERE rating_count > 0;
+
+--bun:split
+
+ALTER TABLE vote_outbox
+    ADD COLUMN IF NOT EXISTS ratings JSONB NOT NULL DEFAULT '{}'::JSONB;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS poll_ballots;
+
+--bun:split
+
+DROP TABLE IF EXISTS polls;
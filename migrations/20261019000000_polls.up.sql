@@ -0,0 +1,36 @@
+/*
+    Community polls. Options are identified by their position in the options array, which never changes once the poll
+    is created.
+*/
+CREATE TABLE IF NOT EXISTS polls (
+    id uuid PRIMARY KEY NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ,
+    created_by uuid NOT NULL,
+
+    question TEXT NOT NULL,
+    options TEXT[] NOT NULL,
+    /* Single-select polls accept exactly one choice per ballot. */
+    multi_select BOOLEAN NOT NULL DEFAULT FALSE,
+    opens_at TIMESTAMPTZ NOT NULL,
+    /* Polls without a close date accept ballots forever. */
+    closes_at TIMESTAMPTZ,
+    /* Results are hidden until closes_at, which is then required. */
+    hide_results BOOLEAN NOT NULL DEFAULT FALSE,
+
+    CHECK (closes_at IS NULL OR closes_at > opens_at),
+    CHECK (NOT hide_results OR closes_at IS NOT NULL)
+);
+
+--bun:split
+
+/* A user casts a single ballot per poll, and cannot change it. */
+CREATE TABLE IF NOT EXISTS poll_ballots (
+    poll_id uuid NOT NULL REFERENCES polls (id) ON DELETE CASCADE,
+    user_id uuid NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    /* Positions of the chosen options. */
+    choices INTEGER[] NOT NULL,
+
+    PRIMARY KEY (poll_id, user_id)
+);
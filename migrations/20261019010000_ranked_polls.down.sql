@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS poll_rankings;
+
+--bun:split
+
+ALTER TABLE polls
+    DROP CONSTRAINT IF EXISTS polls_ranked_check,
+    DROP COLUMN IF EXISTS ranked;
@@ -0,0 +1,17 @@
+/* Ranked polls take ordered ballots, tallied with instant runoff. They are always single-select. */
+ALTER TABLE polls
+    ADD COLUMN IF NOT EXISTS ranked BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD CONSTRAINT polls_ranked_check CHECK (NOT (ranked AND multi_select));
+
+--bun:split
+
+/* A user casts a single ranked ballot per poll, and cannot change it. */
+CREATE TABLE IF NOT EXISTS poll_rankings (
+    poll_id uuid NOT NULL REFERENCES polls (id) ON DELETE CASCADE,
+    user_id uuid NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    /* Positions of the ranked options, from the most to the least preferred. */
+    ranking INTEGER[] NOT NULL,
+
+    PRIMARY KEY (poll_id, user_id)
+);
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS vote_flags_pending_fingerprint_idx;
+
+--bun:split
+
+/* Findings flagged again after their review are dropped, so the fingerprints are unique again. */
+DELETE FROM vote_flags AS a
+    USING vote_flags AS b
+    WHERE a.fingerprint = b.fingerprint AND a.created_at > b.created_at;
+
+--bun:split
+
+ALTER TABLE vote_flags ADD CONSTRAINT vote_flags_fingerprint_key UNIQUE (fingerprint);
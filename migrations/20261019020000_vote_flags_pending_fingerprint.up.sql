@@ -0,0 +1,9 @@
+ALTER TABLE vote_flags DROP CONSTRAINT IF EXISTS vote_flags_fingerprint_key;
+
+--bun:split
+
+/*
+    Only one flag of a finding is pending at a time. Reviewed flags keep their fingerprint, so a finding that grows
+    after its review can be flagged again.
+*/
+CREATE UNIQUE INDEX IF NOT EXISTS vote_flags_pending_fingerprint_idx ON vote_flags (fingerprint) WHERE status = 'pending';
@@ -7,38 +7,95 @@ import (
 	"github.com/google/uuid"
 )
 
-func NewImproveRequestVoteClient(client apiclients.ForumClient, permissionsClient apiclients.PermissionsClient) models.CheckVoteClient {
-	return func(ctx context.Context, id, userID uuid.UUID, upVotes, downVotes int) error {
-		if err := permissionsClient.HasUserScope(ctx, apiclients.HasUserScopeQuery{
-			UserID: userID,
-			Scope:  apiclients.CanVotePost,
-		}); err != nil {
-			return err
-		}
-
-		return client.VoteImproveRequest(ctx, apiclients.UpdateImproveRequestVotesForm{
-			ID:        id,
-			UserID:    userID,
-			UpVotes:   upVotes,
-			DownVotes: downVotes,
-		})
+func NewImproveRequestTargetHandler(client apiclients.ForumClient, permissionsClient apiclients.PermissionsClient, scope apiclients.Scope) models.TargetHandler {
+	return &improveRequestTargetHandler{
+		scopeAuthorizer: scopeAuthorizer{permissionsClient: permissionsClient, scope: scope},
+		client:          client,
 	}
 }
 
-func NewImproveSuggestionVoteClient(client apiclients.ForumClient, permissionsClient apiclients.PermissionsClient) models.CheckVoteClient {
-	return func(ctx context.Context, id, userID uuid.UUID, upVotes, downVotes int) error {
-		if err := permissionsClient.HasUserScope(ctx, apiclients.HasUserScopeQuery{
-			UserID: userID,
-			Scope:  apiclients.CanVotePost,
-		}); err != nil {
-			return err
-		}
-
-		return client.VoteImproveSuggestion(ctx, apiclients.UpdateImproveSuggestionVotesForm{
-			ID:        id,
-			UserID:    userID,
-			UpVotes:   upVotes,
-			DownVotes: downVotes,
-		})
+func NewImproveSuggestionTargetHandler(client apiclients.ForumClient, permissionsClient apiclients.PermissionsClient, scope apiclients.Scope) models.TargetHandler {
+	return &improveSuggestionTargetHandler{
+		scopeAuthorizer: scopeAuthorizer{permissionsClient: permissionsClient, scope: scope},
+		client:          client,
 	}
 }
+
+// NewSilentTargetHandler returns a handler for targets that don't need to be notified of their votes.
+func NewSilentTargetHandler(permissionsClient apiclients.PermissionsClient, scope apiclients.Scope) models.TargetHandler {
+	return &silentTargetHandler{
+		scopeAuthorizer: scopeAuthorizer{permissionsClient: permissionsClient, scope: scope},
+	}
+}
+
+// scopeAuthorizer implements the Authorize method shared by the targets, by requiring a permission scope.
+type scopeAuthorizer struct {
+	permissionsClient apiclients.PermissionsClient
+	scope             apiclients.Scope
+}
+
+func (authorizer scopeAuthorizer) Authorize(ctx context.Context, userID, _ uuid.UUID) error {
+	return authorizer.permissionsClient.HasUserScope(ctx, apiclients.HasUserScopeQuery{
+		UserID: userID,
+		Scope:  authorizer.scope,
+	})
+}
+
+// unknownOwner implements the IsOwner method of the targets that cannot tell their authors. The forum client does not
+// expose the authors of the posts, and silent targets have no service to ask.
+type unknownOwner struct{}
+
+func (unknownOwner) IsOwner(_ context.Context, _, _ uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (unknownOwner) ownersUnknown() {}
+
+// ownersUnknownHandler is implemented by the handlers that embed unknownOwner, so the targets that rely on their
+// authors can be rejected at startup.
+type ownersUnknownHandler interface {
+	ownersUnknown()
+}
+
+type improveRequestTargetHandler struct {
+	scopeAuthorizer
+	unknownOwner
+	client apiclients.ForumClient
+}
+
+func (handler *improveRequestTargetHandler) Publish(ctx context.Context, update *models.TargetUpdate) error {
+	upVotes, downVotes := update.Counts()
+
+	return handler.client.VoteImproveRequest(ctx, apiclients.UpdateImproveRequestVotesForm{
+		ID:        update.TargetID,
+		UserID:    update.UserID,
+		UpVotes:   upVotes,
+		DownVotes: downVotes,
+	})
+}
+
+type improveSuggestionTargetHandler struct {
+	scopeAuthorizer
+	unknownOwner
+	client apiclients.ForumClient
+}
+
+func (handler *improveSuggestionTargetHandler) Publish(ctx context.Context, update *models.TargetUpdate) error {
+	upVotes, downVotes := update.Counts()
+
+	return handler.client.VoteImproveSuggestion(ctx, apiclients.UpdateImproveSuggestionVotesForm{
+		ID:        update.TargetID,
+		UserID:    update.UserID,
+		UpVotes:   upVotes,
+		DownVotes: downVotes,
+	})
+}
+
+type silentTargetHandler struct {
+	scopeAuthorizer
+	unknownOwner
+}
+
+func (handler *silentTargetHandler) Publish(_ context.Context, _ *models.TargetUpdate) error {
+	return nil
+}
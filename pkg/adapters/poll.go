@@ -0,0 +1,25 @@
+package adapters
+
+import (
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+)
+
+func PollToModel(src *dao.PollModel) *models.Poll {
+	if src == nil {
+		return nil
+	}
+
+	return &models.Poll{
+		ID:          src.ID,
+		CreatedAt:   src.CreatedAt,
+		CreatedBy:   src.CreatedBy,
+		Question:    src.Question,
+		Options:     src.Options,
+		MultiSelect: src.MultiSelect,
+		OpensAt:     src.OpensAt,
+		ClosesAt:    src.ClosesAt,
+		HideResults: src.HideResults,
+		Ranked:      src.Ranked,
+	}
+}
@@ -0,0 +1,17 @@
+package adapters
+
+import (
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+)
+
+func TargetScoreToModel(src *dao.TargetScoreModel) *models.RankedTarget {
+	if src == nil {
+		return nil
+	}
+
+	return &models.RankedTarget{
+		TargetID: src.TargetID,
+		Score:    src.Score,
+	}
+}
@@ -0,0 +1,163 @@
+package adapters
+
+import (
+	"context"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/samber/lo"
+	"sync"
+	"time"
+)
+
+// NewMemoryRateLimiter returns a limiter that keeps its buckets in memory. Each instance of the service enforces the
+// limits on its own.
+func NewMemoryRateLimiter() models.RateLimiter {
+	return &memoryRateLimiter{buckets: make(map[string]*memoryBucket)}
+}
+
+// NewPostgresRateLimiter returns a limiter that shares its buckets across all the instances of the service.
+func NewPostgresRateLimiter(repository dao.RateLimitsRepository) models.RateLimiter {
+	return &postgresRateLimiter{repository: repository}
+}
+
+// takeToken refills a bucket for the time elapsed since its last update, then consumes a token from it. When the
+// bucket is empty, it returns the delay until the next token is available.
+func takeToken(tokens float64, updatedAt time.Time, limit models.RateLimit, now time.Time) (float64, time.Duration) {
+	if elapsed := now.Sub(updatedAt); elapsed > 0 {
+		tokens += float64(elapsed) / float64(limit.Interval)
+	}
+	if tokens > float64(limit.Burst) {
+		tokens = float64(limit.Burst)
+	}
+
+	if tokens >= 1 {
+		return tokens - 1, 0
+	}
+
+	return tokens, time.Duration((1 - tokens) * float64(limit.Interval))
+}
+
+// fullAt returns the date at which a bucket is refilled completely.
+func fullAt(tokens float64, limit models.RateLimit, now time.Time) time.Time {
+	return now.Add(time.Duration((float64(limit.Burst) - tokens) * float64(limit.Interval)))
+}
+
+type memoryBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time
+}
+
+type memoryRateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+}
+
+// enabledBuckets filters out the buckets with a disabled limit.
+func enabledBuckets(buckets []models.RateLimitBucket) []models.RateLimitBucket {
+	return lo.Filter(buckets, func(bucket models.RateLimitBucket, _ int) bool {
+		return bucket.Limit.Burst > 0
+	})
+}
+
+func (limiter *memoryRateLimiter) Allow(_ context.Context, buckets []models.RateLimitBucket, now time.Time) (time.Duration, error) {
+	buckets = enabledBuckets(buckets)
+	if len(buckets) == 0 {
+		return 0, nil
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	// Take the tokens from every bucket before saving any of them.
+	var retryAfter time.Duration
+	tokens := make([]float64, len(buckets))
+	for i, item := range buckets {
+		bucket, ok := limiter.buckets[item.Key]
+		if !ok {
+			bucket = &memoryBucket{tokens: float64(item.Limit.Burst), updatedAt: now}
+		}
+
+		var delay time.Duration
+		tokens[i], delay = takeToken(bucket.tokens, bucket.updatedAt, item.Limit, now)
+		retryAfter = max(retryAfter, delay)
+	}
+
+	if retryAfter > 0 {
+		return retryAfter, nil
+	}
+
+	for i, item := range buckets {
+		limiter.buckets[item.Key] = &memoryBucket{
+			tokens:    tokens[i],
+			updatedAt: now,
+			fullAt:    fullAt(tokens[i], item.Limit, now),
+		}
+	}
+
+	return 0, nil
+}
+
+func (limiter *memoryRateLimiter) Purge(_ context.Context, now time.Time) error {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	for key, bucket := range limiter.buckets {
+		if !bucket.fullAt.After(now) {
+			delete(limiter.buckets, key)
+		}
+	}
+
+	return nil
+}
+
+type postgresRateLimiter struct {
+	repository dao.RateLimitsRepository
+}
+
+func (limiter *postgresRateLimiter) Allow(ctx context.Context, buckets []models.RateLimitBucket, now time.Time) (time.Duration, error) {
+	buckets = enabledBuckets(buckets)
+	if len(buckets) == 0 {
+		return 0, nil
+	}
+
+	var retryAfter time.Duration
+
+	// A new bucket starts full.
+	initial := lo.Map(buckets, func(bucket models.RateLimitBucket, _ int) *dao.RateLimitBucketModel {
+		return &dao.RateLimitBucketModel{Key: bucket.Key, Tokens: float64(bucket.Limit.Burst), UpdatedAt: now, FullAt: now}
+	})
+
+	err := limiter.repository.Update(ctx, initial, func(items []*dao.RateLimitBucketModel) bool {
+		// Take the tokens from every bucket before updating any of them.
+		tokens := make([]float64, len(items))
+		for i, item := range items {
+			var delay time.Duration
+			tokens[i], delay = takeToken(item.Tokens, item.UpdatedAt, buckets[i].Limit, now)
+			retryAfter = max(retryAfter, delay)
+		}
+
+		if retryAfter > 0 {
+			return false
+		}
+
+		for i, item := range items {
+			item.Tokens = tokens[i]
+			item.UpdatedAt = now
+			item.FullAt = fullAt(tokens[i], buckets[i].Limit, now)
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return retryAfter, nil
+}
+
+func (limiter *postgresRateLimiter) Purge(ctx context.Context, now time.Time) error {
+	_, err := limiter.repository.Purge(ctx, now)
+	return err
+}
@@ -0,0 +1,280 @@
+package adapters_test
+
+import (
+	"context"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var baseTime = time.Date(2020, time.May, 4, 8, 0, 0, 0, time.UTC)
+
+func TestMemoryRateLimiter(t *testing.T) {
+	limit := models.RateLimit{Burst: 2, Interval: 10 * time.Second}
+
+	type step struct {
+		key   string
+		at    time.Duration
+		limit models.RateLimit
+
+		expect time.Duration
+	}
+
+	data := []struct {
+		name string
+
+		steps []step
+	}{
+		{
+			name: "Success/Burst",
+			steps: []step{
+				{key: "a", limit: limit},
+				{key: "a", limit: limit},
+				{key: "a", limit: limit, expect: 10 * time.Second},
+				// Other keys have their own bucket.
+				{key: "b", limit: limit},
+			},
+		},
+		{
+			name: "Success/Refill",
+			steps: []step{
+				{key: "a", limit: limit},
+				{key: "a", limit: limit},
+				{key: "a", at: 4 * time.Second, limit: limit, expect: 6 * time.Second},
+				{key: "a", at: 10 * time.Second, limit: limit},
+				{key: "a", at: 10 * time.Second, limit: limit, expect: 10 * time.Second},
+			},
+		},
+		// Refilling never exceeds the burst.
+		{
+			name: "Success/RefillCapped",
+			steps: []step{
+				{key: "a", limit: limit},
+				{key: "a", at: time.Hour, limit: limit},
+				{key: "a", at: time.Hour, limit: limit},
+				{key: "a", at: time.Hour, limit: limit, expect: 10 * time.Second},
+			},
+		},
+		{
+			name: "Success/Disabled",
+			steps: []step{
+				{key: "a"},
+				{key: "a"},
+				{key: "a"},
+			},
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			limiter := adapters.NewMemoryRateLimiter()
+
+			for i, s := range d.steps {
+				retryAfter, err := limiter.Allow(context.Background(), []models.RateLimitBucket{{Key: s.key, Limit: s.limit}}, baseTime.Add(s.at))
+				require.NoError(t, err)
+				require.Equal(t, s.expect, retryAfter, "step %d", i)
+			}
+		})
+	}
+}
+
+// An action rejected by one of its buckets must not consume the tokens of the others.
+func TestMemoryRateLimiter_SeveralBuckets(t *testing.T) {
+	user := models.RateLimitBucket{Key: "user", Limit: models.RateLimit{Burst: 2, Interval: 10 * time.Second}}
+	target := models.RateLimitBucket{Key: "target", Limit: models.RateLimit{Burst: 1, Interval: 30 * time.Second}}
+	limiter := adapters.NewMemoryRateLimiter()
+
+	retryAfter, err := limiter.Allow(context.Background(), []models.RateLimitBucket{user, target}, baseTime)
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+
+	retryAfter, err = limiter.Allow(context.Background(), []models.RateLimitBucket{user, target}, baseTime)
+	require.NoError(t, err)
+	require.Equal(t, 30*time.Second, retryAfter)
+
+	// The rejected action left a token in the bucket of the user.
+	retryAfter, err = limiter.Allow(context.Background(), []models.RateLimitBucket{user}, baseTime)
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+
+	retryAfter, err = limiter.Allow(context.Background(), []models.RateLimitBucket{user}, baseTime)
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Second, retryAfter)
+}
+
+func TestMemoryRateLimiter_Purge(t *testing.T) {
+	limit := []models.RateLimitBucket{{Key: "a", Limit: models.RateLimit{Burst: 2, Interval: 10 * time.Second}}}
+	limiter := adapters.NewMemoryRateLimiter()
+
+	for i := 0; i < 2; i++ {
+		retryAfter, err := limiter.Allow(context.Background(), limit, baseTime)
+		require.NoError(t, err)
+		require.Zero(t, retryAfter)
+	}
+
+	// The bucket is not full yet, so it must be kept.
+	require.NoError(t, limiter.Purge(context.Background(), baseTime.Add(10*time.Second)))
+
+	retryAfter, err := limiter.Allow(context.Background(), limit, baseTime.Add(10*time.Second))
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+
+	retryAfter, err = limiter.Allow(context.Background(), limit, baseTime.Add(10*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Second, retryAfter)
+}
+
+func TestPostgresRateLimiter(t *testing.T) {
+	limit := models.RateLimit{Burst: 2, Interval: 10 * time.Second}
+
+	data := []struct {
+		name string
+
+		limit models.RateLimit
+
+		shouldCallDAO bool
+		bucket        *dao.RateLimitBucketModel
+		daoErr        error
+
+		expect       time.Duration
+		expectSaved  bool
+		expectBucket *dao.RateLimitBucketModel
+		expectErr    error
+	}{
+		{
+			name:          "Success/NewBucket",
+			limit:         limit,
+			shouldCallDAO: true,
+			bucket:        &dao.RateLimitBucketModel{Key: "a", Tokens: 2, UpdatedAt: baseTime, FullAt: baseTime},
+			expectSaved:   true,
+			expectBucket: &dao.RateLimitBucketModel{
+				Key:       "a",
+				Tokens:    1,
+				UpdatedAt: baseTime,
+				FullAt:    baseTime.Add(10 * time.Second),
+			},
+		},
+		{
+			name:          "Success/Refill",
+			limit:         limit,
+			shouldCallDAO: true,
+			bucket: &dao.RateLimitBucketModel{
+				Key:       "a",
+				Tokens:    0.5,
+				UpdatedAt: baseTime.Add(-5 * time.Second),
+				FullAt:    baseTime.Add(10 * time.Second),
+			},
+			expectSaved: true,
+			expectBucket: &dao.RateLimitBucketModel{
+				Key:       "a",
+				Tokens:    0,
+				UpdatedAt: baseTime,
+				FullAt:    baseTime.Add(20 * time.Second),
+			},
+		},
+		{
+			name:          "Success/Empty",
+			limit:         limit,
+			shouldCallDAO: true,
+			bucket: &dao.RateLimitBucketModel{
+				Key:       "a",
+				Tokens:    0,
+				UpdatedAt: baseTime.Add(-4 * time.Second),
+				FullAt:    baseTime.Add(16 * time.Second),
+			},
+			expect: 6 * time.Second,
+			expectBucket: &dao.RateLimitBucketModel{
+				Key:       "a",
+				Tokens:    0,
+				UpdatedAt: baseTime.Add(-4 * time.Second),
+				FullAt:    baseTime.Add(16 * time.Second),
+			},
+		},
+		{
+			name: "Success/Disabled",
+		},
+		{
+			name:          "Error/DAOFailure",
+			limit:         limit,
+			shouldCallDAO: true,
+			bucket:        &dao.RateLimitBucketModel{Key: "a", Tokens: 2, UpdatedAt: baseTime, FullAt: baseTime},
+			daoErr:        fooErr,
+			expectSaved:   true,
+			expectBucket: &dao.RateLimitBucketModel{
+				Key:       "a",
+				Tokens:    1,
+				UpdatedAt: baseTime,
+				FullAt:    baseTime.Add(10 * time.Second),
+			},
+			expectErr: fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewRateLimitsRepository(t)
+
+			if d.shouldCallDAO {
+				repository.
+					On("Update", context.Background(), []*dao.RateLimitBucketModel{
+						{Key: "a", Tokens: float64(d.limit.Burst), UpdatedAt: baseTime, FullAt: baseTime},
+					}, mock.Anything).
+					Run(func(args mock.Arguments) {
+						update := args.Get(2).(func(buckets []*dao.RateLimitBucketModel) bool)
+						require.Equal(t, d.expectSaved, update([]*dao.RateLimitBucketModel{d.bucket}))
+						require.Equal(t, d.expectBucket, d.bucket)
+					}).
+					Return(d.daoErr)
+			}
+
+			limiter := adapters.NewPostgresRateLimiter(repository)
+			retryAfter, err := limiter.Allow(context.Background(), []models.RateLimitBucket{{Key: "a", Limit: d.limit}}, baseTime)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, retryAfter)
+
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+// When a bucket is empty, the other buckets of the action are left untouched.
+func TestPostgresRateLimiter_SeveralBuckets(t *testing.T) {
+	repository := daomocks.NewRateLimitsRepository(t)
+
+	user := &dao.RateLimitBucketModel{Key: "user", Tokens: 2, UpdatedAt: baseTime, FullAt: baseTime}
+	target := &dao.RateLimitBucketModel{
+		Key:       "target",
+		Tokens:    0,
+		UpdatedAt: baseTime.Add(-10 * time.Second),
+		FullAt:    baseTime.Add(20 * time.Second),
+	}
+
+	repository.
+		On("Update", context.Background(), []*dao.RateLimitBucketModel{
+			{Key: "user", Tokens: 2, UpdatedAt: baseTime, FullAt: baseTime},
+			{Key: "target", Tokens: 1, UpdatedAt: baseTime, FullAt: baseTime},
+		}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			update := args.Get(2).(func(buckets []*dao.RateLimitBucketModel) bool)
+			require.False(t, update([]*dao.RateLimitBucketModel{user, target}))
+		}).
+		Return(nil)
+
+	limiter := adapters.NewPostgresRateLimiter(repository)
+	retryAfter, err := limiter.Allow(context.Background(), []models.RateLimitBucket{
+		{Key: "user", Limit: models.RateLimit{Burst: 2, Interval: 10 * time.Second}},
+		{Key: "target", Limit: models.RateLimit{Burst: 1, Interval: 30 * time.Second}},
+	}, baseTime)
+
+	require.NoError(t, err)
+	require.Equal(t, 20*time.Second, retryAfter)
+	require.Equal(t, &dao.RateLimitBucketModel{Key: "user", Tokens: 2, UpdatedAt: baseTime, FullAt: baseTime}, user)
+
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,20 @@
+package adapters
+
+import (
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+)
+
+func TargetLockToModel(src *dao.TargetLockModel) *models.TargetLock {
+	if src == nil {
+		return nil
+	}
+
+	return &models.TargetLock{
+		TargetID:  src.TargetID,
+		Target:    src.Target,
+		CreatedAt: src.CreatedAt,
+		LockedBy:  src.LockedBy,
+		ClosesAt:  src.ClosesAt,
+	}
+}
@@ -0,0 +1,101 @@
+package adapters
+
+import (
+	goerrors "errors"
+	"fmt"
+	apiclients "github.com/a-novel/go-apis/clients"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/samber/lo"
+)
+
+var ErrInvalidTargetDefinition = goerrors.New("invalid target definition")
+
+// TargetDefinition declares an entity users can vote on.
+type TargetDefinition struct {
+	Name string
+	// Scope is the permission required to vote on the target.
+	Scope  apiclients.Scope
+	Values []models.VoteValue
+	// Rating makes the target rated rather than voted on. Values must then be empty.
+	Rating *models.RatingScale
+	// CallbackKind selects the TargetHandlerFactory used to build the handler of the target.
+	CallbackKind   string
+	CallbackURL    string
+	CallbackSecret string
+	Open           bool
+	// AuthorsSeeVoters lets the authors of the target see who voted on it, besides the moderators. It requires a
+	// callback kind that can tell the authors of the target, such as webhook.
+	AuthorsSeeVoters bool
+	// WeightResolver is optional. When set, the votes on the target are weighted.
+	WeightResolver models.WeightResolver
+}
+
+// TargetHandlerFactory builds the handler of a target, for a given callback kind.
+type TargetHandlerFactory func(definition TargetDefinition) (models.TargetHandler, error)
+
+// NewTargetsRegistry validates the targets definitions, and resolves them into targets indexed by name.
+func NewTargetsRegistry(definitions []TargetDefinition, factories map[string]TargetHandlerFactory) (map[string]*models.Target, error) {
+	registry := make(map[string]*models.Target, len(definitions))
+
+	for i, definition := range definitions {
+		if definition.Name == "" {
+			return nil, fmt.Errorf("%w: target %d has no name", ErrInvalidTargetDefinition, i)
+		}
+		if _, ok := registry[definition.Name]; ok {
+			return nil, fmt.Errorf("%w: target %q is declared more than once", ErrInvalidTargetDefinition, definition.Name)
+		}
+		if definition.Scope == "" {
+			return nil, fmt.Errorf("%w: target %q has no scope", ErrInvalidTargetDefinition, definition.Name)
+		}
+
+		values := lo.Uniq(definition.Values)
+		if definition.Rating != nil {
+			if len(values) > 0 {
+				return nil, fmt.Errorf("%w: rated target %q cannot have vote values", ErrInvalidTargetDefinition, definition.Name)
+			}
+			if definition.Rating.Min >= definition.Rating.Max {
+				return nil, fmt.Errorf("%w: target %q has an empty rating scale", ErrInvalidTargetDefinition, definition.Name)
+			}
+
+			values = []models.VoteValue{models.VoteValueRating}
+		} else if lo.Contains(values, models.VoteValueRating) {
+			// Ratings carry a score, that only rated targets accept.
+			return nil, fmt.Errorf("%w: target %q cannot use the %q value without a rating scale", ErrInvalidTargetDefinition, definition.Name, models.VoteValueRating)
+		}
+
+		if len(values) == 0 {
+			return nil, fmt.Errorf("%w: target %q has no vote values", ErrInvalidTargetDefinition, definition.Name)
+		}
+		for _, value := range values {
+			if !value.IsValid() {
+				return nil, fmt.Errorf("%w: target %q has an invalid vote value %q", ErrInvalidTargetDefinition, definition.Name, value)
+			}
+		}
+
+		factory, ok := factories[definition.CallbackKind]
+		if !ok {
+			return nil, fmt.Errorf("%w: target %q has an unknown callback kind %q", ErrInvalidTargetDefinition, definition.Name, definition.CallbackKind)
+		}
+
+		handler, err := factory(definition)
+		if err != nil {
+			return nil, goerrors.Join(fmt.Errorf("%w: target %q", ErrInvalidTargetDefinition, definition.Name), err)
+		}
+
+		if _, ok := handler.(ownersUnknownHandler); ok && definition.AuthorsSeeVoters {
+			return nil, fmt.Errorf("%w: callback kind %q of target %q cannot tell the authors who see the voters", ErrInvalidTargetDefinition, definition.CallbackKind, definition.Name)
+		}
+
+		registry[definition.Name] = &models.Target{
+			Name:             definition.Name,
+			Values:           values,
+			Rating:           definition.Rating,
+			Open:             definition.Open,
+			Handler:          handler,
+			WeightResolver:   definition.WeightResolver,
+			AuthorsSeeVoters: definition.AuthorsSeeVoters,
+		}
+	}
+
+	return registry, nil
+}
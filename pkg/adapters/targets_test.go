@@ -0,0 +1,206 @@
+package adapters_test
+
+import (
+	"fmt"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+var fooErr = fmt.Errorf("foo")
+
+func TestNewTargetsRegistry(t *testing.T) {
+	permissionsClient := apiclientsmocks.NewPermissionsClient(t)
+
+	factories := map[string]adapters.TargetHandlerFactory{
+		"none": func(definition adapters.TargetDefinition) (models.TargetHandler, error) {
+			return adapters.NewSilentTargetHandler(permissionsClient, definition.Scope), nil
+		},
+		"webhook": func(definition adapters.TargetDefinition) (models.TargetHandler, error) {
+			return adapters.NewWebhookTargetHandler(permissionsClient, definition.Scope, http.DefaultClient, adapters.WebhookConfig{
+				URL:         "https://example.com/votes",
+				Secret:      "secret",
+				MaxAttempts: 1,
+			})
+		},
+		"broken": func(definition adapters.TargetDefinition) (models.TargetHandler, error) {
+			return nil, fooErr
+		},
+	}
+
+	data := []struct {
+		name string
+
+		definitions []adapters.TargetDefinition
+
+		expect    map[string][]models.VoteValue
+		expectErr error
+	}{
+		{
+			name: "Success",
+			definitions: []adapters.TargetDefinition{
+				{
+					Name:         "comment",
+					Scope:        apiclients.CanVotePost,
+					Values:       []models.VoteValue{models.VoteValueUp, models.VoteValueDown},
+					CallbackKind: "none",
+					Open:         true,
+				},
+				{
+					Name:         "chapter",
+					Scope:        apiclients.CanVotePost,
+					Values:       []models.VoteValue{models.VoteValueUp, models.VoteValueUp},
+					CallbackKind: "none",
+				},
+				{
+					Name:         "review",
+					Scope:        apiclients.CanVotePost,
+					Values:       []models.VoteValue{"helpful", "funny", "insightful"},
+					CallbackKind: "none",
+				},
+				{
+					Name:         "story",
+					Scope:        apiclients.CanVotePost,
+					Rating:       &models.RatingScale{Min: 1, Max: 5},
+					CallbackKind: "none",
+				},
+				{
+					Name:             "answer",
+					Scope:            apiclients.CanVotePost,
+					Values:           []models.VoteValue{models.VoteValueUp, models.VoteValueDown},
+					CallbackKind:     "webhook",
+					AuthorsSeeVoters: true,
+				},
+			},
+			expect: map[string][]models.VoteValue{
+				"comment": {models.VoteValueUp, models.VoteValueDown},
+				"chapter": {models.VoteValueUp},
+				"review":  {"helpful", "funny", "insightful"},
+				"story":   {models.VoteValueRating},
+				"answer":  {models.VoteValueUp, models.VoteValueDown},
+			},
+		},
+		{
+			name:   "Success/Empty",
+			expect: map[string][]models.VoteValue{},
+		},
+		{
+			name: "Error/MissingName",
+			definitions: []adapters.TargetDefinition{
+				{Scope: apiclients.CanVotePost, Values: []models.VoteValue{models.VoteValueUp}, CallbackKind: "none"},
+			},
+			expectErr: adapters.ErrInvalidTargetDefinition,
+		},
+		{
+			name: "Error/DuplicateName",
+			definitions: []adapters.TargetDefinition{
+				{Name: "comment", Scope: apiclients.CanVotePost, Values: []models.VoteValue{models.VoteValueUp}, CallbackKind: "none"},
+				{Name: "comment", Scope: apiclients.CanVotePost, Values: []models.VoteValue{models.VoteValueUp}, CallbackKind: "none"},
+			},
+			expectErr: adapters.ErrInvalidTargetDefinition,
+		},
+		{
+			name: "Error/MissingScope",
+			definitions: []adapters.TargetDefinition{
+				{Name: "comment", Values: []models.VoteValue{models.VoteValueUp}, CallbackKind: "none"},
+			},
+			expectErr: adapters.ErrInvalidTargetDefinition,
+		},
+		{
+			name: "Error/MissingValues",
+			definitions: []adapters.TargetDefinition{
+				{Name: "comment", Scope: apiclients.CanVotePost, CallbackKind: "none"},
+			},
+			expectErr: adapters.ErrInvalidTargetDefinition,
+		},
+		{
+			name: "Error/InvalidValue",
+			definitions: []adapters.TargetDefinition{
+				{Name: "comment", Scope: apiclients.CanVotePost, Values: []models.VoteValue{"Side ways"}, CallbackKind: "none"},
+			},
+			expectErr: adapters.ErrInvalidTargetDefinition,
+		},
+		{
+			name: "Error/RatedTargetWithValues",
+			definitions: []adapters.TargetDefinition{
+				{
+					Name:         "story",
+					Scope:        apiclients.CanVotePost,
+					Values:       []models.VoteValue{models.VoteValueUp},
+					Rating:       &models.RatingScale{Min: 1, Max: 5},
+					CallbackKind: "none",
+				},
+			},
+			expectErr: adapters.ErrInvalidTargetDefinition,
+		},
+		{
+			name: "Error/RatingValueWithoutScale",
+			definitions: []adapters.TargetDefinition{
+				{
+					Name:         "comment",
+					Scope:        apiclients.CanVotePost,
+					Values:       []models.VoteValue{models.VoteValueUp, models.VoteValueRating},
+					CallbackKind: "none",
+				},
+			},
+			expectErr: adapters.ErrInvalidTargetDefinition,
+		},
+		{
+			name: "Error/EmptyRatingScale",
+			definitions: []adapters.TargetDefinition{
+				{Name: "story", Scope: apiclients.CanVotePost, Rating: &models.RatingScale{Min: 5, Max: 5}, CallbackKind: "none"},
+			},
+			expectErr: adapters.ErrInvalidTargetDefinition,
+		},
+		{
+			name: "Error/UnknownCallbackKind",
+			definitions: []adapters.TargetDefinition{
+				{Name: "comment", Scope: apiclients.CanVotePost, Values: []models.VoteValue{models.VoteValueUp}, CallbackKind: "carrier-pigeon"},
+			},
+			expectErr: adapters.ErrInvalidTargetDefinition,
+		},
+		{
+			name: "Error/AuthorsSeeVotersWithoutOwners",
+			definitions: []adapters.TargetDefinition{
+				{
+					Name:             "comment",
+					Scope:            apiclients.CanVotePost,
+					Values:           []models.VoteValue{models.VoteValueUp},
+					CallbackKind:     "none",
+					AuthorsSeeVoters: true,
+				},
+			},
+			expectErr: adapters.ErrInvalidTargetDefinition,
+		},
+		{
+			name: "Error/FactoryFailure",
+			definitions: []adapters.TargetDefinition{
+				{Name: "comment", Scope: apiclients.CanVotePost, Values: []models.VoteValue{models.VoteValueUp}, CallbackKind: "broken"},
+			},
+			expectErr: fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			res, err := adapters.NewTargetsRegistry(d.definitions, factories)
+			require.ErrorIs(t, err, d.expectErr)
+
+			if d.expect == nil {
+				require.Nil(t, res)
+				return
+			}
+
+			require.Len(t, res, len(d.expect))
+			for name, values := range d.expect {
+				require.Equal(t, name, res[name].Name)
+				require.Equal(t, values, res[name].Values)
+				require.NotNil(t, res[name].Handler)
+			}
+		})
+	}
+}
@@ -17,6 +17,9 @@ func VoteToModel(src *dao.VoteModel) *models.Vote {
 		UserID:    src.UserID,
 		TargetID:  src.TargetID,
 		Target:    src.Target,
+		Score:     src.Score,
 		UpdatedAt: lo.Ternary(src.UpdatedAt == nil, src.CreatedAt, lo.FromPtr(src.UpdatedAt)),
+
+		InvalidatedAt: src.InvalidatedAt,
 	}
 }
@@ -0,0 +1,25 @@
+package adapters
+
+import (
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+)
+
+func VoteEventToModel(src *dao.VoteEventModel) *models.VoteEvent {
+	if src == nil {
+		return nil
+	}
+
+	return &models.VoteEvent{
+		ID:        src.ID,
+		CreatedAt: src.CreatedAt,
+		Event:     src.Event,
+		UserID:    src.UserID,
+		TargetID:  src.TargetID,
+		Target:    src.Target,
+		OldVote:   src.OldVote,
+		NewVote:   src.NewVote,
+		OldScore:  src.OldScore,
+		NewScore:  src.NewScore,
+	}
+}
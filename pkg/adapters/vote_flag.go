@@ -0,0 +1,25 @@
+package adapters
+
+import (
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+)
+
+func VoteFlagToModel(src *dao.VoteFlagModel) *models.VoteFlag {
+	if src == nil {
+		return nil
+	}
+
+	return &models.VoteFlag{
+		ID:          src.ID,
+		CreatedAt:   src.CreatedAt,
+		Kind:        src.Kind,
+		Target:      src.Target,
+		TargetIDs:   src.TargetIDs,
+		UserIDs:     src.UserIDs,
+		Occurrences: src.Occurrences,
+		Status:      src.Status,
+		ReviewedBy:  src.ReviewedBy,
+		ReviewedAt:  src.ReviewedAt,
+	}
+}
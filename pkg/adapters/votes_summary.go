@@ -11,7 +11,33 @@ func VotesSummaryToModel(src *dao.VotesSummaryModel) *models.VotesSummary {
 	}
 
 	return &models.VotesSummary{
-		UpVotes:   src.UpVotes,
-		DownVotes: src.DownVotes,
+		UpVotes:           src.UpVotes,
+		DownVotes:         src.DownVotes,
+		WeightedUpVotes:   src.WeightedUpVotes,
+		WeightedDownVotes: src.WeightedDownVotes,
+		Counts:            src.Counts,
+		Rating:            RatingsToModel(src.Ratings),
 	}
 }
+
+// RatingsToModel computes the rating summary of a target from the number of ratings for each score. It returns nil
+// when the target has no rating.
+func RatingsToModel(histogram map[int]int) *models.RatingSummary {
+	if len(histogram) == 0 {
+		return nil
+	}
+
+	summary := &models.RatingSummary{Histogram: histogram}
+
+	var sum int
+	for score, count := range histogram {
+		summary.Count += count
+		sum += score * count
+	}
+
+	if summary.Count > 0 {
+		summary.Average = float64(sum) / float64(summary.Count)
+	}
+
+	return summary
+}
@@ -0,0 +1,235 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	apiclients "github.com/a-novel/go-apis/clients"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// WebhookEventVotesUpdated notifies the webhook of the new counters of a target.
+	WebhookEventVotesUpdated = "votes.updated"
+	// WebhookEventOwnerCheck asks the webhook whether a user is the author of a target. The webhook must answer with
+	// a WebhookOwnerResponse.
+	WebhookEventOwnerCheck = "owner.check"
+
+	WebhookEventHeader     = "X-Votes-Event"
+	WebhookTimestampHeader = "X-Votes-Timestamp"
+	// WebhookSignatureHeader holds the hex encoded HMAC-SHA256 of "<timestamp>.<body>", prefixed with "sha256=".
+	WebhookSignatureHeader = "X-Votes-Signature"
+)
+
+var (
+	ErrInvalidWebhookConfig = goerrors.New("invalid webhook configuration")
+	ErrWebhookRejected      = goerrors.New("webhook rejected the payload")
+)
+
+type WebhookConfig struct {
+	URL string
+	// Secret signs the payloads, so the receiver can authenticate them.
+	Secret string
+	// Timeout applies to each delivery attempt.
+	Timeout time.Duration
+	// MaxAttempts is the number of deliveries attempted before giving up. Only network errors, 429 and 5xx responses
+	// are retried.
+	MaxAttempts int
+	// Backoff is the delay before the first retry. It doubles after each attempt.
+	Backoff time.Duration
+}
+
+// WebhookPayload is the JSON body sent to webhooks.
+type WebhookPayload struct {
+	Event    string    `json:"event"`
+	Target   string    `json:"target"`
+	TargetID uuid.UUID `json:"targetID"`
+	UserID   uuid.UUID `json:"userID"`
+	// UpVotes and DownVotes are weighted when Weighted is true.
+	UpVotes   int  `json:"upVotes"`
+	DownVotes int  `json:"downVotes"`
+	Weighted  bool `json:"weighted"`
+	// Counts gives the number of votes for each value of the target, so targets with other values than up and down
+	// can be notified too.
+	Counts map[models.VoteValue]int `json:"counts,omitempty"`
+	// Rating is only set on rated targets.
+	Rating *models.RatingSummary `json:"rating,omitempty"`
+}
+
+// WebhookOwnerPayload is the JSON body sent to webhooks to check the author of a target.
+type WebhookOwnerPayload struct {
+	Event    string    `json:"event"`
+	TargetID uuid.UUID `json:"targetID"`
+	UserID   uuid.UUID `json:"userID"`
+}
+
+// WebhookOwnerResponse is the JSON body webhooks answer to WebhookEventOwnerCheck events.
+type WebhookOwnerResponse struct {
+	Owner bool `json:"owner"`
+}
+
+// NewWebhookTargetHandler returns a handler that notifies the target by sending a signed payload to a URL.
+func NewWebhookTargetHandler(
+	permissionsClient apiclients.PermissionsClient, scope apiclients.Scope, httpClient *http.Client, config WebhookConfig,
+) (models.TargetHandler, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("%w: missing url", ErrInvalidWebhookConfig)
+	}
+	if config.Secret == "" {
+		return nil, fmt.Errorf("%w: missing secret", ErrInvalidWebhookConfig)
+	}
+	if config.MaxAttempts < 1 {
+		return nil, fmt.Errorf("%w: max attempts must be at least 1", ErrInvalidWebhookConfig)
+	}
+
+	return &webhookTargetHandler{
+		scopeAuthorizer: scopeAuthorizer{permissionsClient: permissionsClient, scope: scope},
+		httpClient:      httpClient,
+		config:          config,
+	}, nil
+}
+
+// SignWebhookPayload computes the value of the WebhookSignatureHeader.
+func SignWebhookPayload(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type webhookTargetHandler struct {
+	scopeAuthorizer
+	httpClient *http.Client
+	config     WebhookConfig
+}
+
+func (handler *webhookTargetHandler) Publish(ctx context.Context, update *models.TargetUpdate) error {
+	upVotes, downVotes := update.Counts()
+
+	body, err := json.Marshal(WebhookPayload{
+		Event:     WebhookEventVotesUpdated,
+		Target:    update.Target,
+		TargetID:  update.TargetID,
+		UserID:    update.UserID,
+		UpVotes:   upVotes,
+		DownVotes: downVotes,
+		Weighted:  update.Weighted,
+		Counts:    update.Summary.Counts,
+		Rating:    update.Summary.Rating,
+	})
+	if err != nil {
+		return err
+	}
+
+	backoff := handler.config.Backoff
+
+	for attempt := 1; ; attempt++ {
+		retry, err := handler.deliver(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= handler.config.MaxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return goerrors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// IsOwner asks the webhook whether the user is the author of the target. Unlike Publish, it is not retried, as a user
+// waits for the answer.
+func (handler *webhookTargetHandler) IsOwner(ctx context.Context, userID, targetID uuid.UUID) (bool, error) {
+	body, err := json.Marshal(WebhookOwnerPayload{
+		Event:    WebhookEventOwnerCheck,
+		TargetID: targetID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := handler.withTimeout(ctx)
+	defer cancel()
+
+	res, _, err := handler.send(ctx, WebhookEventOwnerCheck, body)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	response := new(WebhookOwnerResponse)
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
+		return false, goerrors.Join(ErrWebhookRejected, err)
+	}
+
+	return response.Owner, nil
+}
+
+// deliver performs a single delivery attempt of the votes. It reports whether a failed attempt is worth retrying.
+func (handler *webhookTargetHandler) deliver(ctx context.Context, body []byte) (bool, error) {
+	ctx, cancel := handler.withTimeout(ctx)
+	defer cancel()
+
+	res, retry, err := handler.send(ctx, WebhookEventVotesUpdated, body)
+	if err != nil {
+		return retry, err
+	}
+
+	_ = res.Body.Close()
+	return false, nil
+}
+
+func (handler *webhookTargetHandler) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if handler.config.Timeout > 0 {
+		return context.WithTimeout(ctx, handler.config.Timeout)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// send signs and sends an event to the webhook. On success, the caller must close the body of the response. Otherwise,
+// it reports whether the failed request is worth retrying.
+func (handler *webhookTargetHandler) send(ctx context.Context, event string, body []byte) (*http.Response, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, handler.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The timestamp is signed along with the body, so receivers can reject replayed payloads.
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(handler.config.Secret, timestamp, body))
+
+	res, err := handler.httpClient.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return res, false, nil
+	}
+	_ = res.Body.Close()
+
+	retry := res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+	return nil, retry, fmt.Errorf("%w: unexpected status %d", ErrWebhookRejected, res.StatusCode)
+}
@@ -0,0 +1,258 @@
+package adapters_test
+
+import (
+	"context"
+	"encoding/json"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookTargetHandler_Publish(t *testing.T) {
+	update := &models.TargetUpdate{
+		Target:   "comment",
+		TargetID: goframework.NumberUUID(1),
+		UserID:   goframework.NumberUUID(100),
+		Summary:  &models.VotesSummary{UpVotes: 10, DownVotes: 5},
+	}
+
+	data := []struct {
+		name string
+
+		// Status returned by the server for each attempt. The last status is repeated.
+		statuses []int
+		delay    time.Duration
+
+		timeout     time.Duration
+		maxAttempts int
+
+		expectAttempts int32
+		expectErr      error
+	}{
+		{
+			name:           "Success",
+			statuses:       []int{http.StatusOK},
+			maxAttempts:    3,
+			expectAttempts: 1,
+		},
+		{
+			name:           "Success/Retry",
+			statuses:       []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusNoContent},
+			maxAttempts:    3,
+			expectAttempts: 3,
+		},
+		{
+			name:           "Error/TooManyAttempts",
+			statuses:       []int{http.StatusInternalServerError},
+			maxAttempts:    3,
+			expectAttempts: 3,
+			expectErr:      adapters.ErrWebhookRejected,
+		},
+		{
+			name:           "Error/NoRetryOnClientError",
+			statuses:       []int{http.StatusBadRequest},
+			maxAttempts:    3,
+			expectAttempts: 1,
+			expectErr:      adapters.ErrWebhookRejected,
+		},
+		{
+			name:           "Error/Timeout",
+			statuses:       []int{http.StatusOK},
+			delay:          100 * time.Millisecond,
+			timeout:        10 * time.Millisecond,
+			maxAttempts:    2,
+			expectAttempts: 2,
+			expectErr:      context.DeadlineExceeded,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			var attempts atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := attempts.Add(1)
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				require.Equal(t, adapters.WebhookEventVotesUpdated, r.Header.Get(adapters.WebhookEventHeader))
+				require.Equal(
+					t,
+					adapters.SignWebhookPayload("secret", r.Header.Get(adapters.WebhookTimestampHeader), body),
+					r.Header.Get(adapters.WebhookSignatureHeader),
+				)
+
+				payload := new(adapters.WebhookPayload)
+				require.NoError(t, json.Unmarshal(body, payload))
+				require.Equal(t, &adapters.WebhookPayload{
+					Event:     adapters.WebhookEventVotesUpdated,
+					Target:    "comment",
+					TargetID:  goframework.NumberUUID(1),
+					UserID:    goframework.NumberUUID(100),
+					UpVotes:   10,
+					DownVotes: 5,
+				}, payload)
+
+				if d.delay > 0 {
+					select {
+					case <-time.After(d.delay):
+					case <-r.Context().Done():
+						return
+					}
+				}
+
+				w.WriteHeader(d.statuses[min(int(attempt), len(d.statuses))-1])
+			}))
+			defer server.Close()
+
+			handler, err := adapters.NewWebhookTargetHandler(
+				apiclientsmocks.NewPermissionsClient(t), apiclients.CanVotePost, server.Client(), adapters.WebhookConfig{
+					URL:         server.URL,
+					Secret:      "secret",
+					Timeout:     d.timeout,
+					MaxAttempts: d.maxAttempts,
+					Backoff:     time.Millisecond,
+				},
+			)
+			require.NoError(t, err)
+
+			err = handler.Publish(context.Background(), update)
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expectAttempts, attempts.Load())
+		})
+	}
+}
+
+func TestWebhookTargetHandler_IsOwner(t *testing.T) {
+	data := []struct {
+		name string
+
+		status int
+		body   string
+
+		expect    bool
+		expectErr error
+	}{
+		{
+			name:   "Success",
+			status: http.StatusOK,
+			body:   `{"owner":true}`,
+			expect: true,
+		},
+		{
+			name:   "Success/NotOwner",
+			status: http.StatusOK,
+			body:   `{"owner":false}`,
+		},
+		{
+			name:      "Error/InvalidResponse",
+			status:    http.StatusOK,
+			body:      `not json`,
+			expectErr: adapters.ErrWebhookRejected,
+		},
+		{
+			name:      "Error/Rejected",
+			status:    http.StatusServiceUnavailable,
+			expectErr: adapters.ErrWebhookRejected,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			var attempts atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				require.Equal(t, adapters.WebhookEventOwnerCheck, r.Header.Get(adapters.WebhookEventHeader))
+				require.Equal(
+					t,
+					adapters.SignWebhookPayload("secret", r.Header.Get(adapters.WebhookTimestampHeader), body),
+					r.Header.Get(adapters.WebhookSignatureHeader),
+				)
+
+				payload := new(adapters.WebhookOwnerPayload)
+				require.NoError(t, json.Unmarshal(body, payload))
+				require.Equal(t, &adapters.WebhookOwnerPayload{
+					Event:    adapters.WebhookEventOwnerCheck,
+					TargetID: goframework.NumberUUID(1),
+					UserID:   goframework.NumberUUID(100),
+				}, payload)
+
+				w.WriteHeader(d.status)
+				_, _ = w.Write([]byte(d.body))
+			}))
+			defer server.Close()
+
+			handler, err := adapters.NewWebhookTargetHandler(
+				apiclientsmocks.NewPermissionsClient(t), apiclients.CanVotePost, server.Client(), adapters.WebhookConfig{
+					URL:         server.URL,
+					Secret:      "secret",
+					MaxAttempts: 3,
+					Backoff:     time.Millisecond,
+				},
+			)
+			require.NoError(t, err)
+
+			owner, err := handler.IsOwner(context.Background(), goframework.NumberUUID(100), goframework.NumberUUID(1))
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, owner)
+			// The user waits for the answer, so the request is never retried.
+			require.Equal(t, int32(1), attempts.Load())
+		})
+	}
+}
+
+func TestNewWebhookTargetHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		config adapters.WebhookConfig
+
+		expectErr error
+	}{
+		{
+			name:   "Success",
+			config: adapters.WebhookConfig{URL: "http://localhost", Secret: "secret", MaxAttempts: 1},
+		},
+		{
+			name:      "Error/MissingURL",
+			config:    adapters.WebhookConfig{Secret: "secret", MaxAttempts: 1},
+			expectErr: adapters.ErrInvalidWebhookConfig,
+		},
+		{
+			name:      "Error/MissingSecret",
+			config:    adapters.WebhookConfig{URL: "http://localhost", MaxAttempts: 1},
+			expectErr: adapters.ErrInvalidWebhookConfig,
+		},
+		{
+			name:      "Error/NoAttempts",
+			config:    adapters.WebhookConfig{URL: "http://localhost", Secret: "secret"},
+			expectErr: adapters.ErrInvalidWebhookConfig,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			_, err := adapters.NewWebhookTargetHandler(
+				apiclientsmocks.NewPermissionsClient(t), apiclients.CanVotePost, http.DefaultClient, d.config,
+			)
+			require.ErrorIs(t, err, d.expectErr)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package adapters
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	apiclients "github.com/a-novel/go-apis/clients"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidWeightsConfig = goerrors.New("invalid weights configuration")
+
+// ScopeWeight gives a weight to the votes of the users granted a permission scope.
+type ScopeWeight struct {
+	Scope  apiclients.Scope
+	Weight int
+}
+
+// NewScopeWeightResolver returns a resolver that weighs the votes of a user by their permissions. The weights are
+// checked in order, and the first scope granted to the user wins, so they should be listed from the most to the
+// least privileged. Users with none of the scopes vote with models.DefaultVoteWeight. When a scope cannot be checked,
+// the weight is not resolved, rather than recording a privileged vote with the default weight.
+func NewScopeWeightResolver(permissionsClient apiclients.PermissionsClient, weights []ScopeWeight) (models.WeightResolver, error) {
+	if len(weights) == 0 {
+		return nil, fmt.Errorf("%w: no weights", ErrInvalidWeightsConfig)
+	}
+
+	for i, weight := range weights {
+		if weight.Scope == "" {
+			return nil, fmt.Errorf("%w: weight %d has no scope", ErrInvalidWeightsConfig, i)
+		}
+		if weight.Weight < 1 {
+			return nil, fmt.Errorf("%w: weight of scope %q must be at least 1", ErrInvalidWeightsConfig, weight.Scope)
+		}
+	}
+
+	return &scopeWeightResolver{permissionsClient: permissionsClient, weights: weights}, nil
+}
+
+type scopeWeightResolver struct {
+	permissionsClient apiclients.PermissionsClient
+	weights           []ScopeWeight
+}
+
+func (resolver *scopeWeightResolver) Resolve(ctx context.Context, userID, _ uuid.UUID) (int, error) {
+	for _, weight := range resolver.weights {
+		// The permissions client fails with goframework.ErrInvalidCredentials when the scope is not granted.
+		err := resolver.permissionsClient.HasUserScope(ctx, apiclients.HasUserScopeQuery{
+			UserID: userID,
+			Scope:  weight.Scope,
+		})
+		if err == nil {
+			return weight.Weight, nil
+		}
+		if !goerrors.Is(err, goframework.ErrInvalidCredentials) {
+			return 0, err
+		}
+	}
+
+	return models.DefaultVoteWeight, nil
+}
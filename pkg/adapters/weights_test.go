@@ -0,0 +1,127 @@
+package adapters_test
+
+import (
+	"context"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewScopeWeightResolver(t *testing.T) {
+	data := []struct {
+		name string
+
+		weights []adapters.ScopeWeight
+
+		expectErr error
+	}{
+		{
+			name:    "Success",
+			weights: []adapters.ScopeWeight{{Scope: "can_moderate_votes", Weight: 3}, {Scope: apiclients.CanVotePost, Weight: 1}},
+		},
+		{
+			name:      "Error/NoWeights",
+			expectErr: adapters.ErrInvalidWeightsConfig,
+		},
+		{
+			name:      "Error/MissingScope",
+			weights:   []adapters.ScopeWeight{{Weight: 3}},
+			expectErr: adapters.ErrInvalidWeightsConfig,
+		},
+		{
+			name:      "Error/InvalidWeight",
+			weights:   []adapters.ScopeWeight{{Scope: "can_moderate_votes", Weight: 0}},
+			expectErr: adapters.ErrInvalidWeightsConfig,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			res, err := adapters.NewScopeWeightResolver(apiclientsmocks.NewPermissionsClient(t), d.weights)
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expectErr == nil, res != nil)
+		})
+	}
+}
+
+func TestScopeWeightResolver(t *testing.T) {
+	weights := []adapters.ScopeWeight{
+		{Scope: "can_moderate_votes", Weight: 5},
+		{Scope: "trusted_voter", Weight: 2},
+	}
+
+	data := []struct {
+		name string
+
+		// granted lists the scopes of the user. Other scopes are denied.
+		granted []apiclients.Scope
+		// failing lists the scopes the permissions service fails to check.
+		failing []apiclients.Scope
+		// checked lists the scopes the resolver is expected to ask about.
+		checked []apiclients.Scope
+
+		expect    int
+		expectErr error
+	}{
+		{
+			name:    "Success/FirstScope",
+			granted: []apiclients.Scope{"can_moderate_votes", "trusted_voter"},
+			checked: []apiclients.Scope{"can_moderate_votes"},
+			expect:  5,
+		},
+		{
+			name:    "Success/SecondScope",
+			granted: []apiclients.Scope{"trusted_voter"},
+			checked: []apiclients.Scope{"can_moderate_votes", "trusted_voter"},
+			expect:  2,
+		},
+		{
+			name:    "Success/Default",
+			checked: []apiclients.Scope{"can_moderate_votes", "trusted_voter"},
+			expect:  models.DefaultVoteWeight,
+		},
+		{
+			name:      "Error/PermissionsFailure",
+			granted:   []apiclients.Scope{"trusted_voter"},
+			failing:   []apiclients.Scope{"can_moderate_votes"},
+			checked:   []apiclients.Scope{"can_moderate_votes"},
+			expectErr: fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			permissionsClient := apiclientsmocks.NewPermissionsClient(t)
+
+			for _, scope := range d.checked {
+				var err error
+				if lo.Contains(d.failing, scope) {
+					err = fooErr
+				} else if !lo.Contains(d.granted, scope) {
+					err = goframework.ErrInvalidCredentials
+				}
+
+				permissionsClient.
+					On("HasUserScope", context.Background(), apiclients.HasUserScopeQuery{
+						UserID: goframework.NumberUUID(100),
+						Scope:  scope,
+					}).
+					Return(err)
+			}
+
+			resolver, err := adapters.NewScopeWeightResolver(permissionsClient, weights)
+			require.NoError(t, err)
+
+			res, err := resolver.Resolve(context.Background(), goframework.NumberUUID(100), goframework.NumberUUID(1))
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			permissionsClient.AssertExpectations(t)
+		})
+	}
+}
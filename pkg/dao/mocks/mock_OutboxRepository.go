@@ -0,0 +1,232 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/votes-service/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// OutboxRepository is an autogenerated mock type for the OutboxRepository type
+type OutboxRepository struct {
+	mock.Mock
+}
+
+type OutboxRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OutboxRepository) EXPECT() *OutboxRepository_Expecter {
+	return &OutboxRepository_Expecter{mock: &_m.Mock}
+}
+
+// Acknowledge provides a mock function with given fields: ctx, id, version
+func (_m *OutboxRepository) Acknowledge(ctx context.Context, id int64, version int) error {
+	ret := _m.Called(ctx, id, version)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_Acknowledge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Acknowledge'
+type OutboxRepository_Acknowledge_Call struct {
+	*mock.Call
+}
+
+// Acknowledge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - version int
+func (_e *OutboxRepository_Expecter) Acknowledge(ctx interface{}, id interface{}, version interface{}) *OutboxRepository_Acknowledge_Call {
+	return &OutboxRepository_Acknowledge_Call{Call: _e.mock.On("Acknowledge", ctx, id, version)}
+}
+
+func (_c *OutboxRepository_Acknowledge_Call) Run(run func(ctx context.Context, id int64, version int)) *OutboxRepository_Acknowledge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_Acknowledge_Call) Return(_a0 error) *OutboxRepository_Acknowledge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_Acknowledge_Call) RunAndReturn(run func(context.Context, int64, int) error) *OutboxRepository_Acknowledge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Claim provides a mock function with given fields: ctx, now, leaseUntil, limit
+func (_m *OutboxRepository) Claim(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]*dao.OutboxMessageModel, error) {
+	ret := _m.Called(ctx, now, leaseUntil, limit)
+
+	var r0 []*dao.OutboxMessageModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) ([]*dao.OutboxMessageModel, error)); ok {
+		return rf(ctx, now, leaseUntil, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) []*dao.OutboxMessageModel); ok {
+		r0 = rf(ctx, now, leaseUntil, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.OutboxMessageModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, now, leaseUntil, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OutboxRepository_Claim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Claim'
+type OutboxRepository_Claim_Call struct {
+	*mock.Call
+}
+
+// Claim is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - leaseUntil time.Time
+//   - limit int
+func (_e *OutboxRepository_Expecter) Claim(ctx interface{}, now interface{}, leaseUntil interface{}, limit interface{}) *OutboxRepository_Claim_Call {
+	return &OutboxRepository_Claim_Call{Call: _e.mock.On("Claim", ctx, now, leaseUntil, limit)}
+}
+
+func (_c *OutboxRepository_Claim_Call) Run(run func(ctx context.Context, now time.Time, leaseUntil time.Time, limit int)) *OutboxRepository_Claim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_Claim_Call) Return(_a0 []*dao.OutboxMessageModel, _a1 error) *OutboxRepository_Claim_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OutboxRepository_Claim_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, int) ([]*dao.OutboxMessageModel, error)) *OutboxRepository_Claim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeadLetter provides a mock function with given fields: ctx, id, version, lastError, now
+func (_m *OutboxRepository) DeadLetter(ctx context.Context, id int64, version int, lastError string, now time.Time) error {
+	ret := _m.Called(ctx, id, version, lastError, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, string, time.Time) error); ok {
+		r0 = rf(ctx, id, version, lastError, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_DeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeadLetter'
+type OutboxRepository_DeadLetter_Call struct {
+	*mock.Call
+}
+
+// DeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - version int
+//   - lastError string
+//   - now time.Time
+func (_e *OutboxRepository_Expecter) DeadLetter(ctx interface{}, id interface{}, version interface{}, lastError interface{}, now interface{}) *OutboxRepository_DeadLetter_Call {
+	return &OutboxRepository_DeadLetter_Call{Call: _e.mock.On("DeadLetter", ctx, id, version, lastError, now)}
+}
+
+func (_c *OutboxRepository_DeadLetter_Call) Run(run func(ctx context.Context, id int64, version int, lastError string, now time.Time)) *OutboxRepository_DeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int), args[3].(string), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_DeadLetter_Call) Return(_a0 error) *OutboxRepository_DeadLetter_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_DeadLetter_Call) RunAndReturn(run func(context.Context, int64, int, string, time.Time) error) *OutboxRepository_DeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Retry provides a mock function with given fields: ctx, id, version, lastError, nextAttemptAt
+func (_m *OutboxRepository) Retry(ctx context.Context, id int64, version int, lastError string, nextAttemptAt time.Time) error {
+	ret := _m.Called(ctx, id, version, lastError, nextAttemptAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, string, time.Time) error); ok {
+		r0 = rf(ctx, id, version, lastError, nextAttemptAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_Retry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Retry'
+type OutboxRepository_Retry_Call struct {
+	*mock.Call
+}
+
+// Retry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - version int
+//   - lastError string
+//   - nextAttemptAt time.Time
+func (_e *OutboxRepository_Expecter) Retry(ctx interface{}, id interface{}, version interface{}, lastError interface{}, nextAttemptAt interface{}) *OutboxRepository_Retry_Call {
+	return &OutboxRepository_Retry_Call{Call: _e.mock.On("Retry", ctx, id, version, lastError, nextAttemptAt)}
+}
+
+func (_c *OutboxRepository_Retry_Call) Run(run func(ctx context.Context, id int64, version int, lastError string, nextAttemptAt time.Time)) *OutboxRepository_Retry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int), args[3].(string), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_Retry_Call) Return(_a0 error) *OutboxRepository_Retry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_Retry_Call) RunAndReturn(run func(context.Context, int64, int, string, time.Time) error) *OutboxRepository_Retry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxRepository {
+	mock := &OutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,429 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/votes-service/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// PollsRepository is an autogenerated mock type for the PollsRepository type
+type PollsRepository struct {
+	mock.Mock
+}
+
+type PollsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PollsRepository) EXPECT() *PollsRepository_Expecter {
+	return &PollsRepository_Expecter{mock: &_m.Mock}
+}
+
+// CastBallot provides a mock function with given fields: ctx, pollID, userID, choices, now
+func (_m *PollsRepository) CastBallot(ctx context.Context, pollID uuid.UUID, userID uuid.UUID, choices []int, now time.Time) (bool, error) {
+	ret := _m.Called(ctx, pollID, userID, choices, now)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, []int, time.Time) (bool, error)); ok {
+		return rf(ctx, pollID, userID, choices, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, []int, time.Time) bool); ok {
+		r0 = rf(ctx, pollID, userID, choices, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, []int, time.Time) error); ok {
+		r1 = rf(ctx, pollID, userID, choices, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PollsRepository_CastBallot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CastBallot'
+type PollsRepository_CastBallot_Call struct {
+	*mock.Call
+}
+
+// CastBallot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pollID uuid.UUID
+//   - userID uuid.UUID
+//   - choices []int
+//   - now time.Time
+func (_e *PollsRepository_Expecter) CastBallot(ctx interface{}, pollID interface{}, userID interface{}, choices interface{}, now interface{}) *PollsRepository_CastBallot_Call {
+	return &PollsRepository_CastBallot_Call{Call: _e.mock.On("CastBallot", ctx, pollID, userID, choices, now)}
+}
+
+func (_c *PollsRepository_CastBallot_Call) Run(run func(ctx context.Context, pollID uuid.UUID, userID uuid.UUID, choices []int, now time.Time)) *PollsRepository_CastBallot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].([]int), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PollsRepository_CastBallot_Call) Return(_a0 bool, _a1 error) *PollsRepository_CastBallot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PollsRepository_CastBallot_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, []int, time.Time) (bool, error)) *PollsRepository_CastBallot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CastRanking provides a mock function with given fields: ctx, pollID, userID, ranking, now
+func (_m *PollsRepository) CastRanking(ctx context.Context, pollID uuid.UUID, userID uuid.UUID, ranking []int, now time.Time) (bool, error) {
+	ret := _m.Called(ctx, pollID, userID, ranking, now)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, []int, time.Time) (bool, error)); ok {
+		return rf(ctx, pollID, userID, ranking, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, []int, time.Time) bool); ok {
+		r0 = rf(ctx, pollID, userID, ranking, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, []int, time.Time) error); ok {
+		r1 = rf(ctx, pollID, userID, ranking, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PollsRepository_CastRanking_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CastRanking'
+type PollsRepository_CastRanking_Call struct {
+	*mock.Call
+}
+
+// CastRanking is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pollID uuid.UUID
+//   - userID uuid.UUID
+//   - ranking []int
+//   - now time.Time
+func (_e *PollsRepository_Expecter) CastRanking(ctx interface{}, pollID interface{}, userID interface{}, ranking interface{}, now interface{}) *PollsRepository_CastRanking_Call {
+	return &PollsRepository_CastRanking_Call{Call: _e.mock.On("CastRanking", ctx, pollID, userID, ranking, now)}
+}
+
+func (_c *PollsRepository_CastRanking_Call) Run(run func(ctx context.Context, pollID uuid.UUID, userID uuid.UUID, ranking []int, now time.Time)) *PollsRepository_CastRanking_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].([]int), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PollsRepository_CastRanking_Call) Return(_a0 bool, _a1 error) *PollsRepository_CastRanking_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PollsRepository_CastRanking_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, []int, time.Time) (bool, error)) *PollsRepository_CastRanking_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountBallots provides a mock function with given fields: ctx, pollID
+func (_m *PollsRepository) CountBallots(ctx context.Context, pollID uuid.UUID) (int, error) {
+	ret := _m.Called(ctx, pollID)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int, error)); ok {
+		return rf(ctx, pollID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int); ok {
+		r0 = rf(ctx, pollID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, pollID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PollsRepository_CountBallots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountBallots'
+type PollsRepository_CountBallots_Call struct {
+	*mock.Call
+}
+
+// CountBallots is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pollID uuid.UUID
+func (_e *PollsRepository_Expecter) CountBallots(ctx interface{}, pollID interface{}) *PollsRepository_CountBallots_Call {
+	return &PollsRepository_CountBallots_Call{Call: _e.mock.On("CountBallots", ctx, pollID)}
+}
+
+func (_c *PollsRepository_CountBallots_Call) Run(run func(ctx context.Context, pollID uuid.UUID)) *PollsRepository_CountBallots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PollsRepository_CountBallots_Call) Return(_a0 int, _a1 error) *PollsRepository_CountBallots_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PollsRepository_CountBallots_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int, error)) *PollsRepository_CountBallots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountChoices provides a mock function with given fields: ctx, pollID
+func (_m *PollsRepository) CountChoices(ctx context.Context, pollID uuid.UUID) ([]*dao.PollChoiceCountModel, error) {
+	ret := _m.Called(ctx, pollID)
+
+	var r0 []*dao.PollChoiceCountModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*dao.PollChoiceCountModel, error)); ok {
+		return rf(ctx, pollID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*dao.PollChoiceCountModel); ok {
+		r0 = rf(ctx, pollID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.PollChoiceCountModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, pollID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PollsRepository_CountChoices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountChoices'
+type PollsRepository_CountChoices_Call struct {
+	*mock.Call
+}
+
+// CountChoices is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pollID uuid.UUID
+func (_e *PollsRepository_Expecter) CountChoices(ctx interface{}, pollID interface{}) *PollsRepository_CountChoices_Call {
+	return &PollsRepository_CountChoices_Call{Call: _e.mock.On("CountChoices", ctx, pollID)}
+}
+
+func (_c *PollsRepository_CountChoices_Call) Run(run func(ctx context.Context, pollID uuid.UUID)) *PollsRepository_CountChoices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PollsRepository_CountChoices_Call) Return(_a0 []*dao.PollChoiceCountModel, _a1 error) *PollsRepository_CountChoices_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PollsRepository_CountChoices_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*dao.PollChoiceCountModel, error)) *PollsRepository_CountChoices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, core, createdBy, id, now
+func (_m *PollsRepository) Create(ctx context.Context, core *dao.PollModelCore, createdBy uuid.UUID, id uuid.UUID, now time.Time) (*dao.PollModel, error) {
+	ret := _m.Called(ctx, core, createdBy, id, now)
+
+	var r0 *dao.PollModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.PollModelCore, uuid.UUID, uuid.UUID, time.Time) (*dao.PollModel, error)); ok {
+		return rf(ctx, core, createdBy, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.PollModelCore, uuid.UUID, uuid.UUID, time.Time) *dao.PollModel); ok {
+		r0 = rf(ctx, core, createdBy, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.PollModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.PollModelCore, uuid.UUID, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, core, createdBy, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PollsRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type PollsRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - core *dao.PollModelCore
+//   - createdBy uuid.UUID
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *PollsRepository_Expecter) Create(ctx interface{}, core interface{}, createdBy interface{}, id interface{}, now interface{}) *PollsRepository_Create_Call {
+	return &PollsRepository_Create_Call{Call: _e.mock.On("Create", ctx, core, createdBy, id, now)}
+}
+
+func (_c *PollsRepository_Create_Call) Run(run func(ctx context.Context, core *dao.PollModelCore, createdBy uuid.UUID, id uuid.UUID, now time.Time)) *PollsRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.PollModelCore), args[2].(uuid.UUID), args[3].(uuid.UUID), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PollsRepository_Create_Call) Return(_a0 *dao.PollModel, _a1 error) *PollsRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PollsRepository_Create_Call) RunAndReturn(run func(context.Context, *dao.PollModelCore, uuid.UUID, uuid.UUID, time.Time) (*dao.PollModel, error)) *PollsRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *PollsRepository) Get(ctx context.Context, id uuid.UUID) (*dao.PollModel, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *dao.PollModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*dao.PollModel, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *dao.PollModel); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.PollModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PollsRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type PollsRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *PollsRepository_Expecter) Get(ctx interface{}, id interface{}) *PollsRepository_Get_Call {
+	return &PollsRepository_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *PollsRepository_Get_Call) Run(run func(ctx context.Context, id uuid.UUID)) *PollsRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PollsRepository_Get_Call) Return(_a0 *dao.PollModel, _a1 error) *PollsRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PollsRepository_Get_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*dao.PollModel, error)) *PollsRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRankings provides a mock function with given fields: ctx, pollID
+func (_m *PollsRepository) ListRankings(ctx context.Context, pollID uuid.UUID) ([]*dao.PollRankingModel, error) {
+	ret := _m.Called(ctx, pollID)
+
+	var r0 []*dao.PollRankingModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*dao.PollRankingModel, error)); ok {
+		return rf(ctx, pollID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*dao.PollRankingModel); ok {
+		r0 = rf(ctx, pollID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.PollRankingModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, pollID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PollsRepository_ListRankings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRankings'
+type PollsRepository_ListRankings_Call struct {
+	*mock.Call
+}
+
+// ListRankings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pollID uuid.UUID
+func (_e *PollsRepository_Expecter) ListRankings(ctx interface{}, pollID interface{}) *PollsRepository_ListRankings_Call {
+	return &PollsRepository_ListRankings_Call{Call: _e.mock.On("ListRankings", ctx, pollID)}
+}
+
+func (_c *PollsRepository_ListRankings_Call) Run(run func(ctx context.Context, pollID uuid.UUID)) *PollsRepository_ListRankings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PollsRepository_ListRankings_Call) Return(_a0 []*dao.PollRankingModel, _a1 error) *PollsRepository_ListRankings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PollsRepository_ListRankings_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*dao.PollRankingModel, error)) *PollsRepository_ListRankings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPollsRepository creates a new instance of PollsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPollsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PollsRepository {
+	mock := &PollsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
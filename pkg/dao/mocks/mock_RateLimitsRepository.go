@@ -0,0 +1,136 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/votes-service/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RateLimitsRepository is an autogenerated mock type for the RateLimitsRepository type
+type RateLimitsRepository struct {
+	mock.Mock
+}
+
+type RateLimitsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RateLimitsRepository) EXPECT() *RateLimitsRepository_Expecter {
+	return &RateLimitsRepository_Expecter{mock: &_m.Mock}
+}
+
+// Purge provides a mock function with given fields: ctx, now
+func (_m *RateLimitsRepository) Purge(ctx context.Context, now time.Time) (int, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RateLimitsRepository_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type RateLimitsRepository_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *RateLimitsRepository_Expecter) Purge(ctx interface{}, now interface{}) *RateLimitsRepository_Purge_Call {
+	return &RateLimitsRepository_Purge_Call{Call: _e.mock.On("Purge", ctx, now)}
+}
+
+func (_c *RateLimitsRepository_Purge_Call) Run(run func(ctx context.Context, now time.Time)) *RateLimitsRepository_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RateLimitsRepository_Purge_Call) Return(_a0 int, _a1 error) *RateLimitsRepository_Purge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RateLimitsRepository_Purge_Call) RunAndReturn(run func(context.Context, time.Time) (int, error)) *RateLimitsRepository_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, buckets, update
+func (_m *RateLimitsRepository) Update(ctx context.Context, buckets []*dao.RateLimitBucketModel, update func([]*dao.RateLimitBucketModel) bool) error {
+	ret := _m.Called(ctx, buckets, update)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*dao.RateLimitBucketModel, func([]*dao.RateLimitBucketModel) bool) error); ok {
+		r0 = rf(ctx, buckets, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RateLimitsRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type RateLimitsRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - buckets []*dao.RateLimitBucketModel
+//   - update func([]*dao.RateLimitBucketModel) bool
+func (_e *RateLimitsRepository_Expecter) Update(ctx interface{}, buckets interface{}, update interface{}) *RateLimitsRepository_Update_Call {
+	return &RateLimitsRepository_Update_Call{Call: _e.mock.On("Update", ctx, buckets, update)}
+}
+
+func (_c *RateLimitsRepository_Update_Call) Run(run func(ctx context.Context, buckets []*dao.RateLimitBucketModel, update func([]*dao.RateLimitBucketModel) bool)) *RateLimitsRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*dao.RateLimitBucketModel), args[2].(func([]*dao.RateLimitBucketModel) bool))
+	})
+	return _c
+}
+
+func (_c *RateLimitsRepository_Update_Call) Return(_a0 error) *RateLimitsRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RateLimitsRepository_Update_Call) RunAndReturn(run func(context.Context, []*dao.RateLimitBucketModel, func([]*dao.RateLimitBucketModel) bool) error) *RateLimitsRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRateLimitsRepository creates a new instance of RateLimitsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRateLimitsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RateLimitsRepository {
+	mock := &RateLimitsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
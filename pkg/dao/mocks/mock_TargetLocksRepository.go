@@ -0,0 +1,200 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/votes-service/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// TargetLocksRepository is an autogenerated mock type for the TargetLocksRepository type
+type TargetLocksRepository struct {
+	mock.Mock
+}
+
+type TargetLocksRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TargetLocksRepository) EXPECT() *TargetLocksRepository_Expecter {
+	return &TargetLocksRepository_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: ctx, targetID, target
+func (_m *TargetLocksRepository) Get(ctx context.Context, targetID uuid.UUID, target string) (*dao.TargetLockModel, error) {
+	ret := _m.Called(ctx, targetID, target)
+
+	var r0 *dao.TargetLockModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*dao.TargetLockModel, error)); ok {
+		return rf(ctx, targetID, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *dao.TargetLockModel); ok {
+		r0 = rf(ctx, targetID, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.TargetLockModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, targetID, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TargetLocksRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type TargetLocksRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - targetID uuid.UUID
+//   - target string
+func (_e *TargetLocksRepository_Expecter) Get(ctx interface{}, targetID interface{}, target interface{}) *TargetLocksRepository_Get_Call {
+	return &TargetLocksRepository_Get_Call{Call: _e.mock.On("Get", ctx, targetID, target)}
+}
+
+func (_c *TargetLocksRepository_Get_Call) Run(run func(ctx context.Context, targetID uuid.UUID, target string)) *TargetLocksRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TargetLocksRepository_Get_Call) Return(_a0 *dao.TargetLockModel, _a1 error) *TargetLocksRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TargetLocksRepository_Get_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*dao.TargetLockModel, error)) *TargetLocksRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lock provides a mock function with given fields: ctx, targetID, target, lockedBy, closesAt, now
+func (_m *TargetLocksRepository) Lock(ctx context.Context, targetID uuid.UUID, target string, lockedBy uuid.UUID, closesAt time.Time, now time.Time) (*dao.TargetLockModel, error) {
+	ret := _m.Called(ctx, targetID, target, lockedBy, closesAt, now)
+
+	var r0 *dao.TargetLockModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, uuid.UUID, time.Time, time.Time) (*dao.TargetLockModel, error)); ok {
+		return rf(ctx, targetID, target, lockedBy, closesAt, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, uuid.UUID, time.Time, time.Time) *dao.TargetLockModel); ok {
+		r0 = rf(ctx, targetID, target, lockedBy, closesAt, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.TargetLockModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, targetID, target, lockedBy, closesAt, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TargetLocksRepository_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type TargetLocksRepository_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - targetID uuid.UUID
+//   - target string
+//   - lockedBy uuid.UUID
+//   - closesAt time.Time
+//   - now time.Time
+func (_e *TargetLocksRepository_Expecter) Lock(ctx interface{}, targetID interface{}, target interface{}, lockedBy interface{}, closesAt interface{}, now interface{}) *TargetLocksRepository_Lock_Call {
+	return &TargetLocksRepository_Lock_Call{Call: _e.mock.On("Lock", ctx, targetID, target, lockedBy, closesAt, now)}
+}
+
+func (_c *TargetLocksRepository_Lock_Call) Run(run func(ctx context.Context, targetID uuid.UUID, target string, lockedBy uuid.UUID, closesAt time.Time, now time.Time)) *TargetLocksRepository_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(uuid.UUID), args[4].(time.Time), args[5].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TargetLocksRepository_Lock_Call) Return(_a0 *dao.TargetLockModel, _a1 error) *TargetLocksRepository_Lock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TargetLocksRepository_Lock_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, uuid.UUID, time.Time, time.Time) (*dao.TargetLockModel, error)) *TargetLocksRepository_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unlock provides a mock function with given fields: ctx, targetID, target
+func (_m *TargetLocksRepository) Unlock(ctx context.Context, targetID uuid.UUID, target string) error {
+	ret := _m.Called(ctx, targetID, target)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, targetID, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TargetLocksRepository_Unlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unlock'
+type TargetLocksRepository_Unlock_Call struct {
+	*mock.Call
+}
+
+// Unlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - targetID uuid.UUID
+//   - target string
+func (_e *TargetLocksRepository_Expecter) Unlock(ctx interface{}, targetID interface{}, target interface{}) *TargetLocksRepository_Unlock_Call {
+	return &TargetLocksRepository_Unlock_Call{Call: _e.mock.On("Unlock", ctx, targetID, target)}
+}
+
+func (_c *TargetLocksRepository_Unlock_Call) Run(run func(ctx context.Context, targetID uuid.UUID, target string)) *TargetLocksRepository_Unlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TargetLocksRepository_Unlock_Call) Return(_a0 error) *TargetLocksRepository_Unlock_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TargetLocksRepository_Unlock_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *TargetLocksRepository_Unlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTargetLocksRepository creates a new instance of TargetLocksRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTargetLocksRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TargetLocksRepository {
+	mock := &TargetLocksRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
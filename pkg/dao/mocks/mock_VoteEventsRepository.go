@@ -0,0 +1,94 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/votes-service/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// VoteEventsRepository is an autogenerated mock type for the VoteEventsRepository type
+type VoteEventsRepository struct {
+	mock.Mock
+}
+
+type VoteEventsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *VoteEventsRepository) EXPECT() *VoteEventsRepository_Expecter {
+	return &VoteEventsRepository_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function with given fields: ctx, filter, limit, offset
+func (_m *VoteEventsRepository) List(ctx context.Context, filter dao.VoteEventsFilter, limit int, offset int) ([]*dao.VoteEventModel, error) {
+	ret := _m.Called(ctx, filter, limit, offset)
+
+	var r0 []*dao.VoteEventModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dao.VoteEventsFilter, int, int) ([]*dao.VoteEventModel, error)); ok {
+		return rf(ctx, filter, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dao.VoteEventsFilter, int, int) []*dao.VoteEventModel); ok {
+		r0 = rf(ctx, filter, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.VoteEventModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dao.VoteEventsFilter, int, int) error); ok {
+		r1 = rf(ctx, filter, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VoteEventsRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type VoteEventsRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter dao.VoteEventsFilter
+//   - limit int
+//   - offset int
+func (_e *VoteEventsRepository_Expecter) List(ctx interface{}, filter interface{}, limit interface{}, offset interface{}) *VoteEventsRepository_List_Call {
+	return &VoteEventsRepository_List_Call{Call: _e.mock.On("List", ctx, filter, limit, offset)}
+}
+
+func (_c *VoteEventsRepository_List_Call) Run(run func(ctx context.Context, filter dao.VoteEventsFilter, limit int, offset int)) *VoteEventsRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dao.VoteEventsFilter), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *VoteEventsRepository_List_Call) Return(_a0 []*dao.VoteEventModel, _a1 error) *VoteEventsRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VoteEventsRepository_List_Call) RunAndReturn(run func(context.Context, dao.VoteEventsFilter, int, int) ([]*dao.VoteEventModel, error)) *VoteEventsRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewVoteEventsRepository creates a new instance of VoteEventsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewVoteEventsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *VoteEventsRepository {
+	mock := &VoteEventsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,381 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/votes-service/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// VoteFlagsRepository is an autogenerated mock type for the VoteFlagsRepository type
+type VoteFlagsRepository struct {
+	mock.Mock
+}
+
+type VoteFlagsRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *VoteFlagsRepository) EXPECT() *VoteFlagsRepository_Expecter {
+	return &VoteFlagsRepository_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function with given fields: ctx, filter, limit, offset
+func (_m *VoteFlagsRepository) List(ctx context.Context, filter dao.VoteFlagsFilter, limit int, offset int) ([]*dao.VoteFlagModel, error) {
+	ret := _m.Called(ctx, filter, limit, offset)
+
+	var r0 []*dao.VoteFlagModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dao.VoteFlagsFilter, int, int) ([]*dao.VoteFlagModel, error)); ok {
+		return rf(ctx, filter, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dao.VoteFlagsFilter, int, int) []*dao.VoteFlagModel); ok {
+		r0 = rf(ctx, filter, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.VoteFlagModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dao.VoteFlagsFilter, int, int) error); ok {
+		r1 = rf(ctx, filter, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VoteFlagsRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type VoteFlagsRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter dao.VoteFlagsFilter
+//   - limit int
+//   - offset int
+func (_e *VoteFlagsRepository_Expecter) List(ctx interface{}, filter interface{}, limit interface{}, offset interface{}) *VoteFlagsRepository_List_Call {
+	return &VoteFlagsRepository_List_Call{Call: _e.mock.On("List", ctx, filter, limit, offset)}
+}
+
+func (_c *VoteFlagsRepository_List_Call) Run(run func(ctx context.Context, filter dao.VoteFlagsFilter, limit int, offset int)) *VoteFlagsRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dao.VoteFlagsFilter), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *VoteFlagsRepository_List_Call) Return(_a0 []*dao.VoteFlagModel, _a1 error) *VoteFlagsRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VoteFlagsRepository_List_Call) RunAndReturn(run func(context.Context, dao.VoteFlagsFilter, int, int) ([]*dao.VoteFlagModel, error)) *VoteFlagsRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCoVotingPairs provides a mock function with given fields: ctx, since, window, minTargets
+func (_m *VoteFlagsRepository) ListCoVotingPairs(ctx context.Context, since time.Time, window time.Duration, minTargets int) ([]*dao.CoVotingPairModel, error) {
+	ret := _m.Called(ctx, since, window, minTargets)
+
+	var r0 []*dao.CoVotingPairModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) ([]*dao.CoVotingPairModel, error)); ok {
+		return rf(ctx, since, window, minTargets)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) []*dao.CoVotingPairModel); ok {
+		r0 = rf(ctx, since, window, minTargets)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.CoVotingPairModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration, int) error); ok {
+		r1 = rf(ctx, since, window, minTargets)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VoteFlagsRepository_ListCoVotingPairs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCoVotingPairs'
+type VoteFlagsRepository_ListCoVotingPairs_Call struct {
+	*mock.Call
+}
+
+// ListCoVotingPairs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - since time.Time
+//   - window time.Duration
+//   - minTargets int
+func (_e *VoteFlagsRepository_Expecter) ListCoVotingPairs(ctx interface{}, since interface{}, window interface{}, minTargets interface{}) *VoteFlagsRepository_ListCoVotingPairs_Call {
+	return &VoteFlagsRepository_ListCoVotingPairs_Call{Call: _e.mock.On("ListCoVotingPairs", ctx, since, window, minTargets)}
+}
+
+func (_c *VoteFlagsRepository_ListCoVotingPairs_Call) Run(run func(ctx context.Context, since time.Time, window time.Duration, minTargets int)) *VoteFlagsRepository_ListCoVotingPairs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Duration), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *VoteFlagsRepository_ListCoVotingPairs_Call) Return(_a0 []*dao.CoVotingPairModel, _a1 error) *VoteFlagsRepository_ListCoVotingPairs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VoteFlagsRepository_ListCoVotingPairs_Call) RunAndReturn(run func(context.Context, time.Time, time.Duration, int) ([]*dao.CoVotingPairModel, error)) *VoteFlagsRepository_ListCoVotingPairs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFlipBursts provides a mock function with given fields: ctx, since, minChanges
+func (_m *VoteFlagsRepository) ListFlipBursts(ctx context.Context, since time.Time, minChanges int) ([]*dao.FlipBurstModel, error) {
+	ret := _m.Called(ctx, since, minChanges)
+
+	var r0 []*dao.FlipBurstModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]*dao.FlipBurstModel, error)); ok {
+		return rf(ctx, since, minChanges)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*dao.FlipBurstModel); ok {
+		r0 = rf(ctx, since, minChanges)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.FlipBurstModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, since, minChanges)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VoteFlagsRepository_ListFlipBursts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFlipBursts'
+type VoteFlagsRepository_ListFlipBursts_Call struct {
+	*mock.Call
+}
+
+// ListFlipBursts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - since time.Time
+//   - minChanges int
+func (_e *VoteFlagsRepository_Expecter) ListFlipBursts(ctx interface{}, since interface{}, minChanges interface{}) *VoteFlagsRepository_ListFlipBursts_Call {
+	return &VoteFlagsRepository_ListFlipBursts_Call{Call: _e.mock.On("ListFlipBursts", ctx, since, minChanges)}
+}
+
+func (_c *VoteFlagsRepository_ListFlipBursts_Call) Run(run func(ctx context.Context, since time.Time, minChanges int)) *VoteFlagsRepository_ListFlipBursts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *VoteFlagsRepository_ListFlipBursts_Call) Return(_a0 []*dao.FlipBurstModel, _a1 error) *VoteFlagsRepository_ListFlipBursts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VoteFlagsRepository_ListFlipBursts_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]*dao.FlipBurstModel, error)) *VoteFlagsRepository_ListFlipBursts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNewVoterSwarms provides a mock function with given fields: ctx, since, firstVoteSince, minVoters
+func (_m *VoteFlagsRepository) ListNewVoterSwarms(ctx context.Context, since time.Time, firstVoteSince time.Time, minVoters int) ([]*dao.NewVoterSwarmModel, error) {
+	ret := _m.Called(ctx, since, firstVoteSince, minVoters)
+
+	var r0 []*dao.NewVoterSwarmModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) ([]*dao.NewVoterSwarmModel, error)); ok {
+		return rf(ctx, since, firstVoteSince, minVoters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) []*dao.NewVoterSwarmModel); ok {
+		r0 = rf(ctx, since, firstVoteSince, minVoters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.NewVoterSwarmModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, since, firstVoteSince, minVoters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VoteFlagsRepository_ListNewVoterSwarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNewVoterSwarms'
+type VoteFlagsRepository_ListNewVoterSwarms_Call struct {
+	*mock.Call
+}
+
+// ListNewVoterSwarms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - since time.Time
+//   - firstVoteSince time.Time
+//   - minVoters int
+func (_e *VoteFlagsRepository_Expecter) ListNewVoterSwarms(ctx interface{}, since interface{}, firstVoteSince interface{}, minVoters interface{}) *VoteFlagsRepository_ListNewVoterSwarms_Call {
+	return &VoteFlagsRepository_ListNewVoterSwarms_Call{Call: _e.mock.On("ListNewVoterSwarms", ctx, since, firstVoteSince, minVoters)}
+}
+
+func (_c *VoteFlagsRepository_ListNewVoterSwarms_Call) Run(run func(ctx context.Context, since time.Time, firstVoteSince time.Time, minVoters int)) *VoteFlagsRepository_ListNewVoterSwarms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *VoteFlagsRepository_ListNewVoterSwarms_Call) Return(_a0 []*dao.NewVoterSwarmModel, _a1 error) *VoteFlagsRepository_ListNewVoterSwarms_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VoteFlagsRepository_ListNewVoterSwarms_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, int) ([]*dao.NewVoterSwarmModel, error)) *VoteFlagsRepository_ListNewVoterSwarms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Review provides a mock function with given fields: ctx, id, status, reviewerID, now
+func (_m *VoteFlagsRepository) Review(ctx context.Context, id uuid.UUID, status models.VoteFlagStatus, reviewerID uuid.UUID, now time.Time) (*dao.VoteFlagModel, error) {
+	ret := _m.Called(ctx, id, status, reviewerID, now)
+
+	var r0 *dao.VoteFlagModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.VoteFlagStatus, uuid.UUID, time.Time) (*dao.VoteFlagModel, error)); ok {
+		return rf(ctx, id, status, reviewerID, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.VoteFlagStatus, uuid.UUID, time.Time) *dao.VoteFlagModel); ok {
+		r0 = rf(ctx, id, status, reviewerID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.VoteFlagModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, models.VoteFlagStatus, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, id, status, reviewerID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VoteFlagsRepository_Review_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Review'
+type VoteFlagsRepository_Review_Call struct {
+	*mock.Call
+}
+
+// Review is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - status models.VoteFlagStatus
+//   - reviewerID uuid.UUID
+//   - now time.Time
+func (_e *VoteFlagsRepository_Expecter) Review(ctx interface{}, id interface{}, status interface{}, reviewerID interface{}, now interface{}) *VoteFlagsRepository_Review_Call {
+	return &VoteFlagsRepository_Review_Call{Call: _e.mock.On("Review", ctx, id, status, reviewerID, now)}
+}
+
+func (_c *VoteFlagsRepository_Review_Call) Run(run func(ctx context.Context, id uuid.UUID, status models.VoteFlagStatus, reviewerID uuid.UUID, now time.Time)) *VoteFlagsRepository_Review_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(models.VoteFlagStatus), args[3].(uuid.UUID), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *VoteFlagsRepository_Review_Call) Return(_a0 *dao.VoteFlagModel, _a1 error) *VoteFlagsRepository_Review_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VoteFlagsRepository_Review_Call) RunAndReturn(run func(context.Context, uuid.UUID, models.VoteFlagStatus, uuid.UUID, time.Time) (*dao.VoteFlagModel, error)) *VoteFlagsRepository_Review_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, flags
+func (_m *VoteFlagsRepository) Save(ctx context.Context, flags []*dao.VoteFlagModel) (int, error) {
+	ret := _m.Called(ctx, flags)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*dao.VoteFlagModel) (int, error)); ok {
+		return rf(ctx, flags)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*dao.VoteFlagModel) int); ok {
+		r0 = rf(ctx, flags)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*dao.VoteFlagModel) error); ok {
+		r1 = rf(ctx, flags)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VoteFlagsRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type VoteFlagsRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - flags []*dao.VoteFlagModel
+func (_e *VoteFlagsRepository_Expecter) Save(ctx interface{}, flags interface{}) *VoteFlagsRepository_Save_Call {
+	return &VoteFlagsRepository_Save_Call{Call: _e.mock.On("Save", ctx, flags)}
+}
+
+func (_c *VoteFlagsRepository_Save_Call) Run(run func(ctx context.Context, flags []*dao.VoteFlagModel)) *VoteFlagsRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*dao.VoteFlagModel))
+	})
+	return _c
+}
+
+func (_c *VoteFlagsRepository_Save_Call) Return(_a0 int, _a1 error) *VoteFlagsRepository_Save_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VoteFlagsRepository_Save_Call) RunAndReturn(run func(context.Context, []*dao.VoteFlagModel) (int, error)) *VoteFlagsRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewVoteFlagsRepository creates a new instance of VoteFlagsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewVoteFlagsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *VoteFlagsRepository {
+	mock := &VoteFlagsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,8 +4,10 @@ package daomocks
 
 import (
 	context "context"
+	jsontext "encoding/json/jsontext"
 
 	dao "github.com/a-novel/votes-service/pkg/dao"
+
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/a-novel/votes-service/pkg/models"
@@ -28,25 +30,25 @@ func (_m *VotesRepository) EXPECT() *VotesRepository_Expecter {
 	return &VotesRepository_Expecter{mock: &_m.Mock}
 }
 
-// Cast provides a mock function with given fields: ctx, userID, targetID, target, vote, id, now
-func (_m *VotesRepository) Cast(ctx context.Context, userID uuid.UUID, targetID uuid.UUID, target string, vote *models.VoteValue, id uuid.UUID, now time.Time) (*dao.VoteModel, error) {
-	ret := _m.Called(ctx, userID, targetID, target, vote, id, now)
+// Cast provides a mock function with given fields: ctx, userID, targetID, target, vote, expectedVote, score, weight, id, now
+func (_m *VotesRepository) Cast(ctx context.Context, userID uuid.UUID, targetID uuid.UUID, target string, vote *models.VoteValue, expectedVote *models.VoteValue, score *int, weight int, id uuid.UUID, now time.Time) (*dao.VoteModel, error) {
+	ret := _m.Called(ctx, userID, targetID, target, vote, expectedVote, score, weight, id, now)
 
 	var r0 *dao.VoteModel
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, *models.VoteValue, uuid.UUID, time.Time) (*dao.VoteModel, error)); ok {
-		return rf(ctx, userID, targetID, target, vote, id, now)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, *models.VoteValue, *models.VoteValue, *int, int, uuid.UUID, time.Time) (*dao.VoteModel, error)); ok {
+		return rf(ctx, userID, targetID, target, vote, expectedVote, score, weight, id, now)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, *models.VoteValue, uuid.UUID, time.Time) *dao.VoteModel); ok {
-		r0 = rf(ctx, userID, targetID, target, vote, id, now)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, *models.VoteValue, *models.VoteValue, *int, int, uuid.UUID, time.Time) *dao.VoteModel); ok {
+		r0 = rf(ctx, userID, targetID, target, vote, expectedVote, score, weight, id, now)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dao.VoteModel)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, string, *models.VoteValue, uuid.UUID, time.Time) error); ok {
-		r1 = rf(ctx, userID, targetID, target, vote, id, now)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, string, *models.VoteValue, *models.VoteValue, *int, int, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, userID, targetID, target, vote, expectedVote, score, weight, id, now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -65,15 +67,18 @@ type VotesRepository_Cast_Call struct {
 //   - targetID uuid.UUID
 //   - target string
 //   - vote *models.VoteValue
+//   - expectedVote *models.VoteValue
+//   - score *int
+//   - weight int
 //   - id uuid.UUID
 //   - now time.Time
-func (_e *VotesRepository_Expecter) Cast(ctx interface{}, userID interface{}, targetID interface{}, target interface{}, vote interface{}, id interface{}, now interface{}) *VotesRepository_Cast_Call {
-	return &VotesRepository_Cast_Call{Call: _e.mock.On("Cast", ctx, userID, targetID, target, vote, id, now)}
+func (_e *VotesRepository_Expecter) Cast(ctx interface{}, userID interface{}, targetID interface{}, target interface{}, vote interface{}, expectedVote interface{}, score interface{}, weight interface{}, id interface{}, now interface{}) *VotesRepository_Cast_Call {
+	return &VotesRepository_Cast_Call{Call: _e.mock.On("Cast", ctx, userID, targetID, target, vote, expectedVote, score, weight, id, now)}
 }
 
-func (_c *VotesRepository_Cast_Call) Run(run func(ctx context.Context, userID uuid.UUID, targetID uuid.UUID, target string, vote *models.VoteValue, id uuid.UUID, now time.Time)) *VotesRepository_Cast_Call {
+func (_c *VotesRepository_Cast_Call) Run(run func(ctx context.Context, userID uuid.UUID, targetID uuid.UUID, target string, vote *models.VoteValue, expectedVote *models.VoteValue, score *int, weight int, id uuid.UUID, now time.Time)) *VotesRepository_Cast_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(string), args[4].(*models.VoteValue), args[5].(uuid.UUID), args[6].(time.Time))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(string), args[4].(*models.VoteValue), args[5].(*models.VoteValue), args[6].(*int), args[7].(int), args[8].(uuid.UUID), args[9].(time.Time))
 	})
 	return _c
 }
@@ -83,7 +88,173 @@ func (_c *VotesRepository_Cast_Call) Return(_a0 *dao.VoteModel, _a1 error) *Vote
 	return _c
 }
 
-func (_c *VotesRepository_Cast_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, string, *models.VoteValue, uuid.UUID, time.Time) (*dao.VoteModel, error)) *VotesRepository_Cast_Call {
+func (_c *VotesRepository_Cast_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, string, *models.VoteValue, *models.VoteValue, *int, int, uuid.UUID, time.Time) (*dao.VoteModel, error)) *VotesRepository_Cast_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimIdempotencyKey provides a mock function with given fields: ctx, userID, key, requestHash, now, expiresAt
+func (_m *VotesRepository) ClaimIdempotencyKey(ctx context.Context, userID uuid.UUID, key string, requestHash string, now time.Time, expiresAt time.Time) (bool, error) {
+	ret := _m.Called(ctx, userID, key, requestHash, now, expiresAt)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, time.Time, time.Time) (bool, error)); ok {
+		return rf(ctx, userID, key, requestHash, now, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, userID, key, requestHash, now, expiresAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, key, requestHash, now, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VotesRepository_ClaimIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimIdempotencyKey'
+type VotesRepository_ClaimIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// ClaimIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - key string
+//   - requestHash string
+//   - now time.Time
+//   - expiresAt time.Time
+func (_e *VotesRepository_Expecter) ClaimIdempotencyKey(ctx interface{}, userID interface{}, key interface{}, requestHash interface{}, now interface{}, expiresAt interface{}) *VotesRepository_ClaimIdempotencyKey_Call {
+	return &VotesRepository_ClaimIdempotencyKey_Call{Call: _e.mock.On("ClaimIdempotencyKey", ctx, userID, key, requestHash, now, expiresAt)}
+}
+
+func (_c *VotesRepository_ClaimIdempotencyKey_Call) Run(run func(ctx context.Context, userID uuid.UUID, key string, requestHash string, now time.Time, expiresAt time.Time)) *VotesRepository_ClaimIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(string), args[4].(time.Time), args[5].(time.Time))
+	})
+	return _c
+}
+
+func (_c *VotesRepository_ClaimIdempotencyKey_Call) Return(_a0 bool, _a1 error) *VotesRepository_ClaimIdempotencyKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VotesRepository_ClaimIdempotencyKey_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, string, time.Time, time.Time) (bool, error)) *VotesRepository_ClaimIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountTargetVotes provides a mock function with given fields: ctx, targetID, target, filter
+func (_m *VotesRepository) CountTargetVotes(ctx context.Context, targetID uuid.UUID, target string, filter dao.TargetVotesFilter) (int, error) {
+	ret := _m.Called(ctx, targetID, target, filter)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, dao.TargetVotesFilter) (int, error)); ok {
+		return rf(ctx, targetID, target, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, dao.TargetVotesFilter) int); ok {
+		r0 = rf(ctx, targetID, target, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, dao.TargetVotesFilter) error); ok {
+		r1 = rf(ctx, targetID, target, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VotesRepository_CountTargetVotes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountTargetVotes'
+type VotesRepository_CountTargetVotes_Call struct {
+	*mock.Call
+}
+
+// CountTargetVotes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - targetID uuid.UUID
+//   - target string
+//   - filter dao.TargetVotesFilter
+func (_e *VotesRepository_Expecter) CountTargetVotes(ctx interface{}, targetID interface{}, target interface{}, filter interface{}) *VotesRepository_CountTargetVotes_Call {
+	return &VotesRepository_CountTargetVotes_Call{Call: _e.mock.On("CountTargetVotes", ctx, targetID, target, filter)}
+}
+
+func (_c *VotesRepository_CountTargetVotes_Call) Run(run func(ctx context.Context, targetID uuid.UUID, target string, filter dao.TargetVotesFilter)) *VotesRepository_CountTargetVotes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(dao.TargetVotesFilter))
+	})
+	return _c
+}
+
+func (_c *VotesRepository_CountTargetVotes_Call) Return(_a0 int, _a1 error) *VotesRepository_CountTargetVotes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VotesRepository_CountTargetVotes_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, dao.TargetVotesFilter) (int, error)) *VotesRepository_CountTargetVotes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountUserVotes provides a mock function with given fields: ctx, userID, filter
+func (_m *VotesRepository) CountUserVotes(ctx context.Context, userID uuid.UUID, filter dao.UserVotesFilter) (int, error) {
+	ret := _m.Called(ctx, userID, filter)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, dao.UserVotesFilter) (int, error)); ok {
+		return rf(ctx, userID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, dao.UserVotesFilter) int); ok {
+		r0 = rf(ctx, userID, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, dao.UserVotesFilter) error); ok {
+		r1 = rf(ctx, userID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VotesRepository_CountUserVotes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUserVotes'
+type VotesRepository_CountUserVotes_Call struct {
+	*mock.Call
+}
+
+// CountUserVotes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - filter dao.UserVotesFilter
+func (_e *VotesRepository_Expecter) CountUserVotes(ctx interface{}, userID interface{}, filter interface{}) *VotesRepository_CountUserVotes_Call {
+	return &VotesRepository_CountUserVotes_Call{Call: _e.mock.On("CountUserVotes", ctx, userID, filter)}
+}
+
+func (_c *VotesRepository_CountUserVotes_Call) Run(run func(ctx context.Context, userID uuid.UUID, filter dao.UserVotesFilter)) *VotesRepository_CountUserVotes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(dao.UserVotesFilter))
+	})
+	return _c
+}
+
+func (_c *VotesRepository_CountUserVotes_Call) Return(_a0 int, _a1 error) *VotesRepository_CountUserVotes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VotesRepository_CountUserVotes_Call) RunAndReturn(run func(context.Context, uuid.UUID, dao.UserVotesFilter) (int, error)) *VotesRepository_CountUserVotes_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -145,6 +316,119 @@ func (_c *VotesRepository_Get_Call) RunAndReturn(run func(context.Context, uuid.
 	return _c
 }
 
+// GetIdempotencyKey provides a mock function with given fields: ctx, userID, key, now
+func (_m *VotesRepository) GetIdempotencyKey(ctx context.Context, userID uuid.UUID, key string, now time.Time) (*dao.IdempotencyKeyModel, error) {
+	ret := _m.Called(ctx, userID, key, now)
+
+	var r0 *dao.IdempotencyKeyModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) (*dao.IdempotencyKeyModel, error)); ok {
+		return rf(ctx, userID, key, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) *dao.IdempotencyKeyModel); ok {
+		r0 = rf(ctx, userID, key, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.IdempotencyKeyModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, time.Time) error); ok {
+		r1 = rf(ctx, userID, key, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VotesRepository_GetIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIdempotencyKey'
+type VotesRepository_GetIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// GetIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - key string
+//   - now time.Time
+func (_e *VotesRepository_Expecter) GetIdempotencyKey(ctx interface{}, userID interface{}, key interface{}, now interface{}) *VotesRepository_GetIdempotencyKey_Call {
+	return &VotesRepository_GetIdempotencyKey_Call{Call: _e.mock.On("GetIdempotencyKey", ctx, userID, key, now)}
+}
+
+func (_c *VotesRepository_GetIdempotencyKey_Call) Run(run func(ctx context.Context, userID uuid.UUID, key string, now time.Time)) *VotesRepository_GetIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *VotesRepository_GetIdempotencyKey_Call) Return(_a0 *dao.IdempotencyKeyModel, _a1 error) *VotesRepository_GetIdempotencyKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VotesRepository_GetIdempotencyKey_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, time.Time) (*dao.IdempotencyKeyModel, error)) *VotesRepository_GetIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSummaries provides a mock function with given fields: ctx, target, targetIDs
+func (_m *VotesRepository) GetSummaries(ctx context.Context, target string, targetIDs []uuid.UUID) ([]*dao.VotesSummaryModel, error) {
+	ret := _m.Called(ctx, target, targetIDs)
+
+	var r0 []*dao.VotesSummaryModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []uuid.UUID) ([]*dao.VotesSummaryModel, error)); ok {
+		return rf(ctx, target, targetIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []uuid.UUID) []*dao.VotesSummaryModel); ok {
+		r0 = rf(ctx, target, targetIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.VotesSummaryModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []uuid.UUID) error); ok {
+		r1 = rf(ctx, target, targetIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VotesRepository_GetSummaries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSummaries'
+type VotesRepository_GetSummaries_Call struct {
+	*mock.Call
+}
+
+// GetSummaries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - target string
+//   - targetIDs []uuid.UUID
+func (_e *VotesRepository_Expecter) GetSummaries(ctx interface{}, target interface{}, targetIDs interface{}) *VotesRepository_GetSummaries_Call {
+	return &VotesRepository_GetSummaries_Call{Call: _e.mock.On("GetSummaries", ctx, target, targetIDs)}
+}
+
+func (_c *VotesRepository_GetSummaries_Call) Run(run func(ctx context.Context, target string, targetIDs []uuid.UUID)) *VotesRepository_GetSummaries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *VotesRepository_GetSummaries_Call) Return(_a0 []*dao.VotesSummaryModel, _a1 error) *VotesRepository_GetSummaries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VotesRepository_GetSummaries_Call) RunAndReturn(run func(context.Context, string, []uuid.UUID) ([]*dao.VotesSummaryModel, error)) *VotesRepository_GetSummaries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetSummary provides a mock function with given fields: ctx, targetID, target
 func (_m *VotesRepository) GetSummary(ctx context.Context, targetID uuid.UUID, target string) (*dao.VotesSummaryModel, error) {
 	ret := _m.Called(ctx, targetID, target)
@@ -201,25 +485,81 @@ func (_c *VotesRepository_GetSummary_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
-// ListUserVotes provides a mock function with given fields: ctx, userID, target, limit, offset
-func (_m *VotesRepository) ListUserVotes(ctx context.Context, userID uuid.UUID, target string, limit int, offset int) ([]*dao.VoteModel, error) {
-	ret := _m.Called(ctx, userID, target, limit, offset)
+// GetTargetLock provides a mock function with given fields: ctx, targetID, target
+func (_m *VotesRepository) GetTargetLock(ctx context.Context, targetID uuid.UUID, target string) (*dao.TargetLockModel, error) {
+	ret := _m.Called(ctx, targetID, target)
+
+	var r0 *dao.TargetLockModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*dao.TargetLockModel, error)); ok {
+		return rf(ctx, targetID, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *dao.TargetLockModel); ok {
+		r0 = rf(ctx, targetID, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.TargetLockModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, targetID, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VotesRepository_GetTargetLock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTargetLock'
+type VotesRepository_GetTargetLock_Call struct {
+	*mock.Call
+}
+
+// GetTargetLock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - targetID uuid.UUID
+//   - target string
+func (_e *VotesRepository_Expecter) GetTargetLock(ctx interface{}, targetID interface{}, target interface{}) *VotesRepository_GetTargetLock_Call {
+	return &VotesRepository_GetTargetLock_Call{Call: _e.mock.On("GetTargetLock", ctx, targetID, target)}
+}
+
+func (_c *VotesRepository_GetTargetLock_Call) Run(run func(ctx context.Context, targetID uuid.UUID, target string)) *VotesRepository_GetTargetLock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *VotesRepository_GetTargetLock_Call) Return(_a0 *dao.TargetLockModel, _a1 error) *VotesRepository_GetTargetLock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VotesRepository_GetTargetLock_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*dao.TargetLockModel, error)) *VotesRepository_GetTargetLock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserVotes provides a mock function with given fields: ctx, userID, target, targetIDs
+func (_m *VotesRepository) GetUserVotes(ctx context.Context, userID uuid.UUID, target string, targetIDs []uuid.UUID) ([]*dao.VoteModel, error) {
+	ret := _m.Called(ctx, userID, target, targetIDs)
 
 	var r0 []*dao.VoteModel
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, int, int) ([]*dao.VoteModel, error)); ok {
-		return rf(ctx, userID, target, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, []uuid.UUID) ([]*dao.VoteModel, error)); ok {
+		return rf(ctx, userID, target, targetIDs)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, int, int) []*dao.VoteModel); ok {
-		r0 = rf(ctx, userID, target, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, []uuid.UUID) []*dao.VoteModel); ok {
+		r0 = rf(ctx, userID, target, targetIDs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*dao.VoteModel)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, int, int) error); ok {
-		r1 = rf(ctx, userID, target, limit, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, []uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, target, targetIDs)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -227,77 +567,567 @@ func (_m *VotesRepository) ListUserVotes(ctx context.Context, userID uuid.UUID,
 	return r0, r1
 }
 
-// VotesRepository_ListUserVotes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserVotes'
-type VotesRepository_ListUserVotes_Call struct {
+// VotesRepository_GetUserVotes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserVotes'
+type VotesRepository_GetUserVotes_Call struct {
 	*mock.Call
 }
 
-// ListUserVotes is a helper method to define mock.On call
+// GetUserVotes is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID uuid.UUID
 //   - target string
-//   - limit int
-//   - offset int
-func (_e *VotesRepository_Expecter) ListUserVotes(ctx interface{}, userID interface{}, target interface{}, limit interface{}, offset interface{}) *VotesRepository_ListUserVotes_Call {
-	return &VotesRepository_ListUserVotes_Call{Call: _e.mock.On("ListUserVotes", ctx, userID, target, limit, offset)}
+//   - targetIDs []uuid.UUID
+func (_e *VotesRepository_Expecter) GetUserVotes(ctx interface{}, userID interface{}, target interface{}, targetIDs interface{}) *VotesRepository_GetUserVotes_Call {
+	return &VotesRepository_GetUserVotes_Call{Call: _e.mock.On("GetUserVotes", ctx, userID, target, targetIDs)}
 }
 
-func (_c *VotesRepository_ListUserVotes_Call) Run(run func(ctx context.Context, userID uuid.UUID, target string, limit int, offset int)) *VotesRepository_ListUserVotes_Call {
+func (_c *VotesRepository_GetUserVotes_Call) Run(run func(ctx context.Context, userID uuid.UUID, target string, targetIDs []uuid.UUID)) *VotesRepository_GetUserVotes_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(int), args[4].(int))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].([]uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *VotesRepository_ListUserVotes_Call) Return(_a0 []*dao.VoteModel, _a1 error) *VotesRepository_ListUserVotes_Call {
+func (_c *VotesRepository_GetUserVotes_Call) Return(_a0 []*dao.VoteModel, _a1 error) *VotesRepository_GetUserVotes_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *VotesRepository_ListUserVotes_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, int, int) ([]*dao.VoteModel, error)) *VotesRepository_ListUserVotes_Call {
+func (_c *VotesRepository_GetUserVotes_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, []uuid.UUID) ([]*dao.VoteModel, error)) *VotesRepository_GetUserVotes_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RunInTx provides a mock function with given fields: ctx, f
-func (_m *VotesRepository) RunInTx(ctx context.Context, f func(context.Context, dao.VotesRepository) error) error {
-	ret := _m.Called(ctx, f)
+// Invalidate provides a mock function with given fields: ctx, filter, moderatorID, reason, now
+func (_m *VotesRepository) Invalidate(ctx context.Context, filter dao.ModeratedVotesFilter, moderatorID uuid.UUID, reason string, now time.Time) ([]*dao.VoteModel, error) {
+	ret := _m.Called(ctx, filter, moderatorID, reason, now)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context, dao.VotesRepository) error) error); ok {
-		r0 = rf(ctx, f)
+	var r0 []*dao.VoteModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dao.ModeratedVotesFilter, uuid.UUID, string, time.Time) ([]*dao.VoteModel, error)); ok {
+		return rf(ctx, filter, moderatorID, reason, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dao.ModeratedVotesFilter, uuid.UUID, string, time.Time) []*dao.VoteModel); ok {
+		r0 = rf(ctx, filter, moderatorID, reason, now)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.VoteModel)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, dao.ModeratedVotesFilter, uuid.UUID, string, time.Time) error); ok {
+		r1 = rf(ctx, filter, moderatorID, reason, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// VotesRepository_RunInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunInTx'
-type VotesRepository_RunInTx_Call struct {
+// VotesRepository_Invalidate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Invalidate'
+type VotesRepository_Invalidate_Call struct {
 	*mock.Call
 }
 
-// RunInTx is a helper method to define mock.On call
+// Invalidate is a helper method to define mock.On call
 //   - ctx context.Context
-//   - f func(context.Context , dao.VotesRepository) error
-func (_e *VotesRepository_Expecter) RunInTx(ctx interface{}, f interface{}) *VotesRepository_RunInTx_Call {
-	return &VotesRepository_RunInTx_Call{Call: _e.mock.On("RunInTx", ctx, f)}
+//   - filter dao.ModeratedVotesFilter
+//   - moderatorID uuid.UUID
+//   - reason string
+//   - now time.Time
+func (_e *VotesRepository_Expecter) Invalidate(ctx interface{}, filter interface{}, moderatorID interface{}, reason interface{}, now interface{}) *VotesRepository_Invalidate_Call {
+	return &VotesRepository_Invalidate_Call{Call: _e.mock.On("Invalidate", ctx, filter, moderatorID, reason, now)}
 }
 
-func (_c *VotesRepository_RunInTx_Call) Run(run func(ctx context.Context, f func(context.Context, dao.VotesRepository) error)) *VotesRepository_RunInTx_Call {
+func (_c *VotesRepository_Invalidate_Call) Run(run func(ctx context.Context, filter dao.ModeratedVotesFilter, moderatorID uuid.UUID, reason string, now time.Time)) *VotesRepository_Invalidate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(func(context.Context, dao.VotesRepository) error))
+		run(args[0].(context.Context), args[1].(dao.ModeratedVotesFilter), args[2].(uuid.UUID), args[3].(string), args[4].(time.Time))
 	})
 	return _c
 }
 
-func (_c *VotesRepository_RunInTx_Call) Return(_a0 error) *VotesRepository_RunInTx_Call {
-	_c.Call.Return(_a0)
+func (_c *VotesRepository_Invalidate_Call) Return(_a0 []*dao.VoteModel, _a1 error) *VotesRepository_Invalidate_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *VotesRepository_RunInTx_Call) RunAndReturn(run func(context.Context, func(context.Context, dao.VotesRepository) error) error) *VotesRepository_RunInTx_Call {
+func (_c *VotesRepository_Invalidate_Call) RunAndReturn(run func(context.Context, dao.ModeratedVotesFilter, uuid.UUID, string, time.Time) ([]*dao.VoteModel, error)) *VotesRepository_Invalidate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListHotTargets provides a mock function with given fields: ctx, target, gravity, since, now, limit, offset
+func (_m *VotesRepository) ListHotTargets(ctx context.Context, target string, gravity float64, since time.Time, now time.Time, limit int, offset int) ([]*dao.TargetScoreModel, error) {
+	ret := _m.Called(ctx, target, gravity, since, now, limit, offset)
+
+	var r0 []*dao.TargetScoreModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, time.Time, time.Time, int, int) ([]*dao.TargetScoreModel, error)); ok {
+		return rf(ctx, target, gravity, since, now, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, time.Time, time.Time, int, int) []*dao.TargetScoreModel); ok {
+		r0 = rf(ctx, target, gravity, since, now, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.TargetScoreModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64, time.Time, time.Time, int, int) error); ok {
+		r1 = rf(ctx, target, gravity, since, now, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VotesRepository_ListHotTargets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListHotTargets'
+type VotesRepository_ListHotTargets_Call struct {
+	*mock.Call
+}
+
+// ListHotTargets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - target string
+//   - gravity float64
+//   - since time.Time
+//   - now time.Time
+//   - limit int
+//   - offset int
+func (_e *VotesRepository_Expecter) ListHotTargets(ctx interface{}, target interface{}, gravity interface{}, since interface{}, now interface{}, limit interface{}, offset interface{}) *VotesRepository_ListHotTargets_Call {
+	return &VotesRepository_ListHotTargets_Call{Call: _e.mock.On("ListHotTargets", ctx, target, gravity, since, now, limit, offset)}
+}
+
+func (_c *VotesRepository_ListHotTargets_Call) Run(run func(ctx context.Context, target string, gravity float64, since time.Time, now time.Time, limit int, offset int)) *VotesRepository_ListHotTargets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(float64), args[3].(time.Time), args[4].(time.Time), args[5].(int), args[6].(int))
+	})
+	return _c
+}
+
+func (_c *VotesRepository_ListHotTargets_Call) Return(_a0 []*dao.TargetScoreModel, _a1 error) *VotesRepository_ListHotTargets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VotesRepository_ListHotTargets_Call) RunAndReturn(run func(context.Context, string, float64, time.Time, time.Time, int, int) ([]*dao.TargetScoreModel, error)) *VotesRepository_ListHotTargets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTargetVotes provides a mock function with given fields: ctx, targetID, target, filter, cursor, limit
+func (_m *VotesRepository) ListTargetVotes(ctx context.Context, targetID uuid.UUID, target string, filter dao.TargetVotesFilter, cursor *dao.VotesCursor, limit int) ([]*dao.VoteModel, error) {
+	ret := _m.Called(ctx, targetID, target, filter, cursor, limit)
+
+	var r0 []*dao.VoteModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, dao.TargetVotesFilter, *dao.VotesCursor, int) ([]*dao.VoteModel, error)); ok {
+		return rf(ctx, targetID, target, filter, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, dao.TargetVotesFilter, *dao.VotesCursor, int) []*dao.VoteModel); ok {
+		r0 = rf(ctx, targetID, target, filter, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.VoteModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, dao.TargetVotesFilter, *dao.VotesCursor, int) error); ok {
+		r1 = rf(ctx, targetID, target, filter, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VotesRepository_ListTargetVotes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTargetVotes'
+type VotesRepository_ListTargetVotes_Call struct {
+	*mock.Call
+}
+
+// ListTargetVotes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - targetID uuid.UUID
+//   - target string
+//   - filter dao.TargetVotesFilter
+//   - cursor *dao.VotesCursor
+//   - limit int
+func (_e *VotesRepository_Expecter) ListTargetVotes(ctx interface{}, targetID interface{}, target interface{}, filter interface{}, cursor interface{}, limit interface{}) *VotesRepository_ListTargetVotes_Call {
+	return &VotesRepository_ListTargetVotes_Call{Call: _e.mock.On("ListTargetVotes", ctx, targetID, target, filter, cursor, limit)}
+}
+
+func (_c *VotesRepository_ListTargetVotes_Call) Run(run func(ctx context.Context, targetID uuid.UUID, target string, filter dao.TargetVotesFilter, cursor *dao.VotesCursor, limit int)) *VotesRepository_ListTargetVotes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(dao.TargetVotesFilter), args[4].(*dao.VotesCursor), args[5].(int))
+	})
+	return _c
+}
+
+func (_c *VotesRepository_ListTargetVotes_Call) Return(_a0 []*dao.VoteModel, _a1 error) *VotesRepository_ListTargetVotes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VotesRepository_ListTargetVotes_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, dao.TargetVotesFilter, *dao.VotesCursor, int) ([]*dao.VoteModel, error)) *VotesRepository_ListTargetVotes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTopRatedTargets provides a mock function with given fields: ctx, target, priorWeight, limit, offset
+func (_m *VotesRepository) ListTopRatedTargets(ctx context.Context, target string, priorWeight float64, limit int, offset int) ([]*dao.TargetScoreModel, error) {
+	ret := _m.Called(ctx, target, priorWeight, limit, offset)
+
+	var r0 []*dao.TargetScoreModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, int, int) ([]*dao.TargetScoreModel, error)); ok {
+		return rf(ctx, target, priorWeight, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, int, int) []*dao.TargetScoreModel); ok {
+		r0 = rf(ctx, target, priorWeight, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.TargetScoreModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64, int, int) error); ok {
+		r1 = rf(ctx, target, priorWeight, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VotesRepository_ListTopRatedTargets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTopRatedTargets'
+type VotesRepository_ListTopRatedTargets_Call struct {
+	*mock.Call
+}
+
+// ListTopRatedTargets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - target string
+//   - priorWeight float64
+//   - limit int
+//   - offset int
+func (_e *VotesRepository_Expecter) ListTopRatedTargets(ctx interface{}, target interface{}, priorWeight interface{}, limit interface{}, offset interface{}) *VotesRepository_ListTopRatedTargets_Call {
+	return &VotesRepository_ListTopRatedTargets_Call{Call: _e.mock.On("ListTopRatedTargets", ctx, target, priorWeight, limit, offset)}
+}
+
+func (_c *VotesRepository_ListTopRatedTargets_Call) Run(run func(ctx context.Context, target string, priorWeight float64, limit int, offset int)) *VotesRepository_ListTopRatedTargets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(float64), args[3].(int), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *VotesRepository_ListTopRatedTargets_Call) Return(_a0 []*dao.TargetScoreModel, _a1 error) *VotesRepository_ListTopRatedTargets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VotesRepository_ListTopRatedTargets_Call) RunAndReturn(run func(context.Context, string, float64, int, int) ([]*dao.TargetScoreModel, error)) *VotesRepository_ListTopRatedTargets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserVotes provides a mock function with given fields: ctx, userID, filter, cursor, limit
+func (_m *VotesRepository) ListUserVotes(ctx context.Context, userID uuid.UUID, filter dao.UserVotesFilter, cursor *dao.VotesCursor, limit int) ([]*dao.VoteModel, error) {
+	ret := _m.Called(ctx, userID, filter, cursor, limit)
+
+	var r0 []*dao.VoteModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, dao.UserVotesFilter, *dao.VotesCursor, int) ([]*dao.VoteModel, error)); ok {
+		return rf(ctx, userID, filter, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, dao.UserVotesFilter, *dao.VotesCursor, int) []*dao.VoteModel); ok {
+		r0 = rf(ctx, userID, filter, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.VoteModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, dao.UserVotesFilter, *dao.VotesCursor, int) error); ok {
+		r1 = rf(ctx, userID, filter, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VotesRepository_ListUserVotes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserVotes'
+type VotesRepository_ListUserVotes_Call struct {
+	*mock.Call
+}
+
+// ListUserVotes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - filter dao.UserVotesFilter
+//   - cursor *dao.VotesCursor
+//   - limit int
+func (_e *VotesRepository_Expecter) ListUserVotes(ctx interface{}, userID interface{}, filter interface{}, cursor interface{}, limit interface{}) *VotesRepository_ListUserVotes_Call {
+	return &VotesRepository_ListUserVotes_Call{Call: _e.mock.On("ListUserVotes", ctx, userID, filter, cursor, limit)}
+}
+
+func (_c *VotesRepository_ListUserVotes_Call) Run(run func(ctx context.Context, userID uuid.UUID, filter dao.UserVotesFilter, cursor *dao.VotesCursor, limit int)) *VotesRepository_ListUserVotes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(dao.UserVotesFilter), args[3].(*dao.VotesCursor), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *VotesRepository_ListUserVotes_Call) Return(_a0 []*dao.VoteModel, _a1 error) *VotesRepository_ListUserVotes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VotesRepository_ListUserVotes_Call) RunAndReturn(run func(context.Context, uuid.UUID, dao.UserVotesFilter, *dao.VotesCursor, int) ([]*dao.VoteModel, error)) *VotesRepository_ListUserVotes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeIdempotencyKeys provides a mock function with given fields: ctx, now
+func (_m *VotesRepository) PurgeIdempotencyKeys(ctx context.Context, now time.Time) (int, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VotesRepository_PurgeIdempotencyKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeIdempotencyKeys'
+type VotesRepository_PurgeIdempotencyKeys_Call struct {
+	*mock.Call
+}
+
+// PurgeIdempotencyKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *VotesRepository_Expecter) PurgeIdempotencyKeys(ctx interface{}, now interface{}) *VotesRepository_PurgeIdempotencyKeys_Call {
+	return &VotesRepository_PurgeIdempotencyKeys_Call{Call: _e.mock.On("PurgeIdempotencyKeys", ctx, now)}
+}
+
+func (_c *VotesRepository_PurgeIdempotencyKeys_Call) Run(run func(ctx context.Context, now time.Time)) *VotesRepository_PurgeIdempotencyKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *VotesRepository_PurgeIdempotencyKeys_Call) Return(_a0 int, _a1 error) *VotesRepository_PurgeIdempotencyKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VotesRepository_PurgeIdempotencyKeys_Call) RunAndReturn(run func(context.Context, time.Time) (int, error)) *VotesRepository_PurgeIdempotencyKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueueSummaryUpdate provides a mock function with given fields: ctx, userID, summary, now
+func (_m *VotesRepository) QueueSummaryUpdate(ctx context.Context, userID uuid.UUID, summary *dao.VotesSummaryModel, now time.Time) error {
+	ret := _m.Called(ctx, userID, summary, now)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *dao.VotesSummaryModel, time.Time) error); ok {
+		r0 = rf(ctx, userID, summary, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VotesRepository_QueueSummaryUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueueSummaryUpdate'
+type VotesRepository_QueueSummaryUpdate_Call struct {
+	*mock.Call
+}
+
+// QueueSummaryUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - summary *dao.VotesSummaryModel
+//   - now time.Time
+func (_e *VotesRepository_Expecter) QueueSummaryUpdate(ctx interface{}, userID interface{}, summary interface{}, now interface{}) *VotesRepository_QueueSummaryUpdate_Call {
+	return &VotesRepository_QueueSummaryUpdate_Call{Call: _e.mock.On("QueueSummaryUpdate", ctx, userID, summary, now)}
+}
+
+func (_c *VotesRepository_QueueSummaryUpdate_Call) Run(run func(ctx context.Context, userID uuid.UUID, summary *dao.VotesSummaryModel, now time.Time)) *VotesRepository_QueueSummaryUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*dao.VotesSummaryModel), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *VotesRepository_QueueSummaryUpdate_Call) Return(_a0 error) *VotesRepository_QueueSummaryUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *VotesRepository_QueueSummaryUpdate_Call) RunAndReturn(run func(context.Context, uuid.UUID, *dao.VotesSummaryModel, time.Time) error) *VotesRepository_QueueSummaryUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function with given fields: ctx, filter
+func (_m *VotesRepository) Restore(ctx context.Context, filter dao.ModeratedVotesFilter) ([]*dao.VoteModel, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []*dao.VoteModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dao.ModeratedVotesFilter) ([]*dao.VoteModel, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dao.ModeratedVotesFilter) []*dao.VoteModel); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.VoteModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dao.ModeratedVotesFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VotesRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type VotesRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter dao.ModeratedVotesFilter
+func (_e *VotesRepository_Expecter) Restore(ctx interface{}, filter interface{}) *VotesRepository_Restore_Call {
+	return &VotesRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, filter)}
+}
+
+func (_c *VotesRepository_Restore_Call) Run(run func(ctx context.Context, filter dao.ModeratedVotesFilter)) *VotesRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dao.ModeratedVotesFilter))
+	})
+	return _c
+}
+
+func (_c *VotesRepository_Restore_Call) Return(_a0 []*dao.VoteModel, _a1 error) *VotesRepository_Restore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VotesRepository_Restore_Call) RunAndReturn(run func(context.Context, dao.ModeratedVotesFilter) ([]*dao.VoteModel, error)) *VotesRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunInTx provides a mock function with given fields: ctx, f
+func (_m *VotesRepository) RunInTx(ctx context.Context, f func(context.Context, dao.VotesRepository) error) error {
+	ret := _m.Called(ctx, f)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context, dao.VotesRepository) error) error); ok {
+		r0 = rf(ctx, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VotesRepository_RunInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunInTx'
+type VotesRepository_RunInTx_Call struct {
+	*mock.Call
+}
+
+// RunInTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - f func(context.Context , dao.VotesRepository) error
+func (_e *VotesRepository_Expecter) RunInTx(ctx interface{}, f interface{}) *VotesRepository_RunInTx_Call {
+	return &VotesRepository_RunInTx_Call{Call: _e.mock.On("RunInTx", ctx, f)}
+}
+
+func (_c *VotesRepository_RunInTx_Call) Run(run func(ctx context.Context, f func(context.Context, dao.VotesRepository) error)) *VotesRepository_RunInTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context, dao.VotesRepository) error))
+	})
+	return _c
+}
+
+func (_c *VotesRepository_RunInTx_Call) Return(_a0 error) *VotesRepository_RunInTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *VotesRepository_RunInTx_Call) RunAndReturn(run func(context.Context, func(context.Context, dao.VotesRepository) error) error) *VotesRepository_RunInTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveIdempotencyResponse provides a mock function with given fields: ctx, userID, key, response
+func (_m *VotesRepository) SaveIdempotencyResponse(ctx context.Context, userID uuid.UUID, key string, response jsontext.Value) error {
+	ret := _m.Called(ctx, userID, key, response)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, jsontext.Value) error); ok {
+		r0 = rf(ctx, userID, key, response)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VotesRepository_SaveIdempotencyResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveIdempotencyResponse'
+type VotesRepository_SaveIdempotencyResponse_Call struct {
+	*mock.Call
+}
+
+// SaveIdempotencyResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - key string
+//   - response jsontext.Value
+func (_e *VotesRepository_Expecter) SaveIdempotencyResponse(ctx interface{}, userID interface{}, key interface{}, response interface{}) *VotesRepository_SaveIdempotencyResponse_Call {
+	return &VotesRepository_SaveIdempotencyResponse_Call{Call: _e.mock.On("SaveIdempotencyResponse", ctx, userID, key, response)}
+}
+
+func (_c *VotesRepository_SaveIdempotencyResponse_Call) Run(run func(ctx context.Context, userID uuid.UUID, key string, response jsontext.Value)) *VotesRepository_SaveIdempotencyResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].(jsontext.Value))
+	})
+	return _c
+}
+
+func (_c *VotesRepository_SaveIdempotencyResponse_Call) Return(_a0 error) *VotesRepository_SaveIdempotencyResponse_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *VotesRepository_SaveIdempotencyResponse_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, jsontext.Value) error) *VotesRepository_SaveIdempotencyResponse_Call {
 	_c.Call.Return(run)
 	return _c
 }
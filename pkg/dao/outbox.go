@@ -0,0 +1,125 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+type OutboxRepository interface {
+	// Claim returns the messages ready for delivery, and hides them from other dispatchers until leaseUntil.
+	Claim(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*OutboxMessageModel, error)
+	// Acknowledge removes a delivered message. It does nothing if the message was overwritten since it was claimed.
+	Acknowledge(ctx context.Context, id int64, version int) error
+	// Retry schedules a new delivery attempt for a message.
+	Retry(ctx context.Context, id int64, version int, lastError string, nextAttemptAt time.Time) error
+	// DeadLetter gives up on a message. It is kept for inspection.
+	DeadLetter(ctx context.Context, id int64, version int, lastError string, now time.Time) error
+}
+
+// OutboxMessageModel is written by VotesRepository.QueueSummaryUpdate, in the same transaction as the vote.
+type OutboxMessageModel struct {
+	bun.BaseModel `bun:"table:vote_outbox"`
+
+	ID        int64     `bun:"id,pk,autoincrement"`
+	CreatedAt time.Time `bun:"created_at"`
+	Version   int       `bun:"version"`
+
+	Target    string    `bun:"target"`
+	TargetID  uuid.UUID `bun:"target_id"`
+	UserID    uuid.UUID `bun:"user_id"`
+	UpVotes   int       `bun:"up_votes"`
+	DownVotes int       `bun:"down_votes"`
+
+	WeightedUpVotes   int `bun:"weighted_up_votes"`
+	WeightedDownVotes int `bun:"weighted_down_votes"`
+
+	Counts  map[models.VoteValue]int `bun:"counts,type:jsonb,nullzero,default:'{}'"`
+	Ratings map[int]int              `bun:"ratings,type:jsonb,nullzero,default:'{}'"`
+
+	Attempts      int        `bun:"attempts"`
+	NextAttemptAt time.Time  `bun:"next_attempt_at"`
+	LastError     *string    `bun:"last_error"`
+	DeadAt        *time.Time `bun:"dead_at"`
+}
+
+func NewOutboxRepository(db bun.IDB) OutboxRepository {
+	return &outboxRepositoryImpl{db: db}
+}
+
+type outboxRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *outboxRepositoryImpl) Claim(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*OutboxMessageModel, error) {
+	messages := make([]*OutboxMessageModel, 0)
+
+	// Rows locked by another dispatcher are skipped rather than waited for.
+	ready := repository.db.NewSelect().Model((*OutboxMessageModel)(nil)).
+		Column("id").
+		Where("dead_at IS NULL").
+		Where("next_attempt_at <= ?", now).
+		Order("next_attempt_at", "id").
+		Limit(limit).
+		For("UPDATE SKIP LOCKED")
+
+	err := repository.db.NewUpdate().Model((*OutboxMessageModel)(nil)).
+		Set("next_attempt_at = ?", leaseUntil).
+		Where("id IN (?)", ready).
+		Returning("*").
+		Scan(ctx, &messages)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return messages, nil
+}
+
+func (repository *outboxRepositoryImpl) Acknowledge(ctx context.Context, id int64, version int) error {
+	_, err := repository.db.NewDelete().Model((*OutboxMessageModel)(nil)).
+		Where("id = ?", id).
+		Where("version = ?", version).
+		Exec(ctx)
+
+	if err != nil {
+		return bunovel.HandlePGError(err)
+	}
+
+	return nil
+}
+
+func (repository *outboxRepositoryImpl) Retry(ctx context.Context, id int64, version int, lastError string, nextAttemptAt time.Time) error {
+	_, err := repository.db.NewUpdate().Model((*OutboxMessageModel)(nil)).
+		Set("attempts = attempts + 1").
+		Set("last_error = ?", lastError).
+		Set("next_attempt_at = ?", nextAttemptAt).
+		Where("id = ?", id).
+		Where("version = ?", version).
+		Exec(ctx)
+
+	if err != nil {
+		return bunovel.HandlePGError(err)
+	}
+
+	return nil
+}
+
+func (repository *outboxRepositoryImpl) DeadLetter(ctx context.Context, id int64, version int, lastError string, now time.Time) error {
+	_, err := repository.db.NewUpdate().Model((*OutboxMessageModel)(nil)).
+		Set("attempts = attempts + 1").
+		Set("last_error = ?", lastError).
+		Set("dead_at = ?", now).
+		Where("id = ?", id).
+		Where("version = ?", version).
+		Exec(ctx)
+
+	if err != nil {
+		return bunovel.HandlePGError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/migrations"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+func TestOutboxRepository_Claim(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.OutboxMessageModel{
+		{
+			ID:            1,
+			CreatedAt:     baseTime,
+			Target:        "target",
+			TargetID:      goframework.NumberUUID(1),
+			UserID:        goframework.NumberUUID(1),
+			UpVotes:       10,
+			DownVotes:     5,
+			NextAttemptAt: baseTime,
+			Counts:        map[models.VoteValue]int{models.VoteValueUp: 10, models.VoteValueDown: 5},
+			Ratings:       map[int]int{},
+		},
+		{
+			ID:            2,
+			CreatedAt:     baseTime,
+			Target:        "target",
+			TargetID:      goframework.NumberUUID(2),
+			UserID:        goframework.NumberUUID(1),
+			UpVotes:       1,
+			Attempts:      2,
+			NextAttemptAt: baseTime.Add(time.Minute),
+			Counts:        map[models.VoteValue]int{models.VoteValueUp: 1},
+			Ratings:       map[int]int{},
+		},
+		// Not ready yet.
+		{
+			ID:            3,
+			CreatedAt:     baseTime,
+			Target:        "target",
+			TargetID:      goframework.NumberUUID(3),
+			UserID:        goframework.NumberUUID(1),
+			NextAttemptAt: updateTime,
+			Counts:        map[models.VoteValue]int{},
+			Ratings:       map[int]int{},
+		},
+		// Dead-lettered.
+		{
+			ID:            4,
+			CreatedAt:     baseTime,
+			Target:        "target",
+			TargetID:      goframework.NumberUUID(4),
+			UserID:        goframework.NumberUUID(1),
+			Attempts:      10,
+			NextAttemptAt: baseTime,
+			DeadAt:        lo.ToPtr(baseTime),
+			Counts:        map[models.VoteValue]int{},
+			Ratings:       map[int]int{},
+		},
+	}
+
+	// Cases run in order, on the same transaction: claimed messages stay hidden in the following cases.
+	data := []struct {
+		name string
+
+		now        time.Time
+		leaseUntil time.Time
+		limit      int
+
+		expect    []int64
+		expectErr error
+	}{
+		{
+			name:       "Success",
+			now:        baseTime.Add(time.Minute),
+			leaseUntil: baseTime.Add(30 * time.Minute),
+			limit:      1,
+			expect:     []int64{1},
+		},
+		{
+			name:       "Success/SkipClaimed",
+			now:        baseTime.Add(time.Minute),
+			leaseUntil: baseTime.Add(30 * time.Minute),
+			limit:      10,
+			expect:     []int64{2},
+		},
+		{
+			name:       "Success/NothingReady",
+			now:        baseTime.Add(time.Minute),
+			leaseUntil: baseTime.Add(30 * time.Minute),
+			limit:      10,
+			expect:     []int64{},
+		},
+		{
+			name:       "Success/LeaseExpired",
+			now:        updateTime,
+			leaseUntil: updateTime.Add(30 * time.Minute),
+			limit:      10,
+			expect:     []int64{1, 2, 3},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewOutboxRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.Claim(ctx, d.now, d.leaseUntil, d.limit)
+				require.ErrorIs(t, err, d.expectErr)
+				require.ElementsMatch(t, d.expect, lo.Map(res, func(item *dao.OutboxMessageModel, _ int) int64 {
+					return item.ID
+				}))
+
+				for _, message := range res {
+					require.Equal(t, d.leaseUntil, message.NextAttemptAt)
+				}
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestOutboxRepository_Update(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.OutboxMessageModel{
+		{
+			ID:            1,
+			CreatedAt:     baseTime,
+			Version:       1,
+			Target:        "target",
+			TargetID:      goframework.NumberUUID(1),
+			UserID:        goframework.NumberUUID(1),
+			UpVotes:       10,
+			DownVotes:     5,
+			Attempts:      1,
+			NextAttemptAt: baseTime,
+			Counts:        map[models.VoteValue]int{models.VoteValueUp: 10, models.VoteValueDown: 5},
+			Ratings:       map[int]int{},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		// Either "acknowledge", "retry" or "deadLetter".
+		action  string
+		version int
+
+		expect    *dao.OutboxMessageModel
+		expectErr error
+	}{
+		{
+			name:    "Success/Acknowledge",
+			action:  "acknowledge",
+			version: 1,
+		},
+		{
+			name:    "Success/Retry",
+			action:  "retry",
+			version: 1,
+			expect: &dao.OutboxMessageModel{
+				ID:            1,
+				CreatedAt:     baseTime,
+				Version:       1,
+				Target:        "target",
+				TargetID:      goframework.NumberUUID(1),
+				UserID:        goframework.NumberUUID(1),
+				UpVotes:       10,
+				DownVotes:     5,
+				Attempts:      2,
+				NextAttemptAt: updateTime,
+				LastError:     lo.ToPtr("foo"),
+				Counts:        map[models.VoteValue]int{models.VoteValueUp: 10, models.VoteValueDown: 5},
+				Ratings:       map[int]int{},
+			},
+		},
+		{
+			name:    "Success/DeadLetter",
+			action:  "deadLetter",
+			version: 1,
+			expect: &dao.OutboxMessageModel{
+				ID:            1,
+				CreatedAt:     baseTime,
+				Version:       1,
+				Target:        "target",
+				TargetID:      goframework.NumberUUID(1),
+				UserID:        goframework.NumberUUID(1),
+				UpVotes:       10,
+				DownVotes:     5,
+				Attempts:      2,
+				NextAttemptAt: baseTime,
+				LastError:     lo.ToPtr("foo"),
+				DeadAt:        lo.ToPtr(updateTime),
+				Counts:        map[models.VoteValue]int{models.VoteValueUp: 10, models.VoteValueDown: 5},
+				Ratings:       map[int]int{},
+			},
+		},
+		// The message was overwritten by a newer vote since it was claimed.
+		{
+			name:    "Success/Outdated",
+			action:  "acknowledge",
+			version: 0,
+			expect:  fixtures[0],
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(st *testing.T) {
+			err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+				repository := dao.NewOutboxRepository(tx)
+
+				var err error
+				switch d.action {
+				case "acknowledge":
+					err = repository.Acknowledge(ctx, 1, d.version)
+				case "retry":
+					err = repository.Retry(ctx, 1, d.version, "foo", updateTime)
+				case "deadLetter":
+					err = repository.DeadLetter(ctx, 1, d.version, "foo", updateTime)
+				}
+				require.ErrorIs(t, err, d.expectErr)
+
+				messages := make([]*dao.OutboxMessageModel, 0)
+				require.NoError(t, tx.NewSelect().Model(&messages).Scan(ctx))
+
+				if d.expect == nil {
+					require.Empty(t, messages)
+				} else {
+					require.Equal(t, []*dao.OutboxMessageModel{d.expect}, messages)
+				}
+			})
+			require.NoError(t, err)
+		})
+	}
+}
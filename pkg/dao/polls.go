@@ -0,0 +1,194 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+type PollsRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*PollModel, error)
+	Create(ctx context.Context, core *PollModelCore, createdBy, id uuid.UUID, now time.Time) (*PollModel, error)
+	// CastBallot records the ballot of a user. It returns false if the user already cast a ballot on the poll, which
+	// is left untouched.
+	CastBallot(ctx context.Context, pollID, userID uuid.UUID, choices []int, now time.Time) (bool, error)
+	CountBallots(ctx context.Context, pollID uuid.UUID) (int, error)
+	// CountChoices returns the number of ballots that chose each option. Options nobody chose are omitted.
+	CountChoices(ctx context.Context, pollID uuid.UUID) ([]*PollChoiceCountModel, error)
+	// CastRanking records the ranked ballot of a user. It returns false if the user already cast a ranked ballot on
+	// the poll, which is left untouched.
+	CastRanking(ctx context.Context, pollID, userID uuid.UUID, ranking []int, now time.Time) (bool, error)
+	// ListRankings returns every ranked ballot of a poll, in the order they were cast.
+	ListRankings(ctx context.Context, pollID uuid.UUID) ([]*PollRankingModel, error)
+}
+
+type PollModel struct {
+	bun.BaseModel `bun:"table:polls"`
+	bunovel.Metadata
+
+	CreatedBy uuid.UUID `bun:"created_by"`
+
+	PollModelCore
+}
+
+type PollModelCore struct {
+	Question    string     `bun:"question"`
+	Options     []string   `bun:"options,array"`
+	MultiSelect bool       `bun:"multi_select"`
+	OpensAt     time.Time  `bun:"opens_at"`
+	ClosesAt    *time.Time `bun:"closes_at"`
+	HideResults bool       `bun:"hide_results"`
+	Ranked      bool       `bun:"ranked"`
+}
+
+type PollBallotModel struct {
+	bun.BaseModel `bun:"table:poll_ballots"`
+
+	PollID    uuid.UUID `bun:"poll_id,pk"`
+	UserID    uuid.UUID `bun:"user_id,pk"`
+	CreatedAt time.Time `bun:"created_at"`
+	Choices   []int     `bun:"choices,array"`
+}
+
+type PollRankingModel struct {
+	bun.BaseModel `bun:"table:poll_rankings"`
+
+	PollID    uuid.UUID `bun:"poll_id,pk"`
+	UserID    uuid.UUID `bun:"user_id,pk"`
+	CreatedAt time.Time `bun:"created_at"`
+	Ranking   []int     `bun:"ranking,array"`
+}
+
+type PollChoiceCountModel struct {
+	Choice int `bun:"choice"`
+	Count  int `bun:"count"`
+}
+
+func NewPollsRepository(db bun.IDB) PollsRepository {
+	return &pollsRepositoryImpl{db: db}
+}
+
+type pollsRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *pollsRepositoryImpl) Get(ctx context.Context, id uuid.UUID) (*PollModel, error) {
+	model := new(PollModel)
+
+	if err := repository.db.NewSelect().Model(model).Where("id = ?", id).Scan(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *pollsRepositoryImpl) Create(ctx context.Context, core *PollModelCore, createdBy, id uuid.UUID, now time.Time) (*PollModel, error) {
+	model := &PollModel{
+		Metadata:      bunovel.NewMetadata(id, now, nil),
+		CreatedBy:     createdBy,
+		PollModelCore: *core,
+	}
+
+	if _, err := repository.db.NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *pollsRepositoryImpl) CastBallot(ctx context.Context, pollID, userID uuid.UUID, choices []int, now time.Time) (bool, error) {
+	model := &PollBallotModel{
+		PollID:    pollID,
+		UserID:    userID,
+		CreatedAt: now,
+		Choices:   choices,
+	}
+
+	res, err := repository.db.NewInsert().Model(model).
+		On("CONFLICT (poll_id, user_id) DO NOTHING").
+		Exec(ctx)
+
+	if err != nil {
+		return false, bunovel.HandlePGError(err)
+	}
+
+	cast, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return cast > 0, nil
+}
+
+func (repository *pollsRepositoryImpl) CountBallots(ctx context.Context, pollID uuid.UUID) (int, error) {
+	count, err := repository.db.NewSelect().Model((*PollBallotModel)(nil)).
+		Where("poll_id = ?", pollID).
+		Count(ctx)
+
+	if err != nil {
+		return 0, bunovel.HandlePGError(err)
+	}
+
+	return count, nil
+}
+
+func (repository *pollsRepositoryImpl) CountChoices(ctx context.Context, pollID uuid.UUID) ([]*PollChoiceCountModel, error) {
+	counts := make([]*PollChoiceCountModel, 0)
+
+	// Each ballot is expanded into one row per choice.
+	err := repository.db.NewSelect().Model((*PollBallotModel)(nil)).
+		TableExpr("UNNEST(choices) AS choice").
+		ColumnExpr("choice").
+		ColumnExpr("COUNT(*) AS count").
+		Where("poll_id = ?", pollID).
+		Group("choice").
+		OrderExpr("choice").
+		Scan(ctx, &counts)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return counts, nil
+}
+
+func (repository *pollsRepositoryImpl) CastRanking(ctx context.Context, pollID, userID uuid.UUID, ranking []int, now time.Time) (bool, error) {
+	model := &PollRankingModel{
+		PollID:    pollID,
+		UserID:    userID,
+		CreatedAt: now,
+		Ranking:   ranking,
+	}
+
+	res, err := repository.db.NewInsert().Model(model).
+		On("CONFLICT (poll_id, user_id) DO NOTHING").
+		Exec(ctx)
+
+	if err != nil {
+		return false, bunovel.HandlePGError(err)
+	}
+
+	cast, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return cast > 0, nil
+}
+
+func (repository *pollsRepositoryImpl) ListRankings(ctx context.Context, pollID uuid.UUID) ([]*PollRankingModel, error) {
+	rankings := make([]*PollRankingModel, 0)
+
+	err := repository.db.NewSelect().Model(&rankings).
+		Where("poll_id = ?", pollID).
+		Order("created_at", "user_id").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return rankings, nil
+}
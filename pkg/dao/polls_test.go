@@ -0,0 +1,520 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/migrations"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+func TestPollsRepository_Get(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.PollModel{
+		{
+			Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			CreatedBy: goframework.NumberUUID(100),
+			PollModelCore: dao.PollModelCore{
+				Question:    "Which feature next?",
+				Options:     []string{"Dark mode", "Bookmarks"},
+				MultiSelect: true,
+				OpensAt:     baseTime,
+				ClosesAt:    lo.ToPtr(updateTime),
+				HideResults: true,
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		id uuid.UUID
+
+		expect    *dao.PollModel
+		expectErr error
+	}{
+		{
+			name: "Success",
+			id:   goframework.NumberUUID(1),
+			expect: &dao.PollModel{
+				Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CreatedBy: goframework.NumberUUID(100),
+				PollModelCore: dao.PollModelCore{
+					Question:    "Which feature next?",
+					Options:     []string{"Dark mode", "Bookmarks"},
+					MultiSelect: true,
+					OpensAt:     baseTime,
+					ClosesAt:    lo.ToPtr(updateTime),
+					HideResults: true,
+				},
+			},
+		},
+		{
+			name:      "Error/NotFound",
+			id:        goframework.NumberUUID(2),
+			expectErr: bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewPollsRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.Get(ctx, d.id)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestPollsRepository_Create(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.PollModel{
+		{
+			Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			CreatedBy: goframework.NumberUUID(100),
+			PollModelCore: dao.PollModelCore{
+				Question: "Which feature next?",
+				Options:  []string{"Dark mode", "Bookmarks"},
+				OpensAt:  baseTime,
+			},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		core      *dao.PollModelCore
+		createdBy uuid.UUID
+		id        uuid.UUID
+		now       time.Time
+
+		expect    *dao.PollModel
+		expectErr error
+	}{
+		{
+			name: "Success",
+			core: &dao.PollModelCore{
+				Question:    "Which color?",
+				Options:     []string{"Red", "Green", "Blue"},
+				MultiSelect: true,
+				OpensAt:     updateTime,
+				ClosesAt:    lo.ToPtr(updateTime.Add(24 * time.Hour)),
+				HideResults: true,
+			},
+			createdBy: goframework.NumberUUID(100),
+			id:        goframework.NumberUUID(2),
+			now:       baseTime,
+			expect: &dao.PollModel{
+				Metadata:  bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+				CreatedBy: goframework.NumberUUID(100),
+				PollModelCore: dao.PollModelCore{
+					Question:    "Which color?",
+					Options:     []string{"Red", "Green", "Blue"},
+					MultiSelect: true,
+					OpensAt:     updateTime,
+					ClosesAt:    lo.ToPtr(updateTime.Add(24 * time.Hour)),
+					HideResults: true,
+				},
+			},
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(st *testing.T) {
+			err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+				repository := dao.NewPollsRepository(tx)
+
+				res, err := repository.Create(ctx, d.core, d.createdBy, d.id, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPollsRepository_CastBallot(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.PollModel{
+		{
+			Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			CreatedBy: goframework.NumberUUID(100),
+			PollModelCore: dao.PollModelCore{
+				Question:    "Which feature next?",
+				Options:     []string{"Dark mode", "Bookmarks", "Offline reading"},
+				MultiSelect: true,
+				OpensAt:     baseTime,
+			},
+		},
+	}
+
+	ballotsFixtures := []*dao.PollBallotModel{
+		{
+			PollID:    goframework.NumberUUID(1),
+			UserID:    goframework.NumberUUID(10),
+			CreatedAt: baseTime,
+			Choices:   []int{0},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		pollID  uuid.UUID
+		userID  uuid.UUID
+		choices []int
+		now     time.Time
+
+		expect        bool
+		expectBallots []*dao.PollBallotModel
+		expectErr     error
+	}{
+		{
+			name:    "Success",
+			pollID:  goframework.NumberUUID(1),
+			userID:  goframework.NumberUUID(11),
+			choices: []int{2, 1},
+			now:     updateTime,
+			expect:  true,
+			expectBallots: []*dao.PollBallotModel{
+				{
+					PollID:    goframework.NumberUUID(1),
+					UserID:    goframework.NumberUUID(10),
+					CreatedAt: baseTime,
+					Choices:   []int{0},
+				},
+				{
+					PollID:    goframework.NumberUUID(1),
+					UserID:    goframework.NumberUUID(11),
+					CreatedAt: updateTime,
+					Choices:   []int{2, 1},
+				},
+			},
+		},
+		{
+			name:    "Success/AlreadyCast",
+			pollID:  goframework.NumberUUID(1),
+			userID:  goframework.NumberUUID(10),
+			choices: []int{1},
+			now:     updateTime,
+			expect:  false,
+			expectBallots: []*dao.PollBallotModel{
+				{
+					PollID:    goframework.NumberUUID(1),
+					UserID:    goframework.NumberUUID(10),
+					CreatedAt: baseTime,
+					Choices:   []int{0},
+				},
+			},
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(st *testing.T) {
+			err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+				_, err := tx.NewInsert().Model(&ballotsFixtures).Exec(ctx)
+				require.NoError(t, err)
+
+				repository := dao.NewPollsRepository(tx)
+
+				res, err := repository.CastBallot(ctx, d.pollID, d.userID, d.choices, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+
+				ballots := make([]*dao.PollBallotModel, 0)
+				require.NoError(t, tx.NewSelect().Model(&ballots).Order("user_id").Scan(ctx))
+				require.Equal(t, d.expectBallots, ballots)
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPollsRepository_Count(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.PollModel{
+		{
+			Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			CreatedBy: goframework.NumberUUID(100),
+			PollModelCore: dao.PollModelCore{
+				Question:    "Which feature next?",
+				Options:     []string{"Dark mode", "Bookmarks", "Offline reading"},
+				MultiSelect: true,
+				OpensAt:     baseTime,
+			},
+		},
+		{
+			Metadata:  bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+			CreatedBy: goframework.NumberUUID(100),
+			PollModelCore: dao.PollModelCore{
+				Question: "Which color?",
+				Options:  []string{"Red", "Green"},
+				OpensAt:  baseTime,
+			},
+		},
+		{
+			Metadata:  bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, nil),
+			CreatedBy: goframework.NumberUUID(100),
+			PollModelCore: dao.PollModelCore{
+				Question: "Which season?",
+				Options:  []string{"Summer", "Winter"},
+				OpensAt:  baseTime,
+			},
+		},
+	}
+
+	ballotsFixtures := []*dao.PollBallotModel{
+		{PollID: goframework.NumberUUID(1), UserID: goframework.NumberUUID(10), CreatedAt: baseTime, Choices: []int{0, 2}},
+		{PollID: goframework.NumberUUID(1), UserID: goframework.NumberUUID(11), CreatedAt: baseTime, Choices: []int{2}},
+		{PollID: goframework.NumberUUID(1), UserID: goframework.NumberUUID(12), CreatedAt: baseTime, Choices: []int{0}},
+		{PollID: goframework.NumberUUID(2), UserID: goframework.NumberUUID(10), CreatedAt: baseTime, Choices: []int{1}},
+	}
+
+	data := []struct {
+		name string
+
+		pollID uuid.UUID
+
+		expectBallots int
+		expectChoices []*dao.PollChoiceCountModel
+	}{
+		{
+			name:          "Success",
+			pollID:        goframework.NumberUUID(1),
+			expectBallots: 3,
+			expectChoices: []*dao.PollChoiceCountModel{
+				{Choice: 0, Count: 2},
+				{Choice: 2, Count: 2},
+			},
+		},
+		{
+			name:          "Success/SingleSelect",
+			pollID:        goframework.NumberUUID(2),
+			expectBallots: 1,
+			expectChoices: []*dao.PollChoiceCountModel{
+				{Choice: 1, Count: 1},
+			},
+		},
+		{
+			name:          "Success/NoBallots",
+			pollID:        goframework.NumberUUID(3),
+			expectChoices: []*dao.PollChoiceCountModel{},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		_, err := tx.NewInsert().Model(&ballotsFixtures).Exec(ctx)
+		require.NoError(t, err)
+
+		repository := dao.NewPollsRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				ballots, err := repository.CountBallots(ctx, d.pollID)
+				require.NoError(t, err)
+				require.Equal(t, d.expectBallots, ballots)
+
+				choices, err := repository.CountChoices(ctx, d.pollID)
+				require.NoError(t, err)
+				require.Equal(t, d.expectChoices, choices)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestPollsRepository_CastRanking(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.PollModel{
+		{
+			Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			CreatedBy: goframework.NumberUUID(100),
+			PollModelCore: dao.PollModelCore{
+				Question: "Which story should be featured?",
+				Options:  []string{"Story A", "Story B", "Story C"},
+				OpensAt:  baseTime,
+				Ranked:   true,
+			},
+		},
+	}
+
+	rankingsFixtures := []*dao.PollRankingModel{
+		{
+			PollID:    goframework.NumberUUID(1),
+			UserID:    goframework.NumberUUID(10),
+			CreatedAt: baseTime,
+			Ranking:   []int{0, 2},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		pollID  uuid.UUID
+		userID  uuid.UUID
+		ranking []int
+		now     time.Time
+
+		expect         bool
+		expectRankings []*dao.PollRankingModel
+		expectErr      error
+	}{
+		{
+			name:    "Success",
+			pollID:  goframework.NumberUUID(1),
+			userID:  goframework.NumberUUID(11),
+			ranking: []int{2, 1, 0},
+			now:     updateTime,
+			expect:  true,
+			expectRankings: []*dao.PollRankingModel{
+				{
+					PollID:    goframework.NumberUUID(1),
+					UserID:    goframework.NumberUUID(10),
+					CreatedAt: baseTime,
+					Ranking:   []int{0, 2},
+				},
+				{
+					PollID:    goframework.NumberUUID(1),
+					UserID:    goframework.NumberUUID(11),
+					CreatedAt: updateTime,
+					Ranking:   []int{2, 1, 0},
+				},
+			},
+		},
+		{
+			name:    "Success/AlreadyCast",
+			pollID:  goframework.NumberUUID(1),
+			userID:  goframework.NumberUUID(10),
+			ranking: []int{1},
+			now:     updateTime,
+			expect:  false,
+			expectRankings: []*dao.PollRankingModel{
+				{
+					PollID:    goframework.NumberUUID(1),
+					UserID:    goframework.NumberUUID(10),
+					CreatedAt: baseTime,
+					Ranking:   []int{0, 2},
+				},
+			},
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(st *testing.T) {
+			err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+				_, err := tx.NewInsert().Model(&rankingsFixtures).Exec(ctx)
+				require.NoError(t, err)
+
+				repository := dao.NewPollsRepository(tx)
+
+				res, err := repository.CastRanking(ctx, d.pollID, d.userID, d.ranking, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+
+				rankings, err := repository.ListRankings(ctx, d.pollID)
+				require.NoError(t, err)
+				require.Equal(t, d.expectRankings, rankings)
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPollsRepository_ListRankings(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.PollModel{
+		{
+			Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			CreatedBy: goframework.NumberUUID(100),
+			PollModelCore: dao.PollModelCore{
+				Question: "Which story should be featured?",
+				Options:  []string{"Story A", "Story B", "Story C"},
+				OpensAt:  baseTime,
+				Ranked:   true,
+			},
+		},
+		{
+			Metadata:  bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+			CreatedBy: goframework.NumberUUID(100),
+			PollModelCore: dao.PollModelCore{
+				Question: "Which cover should be featured?",
+				Options:  []string{"Cover A", "Cover B"},
+				OpensAt:  baseTime,
+				Ranked:   true,
+			},
+		},
+	}
+
+	rankingsFixtures := []*dao.PollRankingModel{
+		{PollID: goframework.NumberUUID(1), UserID: goframework.NumberUUID(10), CreatedAt: updateTime, Ranking: []int{1}},
+		{PollID: goframework.NumberUUID(1), UserID: goframework.NumberUUID(11), CreatedAt: baseTime, Ranking: []int{2, 0}},
+		{PollID: goframework.NumberUUID(2), UserID: goframework.NumberUUID(10), CreatedAt: baseTime, Ranking: []int{0, 1}},
+	}
+
+	data := []struct {
+		name string
+
+		pollID uuid.UUID
+
+		expect []*dao.PollRankingModel
+	}{
+		{
+			name:   "Success",
+			pollID: goframework.NumberUUID(1),
+			expect: []*dao.PollRankingModel{
+				{PollID: goframework.NumberUUID(1), UserID: goframework.NumberUUID(11), CreatedAt: baseTime, Ranking: []int{2, 0}},
+				{PollID: goframework.NumberUUID(1), UserID: goframework.NumberUUID(10), CreatedAt: updateTime, Ranking: []int{1}},
+			},
+		},
+		{
+			name:   "Success/NoRankings",
+			pollID: goframework.NumberUUID(3),
+			expect: []*dao.PollRankingModel{},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		_, err := tx.NewInsert().Model(&rankingsFixtures).Exec(ctx)
+		require.NoError(t, err)
+
+		repository := dao.NewPollsRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.ListRankings(ctx, d.pollID)
+				require.NoError(t, err)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
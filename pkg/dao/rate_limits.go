@@ -0,0 +1,91 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/uptrace/bun"
+	"sort"
+	"time"
+)
+
+type RateLimitsRepository interface {
+	// Update locks the buckets, and passes them to update in the same order. A bucket that does not exist yet is
+	// created as given first, so concurrent updates of a new bucket wait for each other. The changes are saved if
+	// update returns true.
+	Update(ctx context.Context, buckets []*RateLimitBucketModel, update func(buckets []*RateLimitBucketModel) bool) error
+	// Purge deletes the buckets that are full at the given date, and returns how many were deleted.
+	Purge(ctx context.Context, now time.Time) (int, error)
+}
+
+type RateLimitBucketModel struct {
+	bun.BaseModel `bun:"table:rate_limit_buckets"`
+
+	Key       string    `bun:"key,pk"`
+	Tokens    float64   `bun:"tokens"`
+	UpdatedAt time.Time `bun:"updated_at"`
+	// FullAt is the date at which the bucket has refilled completely, and can be forgotten.
+	FullAt time.Time `bun:"full_at"`
+}
+
+func NewRateLimitsRepository(db bun.IDB) RateLimitsRepository {
+	return &rateLimitsRepositoryImpl{db: db}
+}
+
+type rateLimitsRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *rateLimitsRepositoryImpl) Update(ctx context.Context, buckets []*RateLimitBucketModel, update func(buckets []*RateLimitBucketModel) bool) error {
+	return repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Lock the buckets in the order of their keys, so concurrent updates of the same buckets cannot deadlock.
+		sorted := append([]*RateLimitBucketModel{}, buckets...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+
+		for _, bucket := range sorted {
+			// A missing bucket has no row to lock. Creating it first makes concurrent requests wait for the insert,
+			// then lock the same row, rather than all starting from a new bucket.
+			_, err := tx.NewInsert().Model(bucket).On("CONFLICT (key) DO NOTHING").Exec(ctx)
+			if err != nil {
+				return bunovel.HandlePGError(err)
+			}
+
+			if err := tx.NewSelect().Model(bucket).WherePK().For("UPDATE").Scan(ctx); err != nil {
+				return bunovel.HandlePGError(err)
+			}
+		}
+
+		if !update(buckets) {
+			return nil
+		}
+
+		_, err := tx.NewInsert().Model(&sorted).
+			On("CONFLICT (key) DO UPDATE").
+			Set("tokens = EXCLUDED.tokens").
+			Set("updated_at = EXCLUDED.updated_at").
+			Set("full_at = EXCLUDED.full_at").
+			Exec(ctx)
+
+		if err != nil {
+			return bunovel.HandlePGError(err)
+		}
+
+		return nil
+	})
+}
+
+func (repository *rateLimitsRepositoryImpl) Purge(ctx context.Context, now time.Time) (int, error) {
+	res, err := repository.db.NewDelete().Model((*RateLimitBucketModel)(nil)).
+		Where("full_at <= ?", now).
+		Exec(ctx)
+
+	if err != nil {
+		return 0, bunovel.HandlePGError(err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
@@ -0,0 +1,237 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/votes-service/migrations"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimitsRepository_Update(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.RateLimitBucketModel{
+		{
+			Key:       "key",
+			Tokens:    1.5,
+			UpdatedAt: baseTime,
+			FullAt:    baseTime.Add(time.Minute),
+		},
+	}
+
+	data := []struct {
+		name string
+
+		keys []string
+		save bool
+
+		expectReceived []*dao.RateLimitBucketModel
+		expect         []*dao.RateLimitBucketModel
+		expectErr      error
+	}{
+		{
+			name: "Success",
+			keys: []string{"key"},
+			save: true,
+			expectReceived: []*dao.RateLimitBucketModel{
+				{
+					Key:       "key",
+					Tokens:    1.5,
+					UpdatedAt: baseTime,
+					FullAt:    baseTime.Add(time.Minute),
+				},
+			},
+			expect: []*dao.RateLimitBucketModel{
+				{
+					Key:       "key",
+					Tokens:    0.5,
+					UpdatedAt: updateTime,
+					FullAt:    updateTime.Add(time.Minute),
+				},
+			},
+		},
+		{
+			name: "Success/NewBucket",
+			keys: []string{"new-key"},
+			save: true,
+			expectReceived: []*dao.RateLimitBucketModel{
+				{Key: "new-key", Tokens: 2, UpdatedAt: updateTime, FullAt: updateTime},
+			},
+			expect: []*dao.RateLimitBucketModel{
+				{
+					Key:       "new-key",
+					Tokens:    1,
+					UpdatedAt: updateTime,
+					FullAt:    updateTime.Add(time.Minute),
+				},
+			},
+		},
+		// The buckets are passed in the order of the keys, whatever the order they are locked in.
+		{
+			name: "Success/SeveralBuckets",
+			keys: []string{"new-key", "key"},
+			save: true,
+			expectReceived: []*dao.RateLimitBucketModel{
+				{Key: "new-key", Tokens: 2, UpdatedAt: updateTime, FullAt: updateTime},
+				{
+					Key:       "key",
+					Tokens:    1.5,
+					UpdatedAt: baseTime,
+					FullAt:    baseTime.Add(time.Minute),
+				},
+			},
+			expect: []*dao.RateLimitBucketModel{
+				{
+					Key:       "new-key",
+					Tokens:    1,
+					UpdatedAt: updateTime,
+					FullAt:    updateTime.Add(time.Minute),
+				},
+				{
+					Key:       "key",
+					Tokens:    0.5,
+					UpdatedAt: updateTime,
+					FullAt:    updateTime.Add(time.Minute),
+				},
+			},
+		},
+		{
+			name: "Success/NotSaved",
+			keys: []string{"key"},
+			expectReceived: []*dao.RateLimitBucketModel{
+				{
+					Key:       "key",
+					Tokens:    1.5,
+					UpdatedAt: baseTime,
+					FullAt:    baseTime.Add(time.Minute),
+				},
+			},
+			expect: []*dao.RateLimitBucketModel{
+				{
+					Key:       "key",
+					Tokens:    1.5,
+					UpdatedAt: baseTime,
+					FullAt:    baseTime.Add(time.Minute),
+				},
+			},
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(st *testing.T) {
+			err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+				repository := dao.NewRateLimitsRepository(tx)
+
+				// New buckets start full.
+				buckets := lo.Map(d.keys, func(key string, _ int) *dao.RateLimitBucketModel {
+					return &dao.RateLimitBucketModel{Key: key, Tokens: 2, UpdatedAt: updateTime, FullAt: updateTime}
+				})
+
+				err := repository.Update(ctx, buckets, func(buckets []*dao.RateLimitBucketModel) bool {
+					require.Equal(t, d.expectReceived, buckets)
+
+					for _, bucket := range buckets {
+						bucket.Tokens--
+						bucket.UpdatedAt = updateTime
+						bucket.FullAt = updateTime.Add(time.Minute)
+					}
+
+					return d.save
+				})
+				require.ErrorIs(t, err, d.expectErr)
+
+				for i, key := range d.keys {
+					bucket := &dao.RateLimitBucketModel{Key: key}
+					require.NoError(t, tx.NewSelect().Model(bucket).WherePK().Scan(ctx))
+					require.Equal(t, d.expect[i], bucket)
+				}
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestRateLimitsRepository_UpdateConcurrently sends a burst of requests against a new bucket. They all wait for the
+// first one to create the bucket, so no more requests get through than the bucket has tokens.
+func TestRateLimitsRepository_UpdateConcurrently(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	ctx := context.Background()
+	repository := dao.NewRateLimitsRepository(db)
+
+	defer func() {
+		_, err := db.NewDelete().Model((*dao.RateLimitBucketModel)(nil)).Where("key = ?", "concurrent-key").Exec(ctx)
+		require.NoError(t, err)
+	}()
+
+	allowed := make([]bool, 5)
+	errs := make([]error, len(allowed))
+	var wg sync.WaitGroup
+	for i := range allowed {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			bucket := &dao.RateLimitBucketModel{Key: "concurrent-key", Tokens: 2, UpdatedAt: baseTime, FullAt: baseTime}
+			errs[i] = repository.Update(ctx, []*dao.RateLimitBucketModel{bucket}, func(buckets []*dao.RateLimitBucketModel) bool {
+				if buckets[0].Tokens < 1 {
+					return false
+				}
+
+				buckets[0].Tokens--
+				allowed[i] = true
+				return true
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, lo.Count(allowed, true))
+}
+
+func TestRateLimitsRepository_Purge(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.RateLimitBucketModel{
+		{
+			Key:       "full",
+			Tokens:    1,
+			UpdatedAt: baseTime,
+			FullAt:    baseTime.Add(time.Minute),
+		},
+		{
+			Key:       "refilling",
+			Tokens:    0,
+			UpdatedAt: baseTime,
+			FullAt:    baseTime.Add(2 * time.Hour),
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewRateLimitsRepository(tx)
+
+		deleted, err := repository.Purge(ctx, updateTime)
+		require.NoError(t, err)
+		require.Equal(t, 1, deleted)
+
+		count, err := tx.NewSelect().Model((*dao.RateLimitBucketModel)(nil)).Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+	require.NoError(t, err)
+}
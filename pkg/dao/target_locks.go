@@ -0,0 +1,121 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+type TargetLocksRepository interface {
+	// Get returns the lock of a target, whether it is already effective or scheduled.
+	Get(ctx context.Context, targetID uuid.UUID, target string) (*TargetLockModel, error)
+	// Lock closes a target to votes from closesAt on. It replaces any existing lock of the target. Like Unlock, it
+	// waits for the casts that already checked the lock of the target.
+	Lock(ctx context.Context, targetID uuid.UUID, target string, lockedBy uuid.UUID, closesAt, now time.Time) (*TargetLockModel, error)
+	// Unlock removes the lock of a target, or cancels its scheduled close.
+	Unlock(ctx context.Context, targetID uuid.UUID, target string) error
+}
+
+type TargetLockModel struct {
+	bun.BaseModel `bun:"table:target_locks"`
+
+	TargetID  uuid.UUID `bun:"target_id,pk"`
+	Target    string    `bun:"target,pk"`
+	CreatedAt time.Time `bun:"created_at"`
+	LockedBy  uuid.UUID `bun:"locked_by"`
+	ClosesAt  time.Time `bun:"closes_at"`
+}
+
+func NewTargetLocksRepository(db bun.IDB) TargetLocksRepository {
+	return &targetLocksRepositoryImpl{db: db}
+}
+
+type targetLocksRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *targetLocksRepositoryImpl) Get(ctx context.Context, targetID uuid.UUID, target string) (*TargetLockModel, error) {
+	model := &TargetLockModel{TargetID: targetID, Target: target}
+
+	if err := repository.db.NewSelect().Model(model).WherePK().Scan(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *targetLocksRepositoryImpl) Lock(ctx context.Context, targetID uuid.UUID, target string, lockedBy uuid.UUID, closesAt, now time.Time) (*TargetLockModel, error) {
+	model := &TargetLockModel{
+		TargetID:  targetID,
+		Target:    target,
+		CreatedAt: now,
+		LockedBy:  lockedBy,
+		ClosesAt:  closesAt,
+	}
+
+	err := repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockTarget(ctx, tx, targetID, target); err != nil {
+			return err
+		}
+
+		_, err := tx.NewInsert().Model(model).
+			On("CONFLICT (target_id, target) DO UPDATE").
+			Set("created_at = EXCLUDED.created_at").
+			Set("locked_by = EXCLUDED.locked_by").
+			Set("closes_at = EXCLUDED.closes_at").
+			Exec(ctx)
+		if err != nil {
+			return bunovel.HandlePGError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (repository *targetLocksRepositoryImpl) Unlock(ctx context.Context, targetID uuid.UUID, target string) error {
+	return repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockTarget(ctx, tx, targetID, target); err != nil {
+			return err
+		}
+
+		res, err := tx.NewDelete().Model(&TargetLockModel{TargetID: targetID, Target: target}).
+			WherePK().
+			Exec(ctx)
+
+		if err != nil {
+			return bunovel.HandlePGError(err)
+		}
+
+		deleted, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			return bunovel.ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+// targetLockKey identifies the lock of a target, for advisory locks.
+func targetLockKey(targetID uuid.UUID, target string) string {
+	return "lock/" + target + "/" + targetID.String()
+}
+
+// lockTarget waits for the casts that checked the lock of a target, and keeps new ones from checking it until the end
+// of the transaction.
+func lockTarget(ctx context.Context, tx bun.Tx, targetID uuid.UUID, target string) error {
+	if _, err := tx.NewRaw("SELECT pg_advisory_xact_lock(hashtext(?))", targetLockKey(targetID, target)).Exec(ctx); err != nil {
+		return bunovel.HandlePGError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/migrations"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+func TestTargetLocksRepository_Get(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.TargetLockModel{
+		{
+			TargetID:  goframework.NumberUUID(1),
+			Target:    "target",
+			CreatedAt: baseTime,
+			LockedBy:  goframework.NumberUUID(100),
+			ClosesAt:  updateTime,
+		},
+	}
+
+	data := []struct {
+		name string
+
+		targetID uuid.UUID
+		target   string
+
+		expect    *dao.TargetLockModel
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			expect: &dao.TargetLockModel{
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				CreatedAt: baseTime,
+				LockedBy:  goframework.NumberUUID(100),
+				ClosesAt:  updateTime,
+			},
+		},
+		{
+			name:      "Error/NotFound",
+			targetID:  goframework.NumberUUID(1),
+			target:    "other-target",
+			expectErr: bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewTargetLocksRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.Get(ctx, d.targetID, d.target)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestTargetLocksRepository_Lock(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.TargetLockModel{
+		{
+			TargetID:  goframework.NumberUUID(1),
+			Target:    "target",
+			CreatedAt: baseTime,
+			LockedBy:  goframework.NumberUUID(100),
+			ClosesAt:  baseTime.Add(24 * time.Hour),
+		},
+	}
+
+	data := []struct {
+		name string
+
+		targetID uuid.UUID
+		target   string
+		closesAt time.Time
+
+		expect    *dao.TargetLockModel
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			targetID: goframework.NumberUUID(2),
+			target:   "target",
+			closesAt: updateTime,
+			expect: &dao.TargetLockModel{
+				TargetID:  goframework.NumberUUID(2),
+				Target:    "target",
+				CreatedAt: updateTime,
+				LockedBy:  goframework.NumberUUID(101),
+				ClosesAt:  updateTime,
+			},
+		},
+		{
+			name:     "Success/Replace",
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			closesAt: updateTime,
+			expect: &dao.TargetLockModel{
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				CreatedAt: updateTime,
+				LockedBy:  goframework.NumberUUID(101),
+				ClosesAt:  updateTime,
+			},
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(st *testing.T) {
+			err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+				repository := dao.NewTargetLocksRepository(tx)
+
+				res, err := repository.Lock(ctx, d.targetID, d.target, goframework.NumberUUID(101), d.closesAt, updateTime)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+
+				stored, err := repository.Get(ctx, d.targetID, d.target)
+				require.NoError(t, err)
+				require.Equal(t, d.expect, stored)
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTargetLocksRepository_Unlock(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.TargetLockModel{
+		{
+			TargetID:  goframework.NumberUUID(1),
+			Target:    "target",
+			CreatedAt: baseTime,
+			LockedBy:  goframework.NumberUUID(100),
+			ClosesAt:  baseTime,
+		},
+	}
+
+	data := []struct {
+		name string
+
+		targetID uuid.UUID
+		target   string
+
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+		},
+		{
+			name:      "Error/NotFound",
+			targetID:  goframework.NumberUUID(2),
+			target:    "target",
+			expectErr: bunovel.ErrNotFound,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(st *testing.T) {
+			err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+				repository := dao.NewTargetLocksRepository(tx)
+
+				err := repository.Unlock(ctx, d.targetID, d.target)
+				require.ErrorIs(t, err, d.expectErr)
+
+				_, err = repository.Get(ctx, d.targetID, d.target)
+				require.ErrorIs(t, err, bunovel.ErrNotFound)
+			})
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package dao
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"time"
+)
+
+type VoteEventsRepository interface {
+	List(ctx context.Context, filter VoteEventsFilter, limit, offset int) ([]*VoteEventModel, error)
+}
+
+// VoteEventModel is written by VotesRepository.Cast, in the same transaction as the vote.
+type VoteEventModel struct {
+	bun.BaseModel `bun:"table:vote_events"`
+
+	ID        int64     `bun:"id,pk,autoincrement"`
+	CreatedAt time.Time `bun:"created_at"`
+
+	Event    models.VoteEventType `bun:"event,type:vote_event"`
+	UserID   uuid.UUID            `bun:"user_id"`
+	TargetID uuid.UUID            `bun:"target_id"`
+	Target   string               `bun:"target"`
+	OldVote  *models.VoteValue    `bun:"old_vote"`
+	NewVote  *models.VoteValue    `bun:"new_vote"`
+	OldScore *int                 `bun:"old_score"`
+	NewScore *int                 `bun:"new_score"`
+}
+
+// VoteEventsFilter restricts the history to a user, a target, or both. Empty fields are ignored.
+type VoteEventsFilter struct {
+	UserID   *uuid.UUID
+	TargetID *uuid.UUID
+	Target   string
+}
+
+func NewVoteEventsRepository(db bun.IDB) VoteEventsRepository {
+	return &voteEventsRepositoryImpl{db: db}
+}
+
+type voteEventsRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *voteEventsRepositoryImpl) List(ctx context.Context, filter VoteEventsFilter, limit, offset int) ([]*VoteEventModel, error) {
+	events := make([]*VoteEventModel, 0)
+
+	query := repository.db.NewSelect().Model(&events)
+
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.TargetID != nil {
+		query = query.Where("target_id = ?", *filter.TargetID)
+	}
+	if filter.Target != "" {
+		query = query.Where("target = ?", filter.Target)
+	}
+
+	err := query.
+		Order("created_at DESC", "id DESC").
+		Limit(limit).Offset(offset).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,125 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/migrations"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+func TestVoteEventsRepository_List(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VoteEventModel{
+		{
+			ID:        1,
+			CreatedAt: baseTime,
+			Event:     models.VoteEventTypeCast,
+			UserID:    goframework.NumberUUID(1),
+			TargetID:  goframework.NumberUUID(1),
+			Target:    "target",
+			NewVote:   lo.ToPtr(models.VoteValueUp),
+		},
+		{
+			ID:        2,
+			CreatedAt: baseTime.Add(time.Minute),
+			Event:     models.VoteEventTypeFlip,
+			UserID:    goframework.NumberUUID(1),
+			TargetID:  goframework.NumberUUID(1),
+			Target:    "target",
+			OldVote:   lo.ToPtr(models.VoteValueUp),
+			NewVote:   lo.ToPtr(models.VoteValueDown),
+		},
+		{
+			ID:        3,
+			CreatedAt: baseTime.Add(2 * time.Minute),
+			Event:     models.VoteEventTypeCast,
+			UserID:    goframework.NumberUUID(2),
+			TargetID:  goframework.NumberUUID(1),
+			Target:    "target",
+			NewVote:   lo.ToPtr(models.VoteValueUp),
+		},
+		// Another target.
+		{
+			ID:        4,
+			CreatedAt: updateTime,
+			Event:     models.VoteEventTypeRetract,
+			UserID:    goframework.NumberUUID(1),
+			TargetID:  goframework.NumberUUID(1),
+			Target:    "other-target",
+			OldVote:   lo.ToPtr(models.VoteValueUp),
+		},
+	}
+
+	data := []struct {
+		name string
+
+		filter dao.VoteEventsFilter
+		limit  int
+		offset int
+
+		expect    []int64
+		expectErr error
+	}{
+		{
+			name:   "Success/User",
+			filter: dao.VoteEventsFilter{UserID: lo.ToPtr(goframework.NumberUUID(1))},
+			limit:  10,
+			expect: []int64{4, 2, 1},
+		},
+		{
+			name:   "Success/Target",
+			filter: dao.VoteEventsFilter{TargetID: lo.ToPtr(goframework.NumberUUID(1)), Target: "target"},
+			limit:  10,
+			expect: []int64{3, 2, 1},
+		},
+		{
+			name: "Success/UserAndTarget",
+			filter: dao.VoteEventsFilter{
+				UserID:   lo.ToPtr(goframework.NumberUUID(1)),
+				TargetID: lo.ToPtr(goframework.NumberUUID(1)),
+				Target:   "target",
+			},
+			limit:  10,
+			expect: []int64{2, 1},
+		},
+		{
+			name:   "Success/Pagination",
+			filter: dao.VoteEventsFilter{UserID: lo.ToPtr(goframework.NumberUUID(1))},
+			limit:  1,
+			offset: 1,
+			expect: []int64{2},
+		},
+		{
+			name:   "Success/NoResults",
+			filter: dao.VoteEventsFilter{UserID: lo.ToPtr(goframework.NumberUUID(10))},
+			limit:  10,
+			expect: []int64{},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVoteEventsRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.List(ctx, d.filter, d.limit, d.offset)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, lo.Map(res, func(item *dao.VoteEventModel, _ int) int64 {
+					return item.ID
+				}))
+			})
+		}
+	})
+	require.NoError(t, err)
+}
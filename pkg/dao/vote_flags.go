@@ -0,0 +1,313 @@
+package dao
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+	"sort"
+	"time"
+)
+
+// ErrVoteFlagPending is returned when a flag is reopened while the detector already opened a newer flag for the same
+// finding.
+var ErrVoteFlagPending = goerrors.New("a pending flag already covers this finding")
+
+type VoteFlagsRepository interface {
+	// ListCoVotingPairs returns the pairs of users who cast the same vote on at least minTargets common targets since
+	// the given date, each time less than window apart.
+	ListCoVotingPairs(ctx context.Context, since time.Time, window time.Duration, minTargets int) ([]*CoVotingPairModel, error)
+	// ListNewVoterSwarms returns the targets that received the same vote, since the given date, from at least
+	// minVoters users whose first vote is more recent than firstVoteSince.
+	ListNewVoterSwarms(ctx context.Context, since, firstVoteSince time.Time, minVoters int) ([]*NewVoterSwarmModel, error)
+	// ListFlipBursts returns the users who changed or retracted at least minChanges votes on a target since the given
+	// date.
+	ListFlipBursts(ctx context.Context, since time.Time, minChanges int) ([]*FlipBurstModel, error)
+
+	// Save inserts new flags. A flag with the fingerprint of a pending flag is merged into it, and a flag that a reviewed
+	// flag already covers, with no new user or target, is ignored. It returns the number of flags inserted.
+	Save(ctx context.Context, flags []*VoteFlagModel) (int, error)
+	List(ctx context.Context, filter VoteFlagsFilter, limit, offset int) ([]*VoteFlagModel, error)
+	// Review sets the status of a flag. Setting it back to pending reopens the flag, and clears its review. A flag cannot
+	// be reopened while another pending flag has its fingerprint: it fails with ErrVoteFlagPending.
+	Review(ctx context.Context, id uuid.UUID, status models.VoteFlagStatus, reviewerID uuid.UUID, now time.Time) (*VoteFlagModel, error)
+}
+
+type VoteFlagModel struct {
+	bun.BaseModel `bun:"table:vote_flags"`
+
+	// ID is generated by the database when left empty.
+	ID        uuid.UUID `bun:"id,pk,nullzero"`
+	CreatedAt time.Time `bun:"created_at"`
+
+	Kind        models.VoteFlagKind `bun:"kind,type:vote_flag_kind"`
+	Target      string              `bun:"target"`
+	TargetIDs   []uuid.UUID         `bun:"target_ids,array"`
+	UserIDs     []uuid.UUID         `bun:"user_ids,array"`
+	Occurrences int                 `bun:"occurrences"`
+	Fingerprint string              `bun:"fingerprint"`
+
+	Status     models.VoteFlagStatus `bun:"status,type:vote_flag_status,nullzero,default:'pending'"`
+	ReviewedBy *uuid.UUID            `bun:"reviewed_by"`
+	ReviewedAt *time.Time            `bun:"reviewed_at"`
+}
+
+type CoVotingPairModel struct {
+	Target    string      `bun:"target"`
+	UserA     uuid.UUID   `bun:"user_a"`
+	UserB     uuid.UUID   `bun:"user_b"`
+	TargetIDs []uuid.UUID `bun:"target_ids,array"`
+}
+
+type NewVoterSwarmModel struct {
+	Target   string           `bun:"target"`
+	TargetID uuid.UUID        `bun:"target_id"`
+	Vote     models.VoteValue `bun:"vote"`
+	UserIDs  []uuid.UUID      `bun:"user_ids,array"`
+}
+
+type FlipBurstModel struct {
+	UserID    uuid.UUID   `bun:"user_id"`
+	Target    string      `bun:"target"`
+	TargetIDs []uuid.UUID `bun:"target_ids,array"`
+	Changes   int         `bun:"changes"`
+}
+
+// VoteFlagsFilter restricts the flags. Empty fields are ignored.
+type VoteFlagsFilter struct {
+	Kind   models.VoteFlagKind
+	Status models.VoteFlagStatus
+}
+
+func NewVoteFlagsRepository(db bun.IDB) VoteFlagsRepository {
+	return &voteFlagsRepositoryImpl{db: db}
+}
+
+type voteFlagsRepositoryImpl struct {
+	db bun.IDB
+}
+
+func (repository *voteFlagsRepositoryImpl) ListCoVotingPairs(ctx context.Context, since time.Time, window time.Duration, minTargets int) ([]*CoVotingPairModel, error) {
+	pairs := make([]*CoVotingPairModel, 0)
+
+	// Votes are compared on their last activity date, since a flip is as much of a vote as the initial cast. Each
+	// pair is only returned once, with the lowest ID first.
+	err := repository.db.NewRaw(`
+SELECT a.target, a.user_id AS user_a, b.user_id AS user_b, array_agg(DISTINCT a.target_id) AS target_ids
+FROM votes AS a
+JOIN votes AS b
+    ON b.target = a.target
+    AND b.target_id = a.target_id
+    AND b.vote = a.vote
+    AND b.user_id > a.user_id
+    AND ABS(EXTRACT(EPOCH FROM COALESCE(b.updated_at, b.created_at) - COALESCE(a.updated_at, a.created_at))) <= ?
+WHERE COALESCE(a.updated_at, a.created_at) >= ?
+    AND COALESCE(b.updated_at, b.created_at) >= ?
+    AND a.invalidated_at IS NULL
+    AND b.invalidated_at IS NULL
+GROUP BY a.target, a.user_id, b.user_id
+HAVING COUNT(DISTINCT a.target_id) >= ?
+ORDER BY a.target, a.user_id, b.user_id
+`, window.Seconds(), since, since, minTargets).Scan(ctx, &pairs)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return pairs, nil
+}
+
+func (repository *voteFlagsRepositoryImpl) ListNewVoterSwarms(ctx context.Context, since, firstVoteSince time.Time, minVoters int) ([]*NewVoterSwarmModel, error) {
+	swarms := make([]*NewVoterSwarmModel, 0)
+
+	// This service does not know when accounts were created: the first vote of a user is the closest approximation.
+	// Invalidated votes still date the first activity of the user, but no longer count in the swarms.
+	err := repository.db.NewRaw(`
+WITH first_votes AS (
+    SELECT user_id, MIN(created_at) AS first_vote_at FROM votes GROUP BY user_id
+)
+SELECT v.target, v.target_id, v.vote, array_agg(v.user_id ORDER BY v.user_id) AS user_ids
+FROM votes AS v
+JOIN first_votes AS f ON f.user_id = v.user_id
+WHERE COALESCE(v.updated_at, v.created_at) >= ?
+    AND f.first_vote_at >= ?
+    AND v.invalidated_at IS NULL
+GROUP BY v.target, v.target_id, v.vote
+HAVING COUNT(*) >= ?
+ORDER BY v.target, v.target_id, v.vote
+`, since, firstVoteSince, minVoters).Scan(ctx, &swarms)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return swarms, nil
+}
+
+func (repository *voteFlagsRepositoryImpl) ListFlipBursts(ctx context.Context, since time.Time, minChanges int) ([]*FlipBurstModel, error) {
+	bursts := make([]*FlipBurstModel, 0)
+
+	err := repository.db.NewRaw(`
+SELECT user_id, target, array_agg(DISTINCT target_id) AS target_ids, COUNT(*) AS changes
+FROM vote_events
+WHERE event IN ('flip', 'retract')
+    AND created_at >= ?
+GROUP BY user_id, target
+HAVING COUNT(*) >= ?
+ORDER BY target, user_id
+`, since, minChanges).Scan(ctx, &bursts)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return bursts, nil
+}
+
+func (repository *voteFlagsRepositoryImpl) Save(ctx context.Context, flags []*VoteFlagModel) (int, error) {
+	if len(flags) == 0 {
+		return 0, nil
+	}
+
+	inserted := 0
+
+	// The fingerprints are locked in the same order by every run, so concurrent runs cannot deadlock.
+	flags = append([]*VoteFlagModel(nil), flags...)
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Fingerprint < flags[j].Fingerprint })
+
+	err := repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for _, flag := range flags {
+			if err := lockFingerprint(ctx, tx, flag.Fingerprint); err != nil {
+				return err
+			}
+
+			existing := make([]*VoteFlagModel, 0)
+			if err := tx.NewSelect().Model(&existing).Where("fingerprint = ?", flag.Fingerprint).Scan(ctx); err != nil {
+				return bunovel.HandlePGError(err)
+			}
+
+			pending := lo.ContainsBy(existing, func(item *VoteFlagModel) bool {
+				return item.Status == models.VoteFlagStatusPending
+			})
+			reviewed := lo.ContainsBy(existing, func(item *VoteFlagModel) bool {
+				return item.Status != models.VoteFlagStatusPending &&
+					lo.Every(item.TargetIDs, flag.TargetIDs) && lo.Every(item.UserIDs, flag.UserIDs)
+			})
+
+			// Moderators already reviewed this finding, and nothing changed since.
+			if !pending && reviewed {
+				continue
+			}
+
+			// A pending flag keeps the evidence of the previous runs, as older votes leave the lookback period.
+			_, err := tx.NewInsert().Model(flag).
+				On("CONFLICT (fingerprint) WHERE status = 'pending' DO UPDATE").
+				Set("target_ids = ARRAY(SELECT DISTINCT id FROM unnest(?TableAlias.target_ids || EXCLUDED.target_ids) AS id ORDER BY id)").
+				Set("user_ids = ARRAY(SELECT DISTINCT id FROM unnest(?TableAlias.user_ids || EXCLUDED.user_ids) AS id ORDER BY id)").
+				Set("occurrences = GREATEST(?TableAlias.occurrences, EXCLUDED.occurrences)").
+				Exec(ctx)
+			if err != nil {
+				return bunovel.HandlePGError(err)
+			}
+
+			if !pending {
+				inserted++
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return inserted, nil
+}
+
+func (repository *voteFlagsRepositoryImpl) List(ctx context.Context, filter VoteFlagsFilter, limit, offset int) ([]*VoteFlagModel, error) {
+	flags := make([]*VoteFlagModel, 0)
+
+	query := repository.db.NewSelect().Model(&flags)
+
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	err := query.
+		Order("created_at DESC", "id").
+		Limit(limit).Offset(offset).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return flags, nil
+}
+
+func (repository *voteFlagsRepositoryImpl) Review(ctx context.Context, id uuid.UUID, status models.VoteFlagStatus, reviewerID uuid.UUID, now time.Time) (*VoteFlagModel, error) {
+	model := &VoteFlagModel{ID: id}
+
+	err := repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := tx.NewSelect().Model(model).WherePK().Scan(ctx); err != nil {
+			return bunovel.HandlePGError(err)
+		}
+
+		// A pending flag is not reviewed yet.
+		reviewedBy, reviewedAt := &reviewerID, &now
+		if status == models.VoteFlagStatusPending {
+			reviewedBy, reviewedAt = nil, nil
+
+			// Only one flag per finding can be pending. Locking the fingerprint keeps the detector from opening a new
+			// one in the meantime.
+			if err := lockFingerprint(ctx, tx, model.Fingerprint); err != nil {
+				return err
+			}
+
+			exists, err := tx.NewSelect().Model((*VoteFlagModel)(nil)).
+				Where("fingerprint = ?", model.Fingerprint).
+				Where("status = ?", models.VoteFlagStatusPending).
+				Where("id != ?", id).
+				Exists(ctx)
+			if err != nil {
+				return bunovel.HandlePGError(err)
+			}
+			if exists {
+				return ErrVoteFlagPending
+			}
+		}
+
+		err := tx.NewUpdate().Model(model).
+			Set("status = ?", status).
+			Set("reviewed_by = ?", reviewedBy).
+			Set("reviewed_at = ?", reviewedAt).
+			WherePK().
+			Returning("*").
+			Scan(ctx)
+		if err != nil {
+			return bunovel.HandlePGError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// lockFingerprint serializes the writes on the flags of a finding, until the end of the transaction.
+func lockFingerprint(ctx context.Context, tx bun.Tx, fingerprint string) error {
+	if _, err := tx.NewRaw("SELECT pg_advisory_xact_lock(hashtext(?))", fingerprint).Exec(ctx); err != nil {
+		return bunovel.HandlePGError(err)
+	}
+
+	return nil
+}
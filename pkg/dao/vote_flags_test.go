@@ -0,0 +1,821 @@
+package dao_test
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/migrations"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+func TestVoteFlagsRepository_ListCoVotingPairs(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VoteModel{
+		// Users 1 and 2 voted together on 3 targets.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(10),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(11),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, nil),
+			Vote:     models.VoteValueDown,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(12),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(4), baseTime.Add(time.Minute), nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(2),
+			TargetID: goframework.NumberUUID(10),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(5), baseTime.Add(time.Minute), nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(2),
+			TargetID: goframework.NumberUUID(11),
+			Target:   "target",
+		},
+		// Flipped within the window.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(6), baseTime.Add(-time.Hour), lo.ToPtr(baseTime.Add(2*time.Minute))),
+			Vote:     models.VoteValueDown,
+			UserID:   goframework.NumberUUID(2),
+			TargetID: goframework.NumberUUID(12),
+			Target:   "target",
+		},
+		// User 3 voted with them, but not often enough.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(7), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(3),
+			TargetID: goframework.NumberUUID(10),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(8), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(3),
+			TargetID: goframework.NumberUUID(11),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(9), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(3),
+			TargetID: goframework.NumberUUID(12),
+			Target:   "target",
+		},
+		// User 5 voted with users 1 and 2, but a moderator discarded the votes.
+		{
+			Metadata:      bunovel.NewMetadata(goframework.NumberUUID(13), baseTime, nil),
+			Vote:          models.VoteValueUp,
+			UserID:        goframework.NumberUUID(5),
+			TargetID:      goframework.NumberUUID(10),
+			Target:        "target",
+			InvalidatedAt: lo.ToPtr(updateTime),
+		},
+		{
+			Metadata:      bunovel.NewMetadata(goframework.NumberUUID(14), baseTime, nil),
+			Vote:          models.VoteValueUp,
+			UserID:        goframework.NumberUUID(5),
+			TargetID:      goframework.NumberUUID(11),
+			Target:        "target",
+			InvalidatedAt: lo.ToPtr(updateTime),
+		},
+		{
+			Metadata:      bunovel.NewMetadata(goframework.NumberUUID(15), baseTime, nil),
+			Vote:          models.VoteValueDown,
+			UserID:        goframework.NumberUUID(5),
+			TargetID:      goframework.NumberUUID(12),
+			Target:        "target",
+			InvalidatedAt: lo.ToPtr(updateTime),
+		},
+		// User 4 voted the same way, but much later.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), updateTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(4),
+			TargetID: goframework.NumberUUID(10),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(11), updateTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(4),
+			TargetID: goframework.NumberUUID(11),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(12), updateTime, nil),
+			Vote:     models.VoteValueDown,
+			UserID:   goframework.NumberUUID(4),
+			TargetID: goframework.NumberUUID(12),
+			Target:   "target",
+		},
+	}
+
+	data := []struct {
+		name string
+
+		since      time.Time
+		window     time.Duration
+		minTargets int
+
+		expect    []*dao.CoVotingPairModel
+		expectErr error
+	}{
+		{
+			name:       "Success",
+			since:      baseTime.Add(-time.Hour),
+			window:     5 * time.Minute,
+			minTargets: 3,
+			expect: []*dao.CoVotingPairModel{
+				{
+					Target:    "target",
+					UserA:     goframework.NumberUUID(1),
+					UserB:     goframework.NumberUUID(2),
+					TargetIDs: []uuid.UUID{goframework.NumberUUID(10), goframework.NumberUUID(11), goframework.NumberUUID(12)},
+				},
+			},
+		},
+		{
+			name:       "Success/LowerThreshold",
+			since:      baseTime.Add(-time.Hour),
+			window:     5 * time.Minute,
+			minTargets: 2,
+			expect: []*dao.CoVotingPairModel{
+				{
+					Target:    "target",
+					UserA:     goframework.NumberUUID(1),
+					UserB:     goframework.NumberUUID(2),
+					TargetIDs: []uuid.UUID{goframework.NumberUUID(10), goframework.NumberUUID(11), goframework.NumberUUID(12)},
+				},
+				{
+					Target:    "target",
+					UserA:     goframework.NumberUUID(1),
+					UserB:     goframework.NumberUUID(3),
+					TargetIDs: []uuid.UUID{goframework.NumberUUID(10), goframework.NumberUUID(11)},
+				},
+				{
+					Target:    "target",
+					UserA:     goframework.NumberUUID(2),
+					UserB:     goframework.NumberUUID(3),
+					TargetIDs: []uuid.UUID{goframework.NumberUUID(10), goframework.NumberUUID(11)},
+				},
+			},
+		},
+		{
+			name:       "Success/OutOfLookback",
+			since:      baseTime.Add(30 * time.Minute),
+			window:     5 * time.Minute,
+			minTargets: 3,
+			expect:     []*dao.CoVotingPairModel{},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVoteFlagsRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.ListCoVotingPairs(ctx, d.since, d.window, d.minTargets)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestVoteFlagsRepository_ListNewVoterSwarms(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VoteModel{
+		// User 1 is an established voter.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime.Add(-30*24*time.Hour), nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(20),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(10),
+			Target:   "target",
+		},
+		// Users 2 to 4 just started voting.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(2),
+			TargetID: goframework.NumberUUID(10),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(4), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(3),
+			TargetID: goframework.NumberUUID(10),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(5), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(4),
+			TargetID: goframework.NumberUUID(10),
+			Target:   "target",
+		},
+		// Not the same vote.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(6), baseTime, nil),
+			Vote:     models.VoteValueDown,
+			UserID:   goframework.NumberUUID(5),
+			TargetID: goframework.NumberUUID(10),
+			Target:   "target",
+		},
+		// Discarded by a moderator.
+		{
+			Metadata:      bunovel.NewMetadata(goframework.NumberUUID(7), baseTime, nil),
+			Vote:          models.VoteValueUp,
+			UserID:        goframework.NumberUUID(6),
+			TargetID:      goframework.NumberUUID(10),
+			Target:        "target",
+			InvalidatedAt: lo.ToPtr(updateTime),
+		},
+	}
+
+	data := []struct {
+		name string
+
+		since          time.Time
+		firstVoteSince time.Time
+		minVoters      int
+
+		expect    []*dao.NewVoterSwarmModel
+		expectErr error
+	}{
+		{
+			name:           "Success",
+			since:          baseTime.Add(-time.Hour),
+			firstVoteSince: baseTime.Add(-72 * time.Hour),
+			minVoters:      3,
+			expect: []*dao.NewVoterSwarmModel{
+				{
+					Target:   "target",
+					TargetID: goframework.NumberUUID(10),
+					Vote:     models.VoteValueUp,
+					UserIDs:  []uuid.UUID{goframework.NumberUUID(2), goframework.NumberUUID(3), goframework.NumberUUID(4)},
+				},
+			},
+		},
+		{
+			name:           "Success/NotEnoughVoters",
+			since:          baseTime.Add(-time.Hour),
+			firstVoteSince: baseTime.Add(-72 * time.Hour),
+			minVoters:      4,
+			expect:         []*dao.NewVoterSwarmModel{},
+		},
+		{
+			name:           "Success/AllUsersAreNew",
+			since:          baseTime.Add(-time.Hour),
+			firstVoteSince: baseTime.Add(-60 * 24 * time.Hour),
+			minVoters:      4,
+			expect: []*dao.NewVoterSwarmModel{
+				{
+					Target:   "target",
+					TargetID: goframework.NumberUUID(10),
+					Vote:     models.VoteValueUp,
+					UserIDs: []uuid.UUID{
+						goframework.NumberUUID(1),
+						goframework.NumberUUID(2),
+						goframework.NumberUUID(3),
+						goframework.NumberUUID(4),
+					},
+				},
+			},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVoteFlagsRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.ListNewVoterSwarms(ctx, d.since, d.firstVoteSince, d.minVoters)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestVoteFlagsRepository_ListFlipBursts(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VoteEventModel{
+		{
+			ID:        1,
+			CreatedAt: baseTime,
+			Event:     models.VoteEventTypeFlip,
+			UserID:    goframework.NumberUUID(1),
+			TargetID:  goframework.NumberUUID(10),
+			Target:    "target",
+			OldVote:   lo.ToPtr(models.VoteValueUp),
+			NewVote:   lo.ToPtr(models.VoteValueDown),
+		},
+		{
+			ID:        2,
+			CreatedAt: baseTime,
+			Event:     models.VoteEventTypeRetract,
+			UserID:    goframework.NumberUUID(1),
+			TargetID:  goframework.NumberUUID(11),
+			Target:    "target",
+			OldVote:   lo.ToPtr(models.VoteValueUp),
+		},
+		// Casts are not changes.
+		{
+			ID:        3,
+			CreatedAt: baseTime,
+			Event:     models.VoteEventTypeCast,
+			UserID:    goframework.NumberUUID(1),
+			TargetID:  goframework.NumberUUID(12),
+			Target:    "target",
+			NewVote:   lo.ToPtr(models.VoteValueUp),
+		},
+		{
+			ID:        4,
+			CreatedAt: baseTime,
+			Event:     models.VoteEventTypeFlip,
+			UserID:    goframework.NumberUUID(1),
+			TargetID:  goframework.NumberUUID(10),
+			Target:    "target",
+			OldVote:   lo.ToPtr(models.VoteValueDown),
+			NewVote:   lo.ToPtr(models.VoteValueUp),
+		},
+		// Out of the lookback.
+		{
+			ID:        5,
+			CreatedAt: baseTime.Add(-2 * time.Hour),
+			Event:     models.VoteEventTypeFlip,
+			UserID:    goframework.NumberUUID(2),
+			TargetID:  goframework.NumberUUID(10),
+			Target:    "target",
+			OldVote:   lo.ToPtr(models.VoteValueUp),
+			NewVote:   lo.ToPtr(models.VoteValueDown),
+		},
+		{
+			ID:        6,
+			CreatedAt: baseTime,
+			Event:     models.VoteEventTypeFlip,
+			UserID:    goframework.NumberUUID(2),
+			TargetID:  goframework.NumberUUID(10),
+			Target:    "target",
+			OldVote:   lo.ToPtr(models.VoteValueDown),
+			NewVote:   lo.ToPtr(models.VoteValueUp),
+		},
+	}
+
+	data := []struct {
+		name string
+
+		since      time.Time
+		minChanges int
+
+		expect    []*dao.FlipBurstModel
+		expectErr error
+	}{
+		{
+			name:       "Success",
+			since:      baseTime.Add(-time.Hour),
+			minChanges: 3,
+			expect: []*dao.FlipBurstModel{
+				{
+					UserID:    goframework.NumberUUID(1),
+					Target:    "target",
+					TargetIDs: []uuid.UUID{goframework.NumberUUID(10), goframework.NumberUUID(11)},
+					Changes:   3,
+				},
+			},
+		},
+		{
+			name:       "Success/LongerLookback",
+			since:      baseTime.Add(-3 * time.Hour),
+			minChanges: 2,
+			expect: []*dao.FlipBurstModel{
+				{
+					UserID:    goframework.NumberUUID(1),
+					Target:    "target",
+					TargetIDs: []uuid.UUID{goframework.NumberUUID(10), goframework.NumberUUID(11)},
+					Changes:   3,
+				},
+				{
+					UserID:    goframework.NumberUUID(2),
+					Target:    "target",
+					TargetIDs: []uuid.UUID{goframework.NumberUUID(10)},
+					Changes:   2,
+				},
+			},
+		},
+		{
+			name:       "Success/NoBurst",
+			since:      baseTime.Add(-time.Hour),
+			minChanges: 4,
+			expect:     []*dao.FlipBurstModel{},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVoteFlagsRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.ListFlipBursts(ctx, d.since, d.minChanges)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestVoteFlagsRepository_Save(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VoteFlagModel{
+		{
+			ID:          goframework.NumberUUID(1),
+			CreatedAt:   baseTime,
+			Kind:        models.VoteFlagKindFlipBurst,
+			Target:      "target",
+			TargetIDs:   []uuid.UUID{goframework.NumberUUID(10)},
+			UserIDs:     []uuid.UUID{goframework.NumberUUID(20)},
+			Occurrences: 30,
+			Fingerprint: "reviewed",
+			Status:      models.VoteFlagStatusDismissed,
+		},
+		{
+			ID:          goframework.NumberUUID(2),
+			CreatedAt:   baseTime,
+			Kind:        models.VoteFlagKindCoVoting,
+			Target:      "target",
+			TargetIDs:   []uuid.UUID{goframework.NumberUUID(10), goframework.NumberUUID(12)},
+			UserIDs:     []uuid.UUID{goframework.NumberUUID(21), goframework.NumberUUID(22), goframework.NumberUUID(23)},
+			Occurrences: 2,
+			Fingerprint: "pending",
+		},
+		{
+			ID:          goframework.NumberUUID(3),
+			CreatedAt:   baseTime,
+			Kind:        models.VoteFlagKindNewVoters,
+			Target:      "target",
+			TargetIDs:   []uuid.UUID{goframework.NumberUUID(13)},
+			UserIDs:     []uuid.UUID{goframework.NumberUUID(24), goframework.NumberUUID(25)},
+			Occurrences: 2,
+			Fingerprint: "grown",
+			Status:      models.VoteFlagStatusConfirmed,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVoteFlagsRepository(tx)
+
+		inserted, err := repository.Save(ctx, []*dao.VoteFlagModel{
+			// Already reviewed, and nothing new: must not be reopened.
+			{
+				CreatedAt:   updateTime,
+				Kind:        models.VoteFlagKindFlipBurst,
+				Target:      "target",
+				TargetIDs:   []uuid.UUID{goframework.NumberUUID(10)},
+				UserIDs:     []uuid.UUID{goframework.NumberUUID(20)},
+				Occurrences: 35,
+				Fingerprint: "reviewed",
+			},
+			// Merged into the pending flag.
+			{
+				CreatedAt:   updateTime,
+				Kind:        models.VoteFlagKindCoVoting,
+				Target:      "target",
+				TargetIDs:   []uuid.UUID{goframework.NumberUUID(11), goframework.NumberUUID(12)},
+				UserIDs:     []uuid.UUID{goframework.NumberUUID(21), goframework.NumberUUID(22), goframework.NumberUUID(26)},
+				Occurrences: 2,
+				Fingerprint: "pending",
+			},
+			// A new user joined after the review.
+			{
+				CreatedAt:   updateTime,
+				Kind:        models.VoteFlagKindNewVoters,
+				Target:      "target",
+				TargetIDs:   []uuid.UUID{goframework.NumberUUID(13)},
+				UserIDs:     []uuid.UUID{goframework.NumberUUID(24), goframework.NumberUUID(25), goframework.NumberUUID(27)},
+				Occurrences: 3,
+				Fingerprint: "grown",
+			},
+			{
+				CreatedAt:   updateTime,
+				Kind:        models.VoteFlagKindNewVoters,
+				Target:      "target",
+				TargetIDs:   []uuid.UUID{goframework.NumberUUID(14)},
+				UserIDs:     []uuid.UUID{goframework.NumberUUID(28), goframework.NumberUUID(29)},
+				Occurrences: 2,
+				Fingerprint: "new",
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, inserted)
+
+		flags := make([]*dao.VoteFlagModel, 0)
+		require.NoError(t, tx.NewSelect().Model(&flags).Order("created_at", "fingerprint").Scan(ctx))
+		require.Len(t, flags, 5)
+
+		require.Equal(t, fixtures[2], flags[0])
+		require.Equal(t, &dao.VoteFlagModel{
+			ID:        goframework.NumberUUID(2),
+			CreatedAt: baseTime,
+			Kind:      models.VoteFlagKindCoVoting,
+			Target:    "target",
+			TargetIDs: []uuid.UUID{goframework.NumberUUID(10), goframework.NumberUUID(11), goframework.NumberUUID(12)},
+			UserIDs: []uuid.UUID{
+				goframework.NumberUUID(21),
+				goframework.NumberUUID(22),
+				goframework.NumberUUID(23),
+				goframework.NumberUUID(26),
+			},
+			Occurrences: 2,
+			Fingerprint: "pending",
+			Status:      models.VoteFlagStatusPending,
+		}, flags[1])
+		require.Equal(t, fixtures[0], flags[2])
+
+		require.NotEqual(t, uuid.Nil, flags[3].ID)
+		require.Equal(t, models.VoteFlagStatusPending, flags[3].Status)
+		require.Equal(t, "grown", flags[3].Fingerprint)
+
+		require.NotEqual(t, uuid.Nil, flags[4].ID)
+		require.Equal(t, models.VoteFlagStatusPending, flags[4].Status)
+		require.Equal(t, "new", flags[4].Fingerprint)
+
+		inserted, err = repository.Save(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, inserted)
+	})
+	require.NoError(t, err)
+}
+
+func TestVoteFlagsRepository_List(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VoteFlagModel{
+		{
+			ID:          goframework.NumberUUID(1),
+			CreatedAt:   baseTime,
+			Kind:        models.VoteFlagKindFlipBurst,
+			Target:      "target",
+			TargetIDs:   []uuid.UUID{goframework.NumberUUID(10)},
+			UserIDs:     []uuid.UUID{goframework.NumberUUID(20)},
+			Occurrences: 30,
+			Fingerprint: "1",
+			Status:      models.VoteFlagStatusPending,
+		},
+		{
+			ID:          goframework.NumberUUID(2),
+			CreatedAt:   updateTime,
+			Kind:        models.VoteFlagKindFlipBurst,
+			Target:      "target",
+			TargetIDs:   []uuid.UUID{goframework.NumberUUID(11)},
+			UserIDs:     []uuid.UUID{goframework.NumberUUID(21)},
+			Occurrences: 25,
+			Fingerprint: "2",
+			Status:      models.VoteFlagStatusDismissed,
+			ReviewedBy:  lo.ToPtr(goframework.NumberUUID(100)),
+			ReviewedAt:  lo.ToPtr(updateTime),
+		},
+		{
+			ID:          goframework.NumberUUID(3),
+			CreatedAt:   updateTime,
+			Kind:        models.VoteFlagKindCoVoting,
+			Target:      "target",
+			TargetIDs:   []uuid.UUID{goframework.NumberUUID(10), goframework.NumberUUID(11)},
+			UserIDs:     []uuid.UUID{goframework.NumberUUID(20), goframework.NumberUUID(21)},
+			Occurrences: 2,
+			Fingerprint: "3",
+			Status:      models.VoteFlagStatusPending,
+		},
+	}
+
+	data := []struct {
+		name string
+
+		filter dao.VoteFlagsFilter
+		limit  int
+		offset int
+
+		expect    []*dao.VoteFlagModel
+		expectErr error
+	}{
+		{
+			name:   "Success",
+			limit:  10,
+			expect: []*dao.VoteFlagModel{fixtures[1], fixtures[2], fixtures[0]},
+		},
+		{
+			name:   "Success/Kind",
+			filter: dao.VoteFlagsFilter{Kind: models.VoteFlagKindFlipBurst},
+			limit:  10,
+			expect: []*dao.VoteFlagModel{fixtures[1], fixtures[0]},
+		},
+		{
+			name:   "Success/Status",
+			filter: dao.VoteFlagsFilter{Status: models.VoteFlagStatusPending},
+			limit:  10,
+			expect: []*dao.VoteFlagModel{fixtures[2], fixtures[0]},
+		},
+		{
+			name:   "Success/KindAndStatus",
+			filter: dao.VoteFlagsFilter{Kind: models.VoteFlagKindFlipBurst, Status: models.VoteFlagStatusPending},
+			limit:  10,
+			expect: []*dao.VoteFlagModel{fixtures[0]},
+		},
+		{
+			name:   "Success/Paginate",
+			limit:  1,
+			offset: 1,
+			expect: []*dao.VoteFlagModel{fixtures[2]},
+		},
+		{
+			name:   "Success/NoResults",
+			filter: dao.VoteFlagsFilter{Status: models.VoteFlagStatusConfirmed},
+			limit:  10,
+			expect: []*dao.VoteFlagModel{},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVoteFlagsRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.List(ctx, d.filter, d.limit, d.offset)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestVoteFlagsRepository_Review(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VoteFlagModel{
+		{
+			ID:          goframework.NumberUUID(1),
+			CreatedAt:   baseTime,
+			Kind:        models.VoteFlagKindFlipBurst,
+			Target:      "target",
+			TargetIDs:   []uuid.UUID{goframework.NumberUUID(10)},
+			UserIDs:     []uuid.UUID{goframework.NumberUUID(20)},
+			Occurrences: 30,
+			Fingerprint: "1",
+			Status:      models.VoteFlagStatusPending,
+		},
+		{
+			ID:          goframework.NumberUUID(2),
+			CreatedAt:   baseTime,
+			Kind:        models.VoteFlagKindFlipBurst,
+			Target:      "target",
+			TargetIDs:   []uuid.UUID{goframework.NumberUUID(11)},
+			UserIDs:     []uuid.UUID{goframework.NumberUUID(21)},
+			Occurrences: 30,
+			Fingerprint: "2",
+			Status:      models.VoteFlagStatusConfirmed,
+			ReviewedBy:  lo.ToPtr(goframework.NumberUUID(100)),
+			ReviewedAt:  lo.ToPtr(baseTime),
+		},
+		// Reviewed flag, of a finding the detector opened a new flag for since.
+		{
+			ID:          goframework.NumberUUID(3),
+			CreatedAt:   baseTime,
+			Kind:        models.VoteFlagKindFlipBurst,
+			Target:      "target",
+			TargetIDs:   []uuid.UUID{goframework.NumberUUID(10)},
+			UserIDs:     []uuid.UUID{goframework.NumberUUID(20)},
+			Occurrences: 20,
+			Fingerprint: "1",
+			Status:      models.VoteFlagStatusDismissed,
+			ReviewedBy:  lo.ToPtr(goframework.NumberUUID(100)),
+			ReviewedAt:  lo.ToPtr(baseTime),
+		},
+	}
+
+	data := []struct {
+		name string
+
+		id         uuid.UUID
+		status     models.VoteFlagStatus
+		reviewerID uuid.UUID
+
+		expect    *dao.VoteFlagModel
+		expectErr error
+	}{
+		{
+			name:       "Success",
+			id:         goframework.NumberUUID(1),
+			status:     models.VoteFlagStatusConfirmed,
+			reviewerID: goframework.NumberUUID(100),
+			expect: &dao.VoteFlagModel{
+				ID:          goframework.NumberUUID(1),
+				CreatedAt:   baseTime,
+				Kind:        models.VoteFlagKindFlipBurst,
+				Target:      "target",
+				TargetIDs:   []uuid.UUID{goframework.NumberUUID(10)},
+				UserIDs:     []uuid.UUID{goframework.NumberUUID(20)},
+				Occurrences: 30,
+				Fingerprint: "1",
+				Status:      models.VoteFlagStatusConfirmed,
+				ReviewedBy:  lo.ToPtr(goframework.NumberUUID(100)),
+				ReviewedAt:  lo.ToPtr(updateTime),
+			},
+		},
+		{
+			name:       "Success/Reopen",
+			id:         goframework.NumberUUID(2),
+			status:     models.VoteFlagStatusPending,
+			reviewerID: goframework.NumberUUID(101),
+			expect: &dao.VoteFlagModel{
+				ID:          goframework.NumberUUID(2),
+				CreatedAt:   baseTime,
+				Kind:        models.VoteFlagKindFlipBurst,
+				Target:      "target",
+				TargetIDs:   []uuid.UUID{goframework.NumberUUID(11)},
+				UserIDs:     []uuid.UUID{goframework.NumberUUID(21)},
+				Occurrences: 30,
+				Fingerprint: "2",
+				Status:      models.VoteFlagStatusPending,
+			},
+		},
+		{
+			name:       "Error/ReopenWhilePending",
+			id:         goframework.NumberUUID(3),
+			status:     models.VoteFlagStatusPending,
+			reviewerID: goframework.NumberUUID(101),
+			expectErr:  dao.ErrVoteFlagPending,
+		},
+		{
+			name:       "Error/NotFound",
+			id:         goframework.NumberUUID(4),
+			status:     models.VoteFlagStatusConfirmed,
+			reviewerID: goframework.NumberUUID(100),
+			expectErr:  bunovel.ErrNotFound,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(st *testing.T) {
+			err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+				repository := dao.NewVoteFlagsRepository(tx)
+
+				res, err := repository.Review(ctx, d.id, d.status, d.reviewerID, updateTime)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+			require.NoError(t, err)
+		})
+	}
+}
@@ -2,18 +2,61 @@ package dao
 
 import (
 	"context"
+	"encoding/json"
+	goerrors "errors"
 	"github.com/a-novel/bunovel"
 	"github.com/a-novel/votes-service/pkg/models"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/uptrace/bun"
+	"sort"
 	"time"
 )
 
+// ErrVoteInvalidated is returned when a user retracts a vote that was invalidated by a moderator.
+var ErrVoteInvalidated = goerrors.New("vote was invalidated by a moderator")
+
 type VotesRepository interface {
 	Get(ctx context.Context, userID, targetID uuid.UUID, target string) (*VoteModel, error)
+	GetUserVotes(ctx context.Context, userID uuid.UUID, target string, targetIDs []uuid.UUID) ([]*VoteModel, error)
 	GetSummary(ctx context.Context, targetID uuid.UUID, target string) (*VotesSummaryModel, error)
-	ListUserVotes(ctx context.Context, userID uuid.UUID, target string, limit, offset int) ([]*VoteModel, error)
-	Cast(ctx context.Context, userID, targetID uuid.UUID, target string, vote *models.VoteValue, id uuid.UUID, now time.Time) (*VoteModel, error)
+	GetSummaries(ctx context.Context, target string, targetIDs []uuid.UUID) ([]*VotesSummaryModel, error)
+	// ListUserVotes returns the votes of a user, most recently active first. When cursor is set, only the votes
+	// after it are returned.
+	ListUserVotes(ctx context.Context, userID uuid.UUID, filter UserVotesFilter, cursor *VotesCursor, limit int) ([]*VoteModel, error)
+	CountUserVotes(ctx context.Context, userID uuid.UUID, filter UserVotesFilter) (int, error)
+	// ListTargetVotes returns the votes on a target, most recently active first. When cursor is set, only the votes
+	// after it are returned.
+	ListTargetVotes(ctx context.Context, targetID uuid.UUID, target string, filter TargetVotesFilter, cursor *VotesCursor, limit int) ([]*VoteModel, error)
+	CountTargetVotes(ctx context.Context, targetID uuid.UUID, target string, filter TargetVotesFilter) (int, error)
+	ListHotTargets(ctx context.Context, target string, gravity float64, since, now time.Time, limit, offset int) ([]*TargetScoreModel, error)
+	// ListTopRatedTargets returns the rated targets, best average first. A positive priorWeight pulls the average of
+	// each target towards the average of all the targets of its kind, as if it had priorWeight more ratings.
+	ListTopRatedTargets(ctx context.Context, target string, priorWeight float64, limit, offset int) ([]*TargetScoreModel, error)
+	// Cast sets the vote of a user. When expectedVote is set, the cast fails with a *models.VoteConflictError if the
+	// current vote is different. An empty expected value matches a user without vote. A vote that was invalidated
+	// stays invalidated when it changes, so it never counts in the summary, and cannot be retracted: removing it fails
+	// with ErrVoteInvalidated. The weight and score replace the ones of the current vote.
+	Cast(ctx context.Context, userID, targetID uuid.UUID, target string, vote, expectedVote *models.VoteValue, score *int, weight int, id uuid.UUID, now time.Time) (*VoteModel, error)
+	// GetTargetLock returns the lock of a target. Within a transaction, locking or unlocking the target waits until the
+	// transaction ends, even when the target has no lock yet, so the lock cannot change between its check and the cast.
+	GetTargetLock(ctx context.Context, targetID uuid.UUID, target string) (*TargetLockModel, error)
+	// QueueSummaryUpdate schedules the delivery of the counters of a summary to its target.
+	QueueSummaryUpdate(ctx context.Context, userID uuid.UUID, summary *VotesSummaryModel, now time.Time) error
+	// Invalidate excludes the matching votes from the summaries, and returns the votes that were invalidated. Votes
+	// that were already invalidated are left untouched.
+	Invalidate(ctx context.Context, filter ModeratedVotesFilter, moderatorID uuid.UUID, reason string, now time.Time) ([]*VoteModel, error)
+	// Restore counts the matching invalidated votes in the summaries again, and returns the votes that were restored.
+	Restore(ctx context.Context, filter ModeratedVotesFilter) ([]*VoteModel, error)
+	// GetIdempotencyKey returns a key that has not expired yet.
+	GetIdempotencyKey(ctx context.Context, userID uuid.UUID, key string, now time.Time) (*IdempotencyKeyModel, error)
+	// ClaimIdempotencyKey reserves a key for a request. It returns false if the key is already in use. Concurrent
+	// claims of the same key wait for each other, so the loser only returns once the winner is done.
+	ClaimIdempotencyKey(ctx context.Context, userID uuid.UUID, key, requestHash string, now, expiresAt time.Time) (bool, error)
+	// SaveIdempotencyResponse stores the response of the request a key was claimed for.
+	SaveIdempotencyResponse(ctx context.Context, userID uuid.UUID, key string, response json.RawMessage) error
+	// PurgeIdempotencyKeys deletes the expired keys, and returns how many were deleted.
+	PurgeIdempotencyKeys(ctx context.Context, now time.Time) (int, error)
 
 	RunInTx(ctx context.Context, f func(ctx context.Context, txClient VotesRepository) error) error
 }
@@ -22,10 +65,19 @@ type VoteModel struct {
 	bun.BaseModel `bun:"table:votes"`
 	bunovel.Metadata
 
-	Vote     models.VoteValue `bun:"type:vote"`
+	Vote     models.VoteValue `bun:"vote"`
 	UserID   uuid.UUID        `bun:"user_id"`
 	TargetID uuid.UUID        `bun:"target_id"`
 	Target   string           `bun:"target"`
+	// Weight is resolved when the vote is cast. It is 1 on targets that do not weigh their votes.
+	Weight int `bun:"weight"`
+	// Score is only set on the votes of rated targets.
+	Score *int `bun:"score"`
+
+	// InvalidatedAt is set when a moderator discarded the vote.
+	InvalidatedAt      *time.Time `bun:"invalidated_at"`
+	InvalidatedBy      *uuid.UUID `bun:"invalidated_by"`
+	InvalidationReason *string    `bun:"invalidation_reason"`
 }
 
 type VotesSummaryModel struct {
@@ -35,6 +87,66 @@ type VotesSummaryModel struct {
 	Target    string    `bun:"target"`
 	UpVotes   int       `bun:"up_votes"`
 	DownVotes int       `bun:"down_votes"`
+
+	// WeightedUpVotes and WeightedDownVotes sum the weights of the votes, rather than counting them.
+	WeightedUpVotes   int `bun:"weighted_up_votes"`
+	WeightedDownVotes int `bun:"weighted_down_votes"`
+
+	// Counts gives the number of votes for each value. Values without votes are omitted.
+	Counts map[models.VoteValue]int `bun:"counts,type:jsonb,nullzero,default:'{}'"`
+
+	// Ratings gives the number of ratings for each score. Scores without ratings are omitted. RatingCount and
+	// RatingSum are derived from it, for ranking.
+	Ratings     map[int]int `bun:"ratings,type:jsonb,nullzero,default:'{}'"`
+	RatingCount int         `bun:"rating_count"`
+	RatingSum   int         `bun:"rating_sum"`
+}
+
+type IdempotencyKeyModel struct {
+	bun.BaseModel `bun:"table:idempotency_keys"`
+
+	UserID    uuid.UUID `bun:"user_id,pk"`
+	Key       string    `bun:"idempotency_key,pk"`
+	CreatedAt time.Time `bun:"created_at"`
+	ExpiresAt time.Time `bun:"expires_at"`
+
+	RequestHash string          `bun:"request_hash"`
+	Response    json.RawMessage `bun:"response,type:jsonb,nullzero"`
+}
+
+// UserVotesFilter restricts the votes of a user. Empty fields are ignored.
+type UserVotesFilter struct {
+	Targets []string
+	Vote    *models.VoteValue
+	// Since and Until bound the activity date of the votes, inclusively.
+	Since *time.Time
+	Until *time.Time
+}
+
+// TargetVotesFilter restricts the votes on a target. Empty fields are ignored.
+type TargetVotesFilter struct {
+	Vote *models.VoteValue
+	// IncludeInvalidated also returns the votes discarded by moderators, which are excluded otherwise.
+	IncludeInvalidated bool
+}
+
+// VotesCursor points to a vote in a list ordered by activity date, then ID.
+type VotesCursor struct {
+	ActivityAt time.Time
+	ID         uuid.UUID
+}
+
+// ModeratedVotesFilter selects the votes of a moderation action. At least one of UserID or TargetID must be set.
+type ModeratedVotesFilter struct {
+	UserID *uuid.UUID
+	// TargetID requires Target to be set.
+	TargetID *uuid.UUID
+	Target   string
+}
+
+type TargetScoreModel struct {
+	TargetID uuid.UUID `bun:"target_id"`
+	Score    float64   `bun:"score"`
 }
 
 func NewVotesRepository(db bun.IDB) VotesRepository {
@@ -61,6 +173,26 @@ func (repository *votesRepositoryImpl) Get(ctx context.Context, userID, targetID
 	return model, nil
 }
 
+func (repository *votesRepositoryImpl) GetUserVotes(ctx context.Context, userID uuid.UUID, target string, targetIDs []uuid.UUID) ([]*VoteModel, error) {
+	votes := make([]*VoteModel, 0)
+
+	if len(targetIDs) == 0 {
+		return votes, nil
+	}
+
+	err := repository.db.NewSelect().Model(&votes).
+		Where("user_id = ?", userID).
+		Where("target_id IN (?)", bun.In(targetIDs)).
+		Where("target = ?", target).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return votes, nil
+}
+
 func (repository *votesRepositoryImpl) GetSummary(ctx context.Context, targetID uuid.UUID, target string) (*VotesSummaryModel, error) {
 	model := new(VotesSummaryModel)
 
@@ -70,20 +202,55 @@ func (repository *votesRepositoryImpl) GetSummary(ctx context.Context, targetID
 		Scan(ctx)
 
 	if err != nil {
-		return nil, bunovel.HandlePGError(err)
+		err = bunovel.HandlePGError(err)
+
+		// A target nobody voted for yet has no summary row, which is a valid state.
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return &VotesSummaryModel{
+				TargetID: targetID,
+				Target:   target,
+				Counts:   map[models.VoteValue]int{},
+				Ratings:  map[int]int{},
+			}, nil
+		}
+
+		return nil, err
 	}
 
 	return model, nil
 }
 
-func (repository *votesRepositoryImpl) ListUserVotes(ctx context.Context, userID uuid.UUID, target string, limit, offset int) ([]*VoteModel, error) {
-	votes := make([]*VoteModel, 0)
+func (repository *votesRepositoryImpl) GetSummaries(ctx context.Context, target string, targetIDs []uuid.UUID) ([]*VotesSummaryModel, error) {
+	summaries := make([]*VotesSummaryModel, 0)
 
-	err := repository.db.NewSelect().Model(&votes).
-		Where("user_id = ?", userID).
+	if len(targetIDs) == 0 {
+		return summaries, nil
+	}
+
+	err := repository.db.NewSelect().Model(&summaries).
+		Where("target_id IN (?)", bun.In(targetIDs)).
 		Where("target = ?", target).
-		OrderExpr("COALESCE(updated_at, created_at) DESC").
-		Limit(limit).Offset(offset).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return summaries, nil
+}
+
+func (repository *votesRepositoryImpl) ListUserVotes(ctx context.Context, userID uuid.UUID, filter UserVotesFilter, cursor *VotesCursor, limit int) ([]*VoteModel, error) {
+	votes := make([]*VoteModel, 0)
+
+	query := filterUserVotes(repository.db.NewSelect().Model(&votes), userID, filter)
+
+	if cursor != nil {
+		query = query.Where("(COALESCE(updated_at, created_at), id) < (?, ?)", cursor.ActivityAt, cursor.ID)
+	}
+
+	err := query.
+		OrderExpr("COALESCE(updated_at, created_at) DESC, id DESC").
+		Limit(limit).
 		Scan(ctx)
 
 	if err != nil {
@@ -93,35 +260,332 @@ func (repository *votesRepositoryImpl) ListUserVotes(ctx context.Context, userID
 	return votes, nil
 }
 
-func (repository *votesRepositoryImpl) Cast(ctx context.Context, userID, targetID uuid.UUID, target string, vote *models.VoteValue, id uuid.UUID, now time.Time) (*VoteModel, error) {
-	model := new(VoteModel)
+func (repository *votesRepositoryImpl) CountUserVotes(ctx context.Context, userID uuid.UUID, filter UserVotesFilter) (int, error) {
+	count, err := filterUserVotes(repository.db.NewSelect().Model((*VoteModel)(nil)), userID, filter).Count(ctx)
+	if err != nil {
+		return 0, bunovel.HandlePGError(err)
+	}
+
+	return count, nil
+}
+
+func (repository *votesRepositoryImpl) ListTargetVotes(ctx context.Context, targetID uuid.UUID, target string, filter TargetVotesFilter, cursor *VotesCursor, limit int) ([]*VoteModel, error) {
+	votes := make([]*VoteModel, 0)
+
+	query := filterTargetVotes(repository.db.NewSelect().Model(&votes), targetID, target, filter)
+
+	if cursor != nil {
+		query = query.Where("(COALESCE(updated_at, created_at), id) < (?, ?)", cursor.ActivityAt, cursor.ID)
+	}
+
+	err := query.
+		OrderExpr("COALESCE(updated_at, created_at) DESC, id DESC").
+		Limit(limit).
+		Scan(ctx)
 
-	if vote == nil {
-		_, err := repository.db.NewDelete().Model(model).
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return votes, nil
+}
+
+func (repository *votesRepositoryImpl) CountTargetVotes(ctx context.Context, targetID uuid.UUID, target string, filter TargetVotesFilter) (int, error) {
+	count, err := filterTargetVotes(repository.db.NewSelect().Model((*VoteModel)(nil)), targetID, target, filter).Count(ctx)
+	if err != nil {
+		return 0, bunovel.HandlePGError(err)
+	}
+
+	return count, nil
+}
+
+func (repository *votesRepositoryImpl) ListHotTargets(ctx context.Context, target string, gravity float64, since, now time.Time, limit, offset int) ([]*TargetScoreModel, error) {
+	scores := make([]*TargetScoreModel, 0)
+
+	// Each vote counts for -1 if it is a down vote and +1 otherwise: reactions are positive engagement, so they make a
+	// target hot like up votes do. Ratings would also count as +1, whatever their score, so rated targets must be
+	// ranked with ListTopRatedTargets instead. Each vote is divided by its age in hours (plus 2, so fresh votes do not
+	// weigh infinitely) raised to the power of gravity.
+	err := repository.db.NewSelect().Model((*VoteModel)(nil)).
+		Column("target_id").
+		ColumnExpr(
+			"SUM((CASE WHEN vote = 'down' THEN -1 ELSE 1 END) / POWER(GREATEST(EXTRACT(EPOCH FROM (?::timestamptz - COALESCE(updated_at, created_at))), 0) / 3600 + 2, ?)) AS score",
+			now, gravity,
+		).
+		Where("target = ?", target).
+		Where("COALESCE(updated_at, created_at) >= ?", since).
+		Where("invalidated_at IS NULL").
+		Group("target_id").
+		OrderExpr("score DESC, target_id").
+		Limit(limit).Offset(offset).
+		Scan(ctx, &scores)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return scores, nil
+}
+
+func (repository *votesRepositoryImpl) ListTopRatedTargets(ctx context.Context, target string, priorWeight float64, limit, offset int) ([]*TargetScoreModel, error) {
+	scores := make([]*TargetScoreModel, 0)
+
+	// The prior is the average of all the ratings of the same kind of target.
+	prior := repository.db.NewSelect().Model((*VotesSummaryModel)(nil)).
+		ColumnExpr("COALESCE(SUM(rating_sum)::DOUBLE PRECISION / NULLIF(SUM(rating_count), 0), 0) AS mean").
+		Where("target = ?", target)
+
+	err := repository.db.NewSelect().
+		With("prior", prior).
+		Model((*VotesSummaryModel)(nil)).
+		TableExpr("prior").
+		Column("target_id").
+		ColumnExpr(
+			"(?::DOUBLE PRECISION * prior.mean + rating_sum) / (?::DOUBLE PRECISION + rating_count) AS score",
+			priorWeight, priorWeight,
+		).
+		Where("target = ?", target).
+		Where("rating_count > 0").
+		OrderExpr("score DESC, target_id").
+		Limit(limit).Offset(offset).
+		Scan(ctx, &scores)
+
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return scores, nil
+}
+
+func (repository *votesRepositoryImpl) Cast(ctx context.Context, userID, targetID uuid.UUID, target string, vote, expectedVote *models.VoteValue, score *int, weight int, id uuid.UUID, now time.Time) (*VoteModel, error) {
+	var model *VoteModel
+
+	// The vote, its history and the summary counters must be updated together, otherwise the summary drifts away
+	// from the actual votes. When the repository is already bound to a transaction, this creates a savepoint.
+	err := repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Serialize the casts of the user on the target, so concurrent casts compute their deltas from the same state.
+		// Locking the current vote is not enough: a user without vote has no row to lock, and concurrent first casts
+		// would all insert one.
+		_, err := tx.NewRaw("SELECT pg_advisory_xact_lock(hashtext(?))", voteLockKey(userID, targetID, target)).Exec(ctx)
+		if err != nil {
+			return bunovel.HandlePGError(err)
+		}
+
+		previous := new(VoteModel)
+
+		err = tx.NewSelect().Model(previous).
 			Where("user_id = ?", userID).
 			Where("target_id = ?", targetID).
 			Where("target = ?", target).
-			Exec(ctx)
+			For("UPDATE").
+			Scan(ctx)
+
+		if err != nil {
+			if err = bunovel.HandlePGError(err); !goerrors.Is(err, bunovel.ErrNotFound) {
+				return err
+			}
+
+			previous = nil
+		}
+
+		// Keep a copy of the previous value, score and weight, as the model is updated in place below.
+		var (
+			previousVote   *models.VoteValue
+			previousScore  *int
+			previousWeight int
+		)
+		if previous != nil {
+			previousVote = lo.ToPtr(previous.Vote)
+			previousScore = previous.Score
+			previousWeight = previous.Weight
+		}
+
+		// The casts of the user on the target are serialized by the advisory lock above, so the current vote cannot
+		// change between this check and the write, even when the user had no vote yet.
+		if expectedVote != nil && lo.FromPtr(previousVote) != *expectedVote {
+			return &models.VoteConflictError{CurrentVote: previousVote}
+		}
+
+		switch {
+		case vote == nil && previous == nil:
+			return nil
+		// Deleting the vote would erase the decision of the moderator, and let the user vote again from scratch.
+		case vote == nil && previous.InvalidatedAt != nil:
+			return ErrVoteInvalidated
+		case vote == nil:
+			_, err = tx.NewDelete().Model(previous).WherePK().Exec(ctx)
+		case previous == nil:
+			model = &VoteModel{
+				Metadata: bunovel.NewMetadata(id, now, nil),
+				Vote:     *vote,
+				UserID:   userID,
+				TargetID: targetID,
+				Target:   target,
+				Weight:   weight,
+				Score:    score,
+			}
+			_, err = tx.NewInsert().Model(model).Returning("*").Exec(ctx)
+		default:
+			model = previous
+			model.Vote = *vote
+			model.Weight = weight
+			model.Score = score
+			model.UpdatedAt = &now
+			_, err = tx.NewUpdate().Model(model).Column("vote", "weight", "score", "updated_at").WherePK().Exec(ctx)
+		}
+
+		if err != nil {
+			return bunovel.HandlePGError(err)
+		}
+
+		if eventType, ok := voteEventType(previousVote, vote, previousScore, score); ok {
+			_, err = tx.NewInsert().Model(&VoteEventModel{
+				CreatedAt: now,
+				Event:     eventType,
+				UserID:    userID,
+				TargetID:  targetID,
+				Target:    target,
+				OldVote:   previousVote,
+				NewVote:   vote,
+				OldScore:  previousScore,
+				NewScore:  score,
+			}).Exec(ctx)
+
+			if err != nil {
+				return bunovel.HandlePGError(err)
+			}
+		}
+
+		// An invalidated vote is not part of the summary, whatever its value.
+		if previous != nil && previous.InvalidatedAt != nil {
+			return nil
+		}
+
+		delta := voteDelta(vote, score, weight).sub(voteDelta(previousVote, previousScore, previousWeight))
+
+		return addToSummary(ctx, tx, targetID, target, delta)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (repository *votesRepositoryImpl) GetTargetLock(ctx context.Context, targetID uuid.UUID, target string) (*TargetLockModel, error) {
+	// A row lock would not be enough: a target without lock has no row to lock, and a new lock could be inserted
+	// before the cast. TargetLocksRepository takes the same key exclusively.
+	_, err := repository.db.NewRaw("SELECT pg_advisory_xact_lock_shared(hashtext(?))", targetLockKey(targetID, target)).Exec(ctx)
+	if err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	model := &TargetLockModel{TargetID: targetID, Target: target}
 
+	if err := repository.db.NewSelect().Model(model).WherePK().Scan(ctx); err != nil {
+		return nil, bunovel.HandlePGError(err)
+	}
+
+	return model, nil
+}
+
+func (repository *votesRepositoryImpl) QueueSummaryUpdate(ctx context.Context, userID uuid.UUID, summary *VotesSummaryModel, now time.Time) error {
+	model := &OutboxMessageModel{
+		CreatedAt:         now,
+		Target:            summary.Target,
+		TargetID:          summary.TargetID,
+		UserID:            userID,
+		UpVotes:           summary.UpVotes,
+		DownVotes:         summary.DownVotes,
+		WeightedUpVotes:   summary.WeightedUpVotes,
+		WeightedDownVotes: summary.WeightedDownVotes,
+		Counts:            summary.Counts,
+		Ratings:           summary.Ratings,
+		NextAttemptAt:     now,
+	}
+
+	// A pending message for the same target is outdated: overwrite it, and reset its delivery state. A message claimed
+	// by a dispatcher keeps its lease, so no other dispatcher delivers the new counters before the old ones.
+	_, err := repository.db.NewInsert().Model(model).
+		On("CONFLICT (target_id, target) WHERE dead_at IS NULL DO UPDATE").
+		Set("version = vote_outbox.version + 1").
+		Set("user_id = EXCLUDED.user_id").
+		Set("up_votes = EXCLUDED.up_votes").
+		Set("down_votes = EXCLUDED.down_votes").
+		Set("weighted_up_votes = EXCLUDED.weighted_up_votes").
+		Set("weighted_down_votes = EXCLUDED.weighted_down_votes").
+		Set("counts = EXCLUDED.counts").
+		Set("ratings = EXCLUDED.ratings").
+		Set("attempts = 0").
+		Set("next_attempt_at = GREATEST(vote_outbox.next_attempt_at, EXCLUDED.next_attempt_at)").
+		Set("last_error = NULL").
+		Exec(ctx)
+
+	if err != nil {
+		return bunovel.HandlePGError(err)
+	}
+
+	return nil
+}
+
+func (repository *votesRepositoryImpl) Invalidate(ctx context.Context, filter ModeratedVotesFilter, moderatorID uuid.UUID, reason string, now time.Time) ([]*VoteModel, error) {
+	votes := make([]*VoteModel, 0)
+
+	err := repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		query := tx.NewUpdate().Model((*VoteModel)(nil)).
+			Set("invalidated_at = ?", now).
+			Set("invalidated_by = ?", moderatorID).
+			Set("invalidation_reason = ?", reason).
+			Where("invalidated_at IS NULL")
+
+		err := filterModeratedVotes(query, filter).Returning("*").Scan(ctx, &votes)
 		if err != nil {
-			return nil, bunovel.HandlePGError(err)
+			return bunovel.HandlePGError(err)
 		}
 
-		return nil, nil
+		return removeFromSummaries(ctx, tx, votes, true)
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	model.ID = id
-	model.CreatedAt = now
-	model.UserID = userID
-	model.TargetID = targetID
-	model.Target = target
-	model.Vote = *vote
+	return votes, nil
+}
+
+func (repository *votesRepositoryImpl) Restore(ctx context.Context, filter ModeratedVotesFilter) ([]*VoteModel, error) {
+	votes := make([]*VoteModel, 0)
+
+	err := repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		query := tx.NewUpdate().Model((*VoteModel)(nil)).
+			Set("invalidated_at = NULL").
+			Set("invalidated_by = NULL").
+			Set("invalidation_reason = NULL").
+			Where("invalidated_at IS NOT NULL")
+
+		err := filterModeratedVotes(query, filter).Returning("*").Scan(ctx, &votes)
+		if err != nil {
+			return bunovel.HandlePGError(err)
+		}
+
+		return removeFromSummaries(ctx, tx, votes, false)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+func (repository *votesRepositoryImpl) GetIdempotencyKey(ctx context.Context, userID uuid.UUID, key string, now time.Time) (*IdempotencyKeyModel, error) {
+	model := new(IdempotencyKeyModel)
 
-	err := repository.db.NewInsert().Model(model).
-		Returning("*").
-		On("CONFLICT (user_id, target_id, target) DO UPDATE").
-		Set("vote = EXCLUDED.vote").
-		Set("updated_at = EXCLUDED.created_at").
+	err := repository.db.NewSelect().Model(model).
+		Where("user_id = ?", userID).
+		Where("idempotency_key = ?", key).
+		Where("expires_at > ?", now).
 		Scan(ctx)
 
 	if err != nil {
@@ -131,8 +595,295 @@ func (repository *votesRepositoryImpl) Cast(ctx context.Context, userID, targetI
 	return model, nil
 }
 
+func (repository *votesRepositoryImpl) ClaimIdempotencyKey(ctx context.Context, userID uuid.UUID, key, requestHash string, now, expiresAt time.Time) (bool, error) {
+	model := &IdempotencyKeyModel{
+		UserID:      userID,
+		Key:         key,
+		CreatedAt:   now,
+		ExpiresAt:   expiresAt,
+		RequestHash: requestHash,
+	}
+
+	// An expired key is free to be claimed again.
+	res, err := repository.db.NewInsert().Model(model).
+		On("CONFLICT (user_id, idempotency_key) DO UPDATE").
+		Set("created_at = EXCLUDED.created_at").
+		Set("expires_at = EXCLUDED.expires_at").
+		Set("request_hash = EXCLUDED.request_hash").
+		Set("response = NULL").
+		Where("idempotency_keys.expires_at <= ?", now).
+		Exec(ctx)
+
+	if err != nil {
+		return false, bunovel.HandlePGError(err)
+	}
+
+	claimed, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return claimed > 0, nil
+}
+
+func (repository *votesRepositoryImpl) SaveIdempotencyResponse(ctx context.Context, userID uuid.UUID, key string, response json.RawMessage) error {
+	_, err := repository.db.NewUpdate().Model((*IdempotencyKeyModel)(nil)).
+		Set("response = ?", string(response)).
+		Where("user_id = ?", userID).
+		Where("idempotency_key = ?", key).
+		Exec(ctx)
+
+	if err != nil {
+		return bunovel.HandlePGError(err)
+	}
+
+	return nil
+}
+
+func (repository *votesRepositoryImpl) PurgeIdempotencyKeys(ctx context.Context, now time.Time) (int, error) {
+	res, err := repository.db.NewDelete().Model((*IdempotencyKeyModel)(nil)).
+		Where("expires_at <= ?", now).
+		Exec(ctx)
+
+	if err != nil {
+		return 0, bunovel.HandlePGError(err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
 func (repository *votesRepositoryImpl) RunInTx(ctx context.Context, callback func(ctx context.Context, txRepository VotesRepository) error) error {
 	return repository.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
 		return callback(ctx, NewVotesRepository(tx))
 	})
 }
+
+// voteLockKey identifies the vote of a user on a target, for advisory locks.
+func voteLockKey(userID, targetID uuid.UUID, target string) string {
+	return userID.String() + "/" + target + "/" + targetID.String()
+}
+
+// summaryDelta is a change to apply to the summary counters of a target.
+type summaryDelta struct {
+	upVotes           int
+	downVotes         int
+	weightedUpVotes   int
+	weightedDownVotes int
+	counts            map[models.VoteValue]int
+	ratings           map[int]int
+	ratingCount       int
+	ratingSum         int
+}
+
+// voteDelta returns the share of a single vote in the summary counters of its target. A nil value means no vote.
+func voteDelta(vote *models.VoteValue, score *int, weight int) summaryDelta {
+	delta := summaryDelta{counts: map[models.VoteValue]int{}, ratings: map[int]int{}}
+
+	if vote != nil {
+		delta.counts[*vote] = 1
+
+		switch *vote {
+		case models.VoteValueUp:
+			delta.upVotes, delta.weightedUpVotes = 1, weight
+		case models.VoteValueDown:
+			delta.downVotes, delta.weightedDownVotes = 1, weight
+		case models.VoteValueRating:
+			if score != nil {
+				delta.ratings[*score] = 1
+				delta.ratingCount, delta.ratingSum = 1, *score
+			}
+		}
+	}
+
+	return delta
+}
+
+func (delta summaryDelta) add(other summaryDelta) summaryDelta {
+	return delta.merge(other, 1)
+}
+
+func (delta summaryDelta) sub(other summaryDelta) summaryDelta {
+	return delta.merge(other, -1)
+}
+
+func (delta summaryDelta) merge(other summaryDelta, sign int) summaryDelta {
+	return summaryDelta{
+		upVotes:           delta.upVotes + sign*other.upVotes,
+		downVotes:         delta.downVotes + sign*other.downVotes,
+		weightedUpVotes:   delta.weightedUpVotes + sign*other.weightedUpVotes,
+		weightedDownVotes: delta.weightedDownVotes + sign*other.weightedDownVotes,
+		counts:            mergeCounts(delta.counts, other.counts, sign),
+		ratings:           mergeCounts(delta.ratings, other.ratings, sign),
+		ratingCount:       delta.ratingCount + sign*other.ratingCount,
+		ratingSum:         delta.ratingSum + sign*other.ratingSum,
+	}
+}
+
+func (delta summaryDelta) isZero() bool {
+	return delta.upVotes == 0 && delta.downVotes == 0 &&
+		delta.weightedUpVotes == 0 && delta.weightedDownVotes == 0 &&
+		len(delta.counts) == 0 && len(delta.ratings) == 0 &&
+		delta.ratingCount == 0 && delta.ratingSum == 0
+}
+
+// mergeCounts adds or subtracts the counters of other to a copy of counts. Keys that end up at 0 are removed.
+func mergeCounts[K comparable](counts, other map[K]int, sign int) map[K]int {
+	merged := make(map[K]int, len(counts)+len(other))
+	for key, count := range counts {
+		merged[key] = count
+	}
+	for key, count := range other {
+		if merged[key] += sign * count; merged[key] == 0 {
+			delete(merged, key)
+		}
+	}
+
+	return merged
+}
+
+// voteEventType returns the event to record when a vote goes from the previous value and score to the next ones.
+// Casting the same value and score again is not an event.
+func voteEventType(previous, next *models.VoteValue, previousScore, nextScore *int) (models.VoteEventType, bool) {
+	switch {
+	case previous == nil && next == nil:
+		return "", false
+	case previous == nil:
+		return models.VoteEventTypeCast, true
+	case next == nil:
+		return models.VoteEventTypeRetract, true
+	case *previous != *next, lo.FromPtr(previousScore) != lo.FromPtr(nextScore):
+		return models.VoteEventTypeFlip, true
+	default:
+		return "", false
+	}
+}
+
+// addToSummary applies a delta to the summary counters of a target.
+func addToSummary(ctx context.Context, db bun.IDB, targetID uuid.UUID, target string, delta summaryDelta) error {
+	if delta.isZero() {
+		return nil
+	}
+
+	_, err := db.NewInsert().
+		Model(&VotesSummaryModel{
+			TargetID:          targetID,
+			Target:            target,
+			UpVotes:           delta.upVotes,
+			DownVotes:         delta.downVotes,
+			WeightedUpVotes:   delta.weightedUpVotes,
+			WeightedDownVotes: delta.weightedDownVotes,
+			Counts:            delta.counts,
+			Ratings:           delta.ratings,
+			RatingCount:       delta.ratingCount,
+			RatingSum:         delta.ratingSum,
+		}).
+		On("CONFLICT (target_id, target) DO UPDATE").
+		Set("up_votes = votes_summary.up_votes + EXCLUDED.up_votes").
+		Set("down_votes = votes_summary.down_votes + EXCLUDED.down_votes").
+		Set("weighted_up_votes = votes_summary.weighted_up_votes + EXCLUDED.weighted_up_votes").
+		Set("weighted_down_votes = votes_summary.weighted_down_votes + EXCLUDED.weighted_down_votes").
+		Set("counts = jsonb_add_counts(votes_summary.counts, EXCLUDED.counts)").
+		Set("ratings = jsonb_add_counts(votes_summary.ratings, EXCLUDED.ratings)").
+		Set("rating_count = votes_summary.rating_count + EXCLUDED.rating_count").
+		Set("rating_sum = votes_summary.rating_sum + EXCLUDED.rating_sum").
+		Exec(ctx)
+
+	if err != nil {
+		return bunovel.HandlePGError(err)
+	}
+
+	return nil
+}
+
+// removeFromSummaries removes the votes from the summary counters of their targets, or adds them back when remove
+// is false.
+func removeFromSummaries(ctx context.Context, db bun.IDB, votes []*VoteModel, remove bool) error {
+	type summaryKey struct {
+		targetID uuid.UUID
+		target   string
+	}
+
+	deltas := make(map[summaryKey]summaryDelta)
+	keys := make([]summaryKey, 0)
+
+	for _, vote := range votes {
+		key := summaryKey{targetID: vote.TargetID, target: vote.Target}
+		if _, ok := deltas[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		if remove {
+			deltas[key] = deltas[key].sub(voteDelta(&vote.Vote, vote.Score, vote.Weight))
+		} else {
+			deltas[key] = deltas[key].add(voteDelta(&vote.Vote, vote.Score, vote.Weight))
+		}
+	}
+
+	// Update the summaries in a stable order, so concurrent moderation actions do not deadlock.
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].target != keys[j].target {
+			return keys[i].target < keys[j].target
+		}
+
+		return keys[i].targetID.String() < keys[j].targetID.String()
+	})
+
+	for _, key := range keys {
+		if err := addToSummary(ctx, db, key.targetID, key.target, deltas[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func filterModeratedVotes(query *bun.UpdateQuery, filter ModeratedVotesFilter) *bun.UpdateQuery {
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.TargetID != nil {
+		query = query.Where("target_id = ?", *filter.TargetID)
+	}
+	if filter.Target != "" {
+		query = query.Where("target = ?", filter.Target)
+	}
+
+	return query
+}
+
+func filterUserVotes(query *bun.SelectQuery, userID uuid.UUID, filter UserVotesFilter) *bun.SelectQuery {
+	query = query.Where("user_id = ?", userID)
+
+	if len(filter.Targets) > 0 {
+		query = query.Where("target IN (?)", bun.In(filter.Targets))
+	}
+	if filter.Vote != nil {
+		query = query.Where("vote = ?", *filter.Vote)
+	}
+	if filter.Since != nil {
+		query = query.Where("COALESCE(updated_at, created_at) >= ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		query = query.Where("COALESCE(updated_at, created_at) <= ?", *filter.Until)
+	}
+
+	return query
+}
+
+func filterTargetVotes(query *bun.SelectQuery, targetID uuid.UUID, target string, filter TargetVotesFilter) *bun.SelectQuery {
+	query = query.Where("target_id = ?", targetID).Where("target = ?", target)
+
+	if filter.Vote != nil {
+		query = query.Where("vote = ?", *filter.Vote)
+	}
+	if !filter.IncludeInvalidated {
+		query = query.Where("invalidated_at IS NULL")
+	}
+
+	return query
+}
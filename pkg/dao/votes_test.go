@@ -2,6 +2,8 @@ package dao_test
 
 import (
 	"context"
+	"encoding/json"
+	goerrors "errors"
 	"github.com/a-novel/bunovel"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/a-novel/votes-service/migrations"
@@ -12,6 +14,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/uptrace/bun"
 	"io/fs"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -84,7 +88,7 @@ func TestVotesRepository_Get(t *testing.T) {
 	require.NoError(t, err)
 }
 
-func TestVotesRepository_GetSummary(t *testing.T) {
+func TestVotesRepository_GetUserVotes(t *testing.T) {
 	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
 	defer db.Close()
 	defer sqlDB.Close()
@@ -99,35 +103,119 @@ func TestVotesRepository_GetSummary(t *testing.T) {
 		},
 		{
 			Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
-			Vote:     models.VoteValueUp,
-			UserID:   goframework.NumberUUID(2),
-			TargetID: goframework.NumberUUID(1),
+			Vote:     models.VoteValueDown,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(2),
 			Target:   "target",
 		},
+		// Another user.
 		{
 			Metadata: bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, nil),
-			Vote:     models.VoteValueDown,
-			UserID:   goframework.NumberUUID(3),
-			TargetID: goframework.NumberUUID(1),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(2),
+			TargetID: goframework.NumberUUID(3),
 			Target:   "target",
 		},
-
-		// Another target id.
+		// Another target.
 		{
 			Metadata: bunovel.NewMetadata(goframework.NumberUUID(4), baseTime, nil),
 			Vote:     models.VoteValueUp,
 			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(3),
+			Target:   "other-target",
+		},
+	}
+
+	data := []struct {
+		name string
+
+		userID    uuid.UUID
+		target    string
+		targetIDs []uuid.UUID
+
+		expect    []*dao.VoteModel
+		expectErr error
+	}{
+		{
+			name:      "Success",
+			userID:    goframework.NumberUUID(1),
+			target:    "target",
+			targetIDs: []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2), goframework.NumberUUID(3)},
+			expect: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(1),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+					Vote:     models.VoteValueDown,
+					UserID:   goframework.NumberUUID(1),
+					TargetID: goframework.NumberUUID(2),
+					Target:   "target",
+				},
+			},
+		},
+		{
+			name:      "Success/NoResults",
+			userID:    goframework.NumberUUID(2),
+			target:    "target",
+			targetIDs: []uuid.UUID{goframework.NumberUUID(1)},
+			expect:    []*dao.VoteModel{},
+		},
+		{
+			name:   "Success/NoTargets",
+			userID: goframework.NumberUUID(1),
+			target: "target",
+			expect: []*dao.VoteModel{},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVotesRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.GetUserVotes(ctx, d.userID, d.target, d.targetIDs)
+				require.ErrorIs(t, err, d.expectErr)
+				require.ElementsMatch(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestVotesRepository_GetSummary(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VotesSummaryModel{
+		{
+			TargetID:  goframework.NumberUUID(1),
+			Target:    "target",
+			UpVotes:   2,
+			DownVotes: 1,
+			Counts:    map[models.VoteValue]int{models.VoteValueUp: 2, models.VoteValueDown: 1},
+			Ratings:   map[int]int{},
+		},
+		// Another target id.
+		{
 			TargetID: goframework.NumberUUID(2),
 			Target:   "target",
+			UpVotes:  1,
+			Counts:   map[models.VoteValue]int{models.VoteValueUp: 1},
+			Ratings:  map[int]int{},
 		},
-
 		// Another target.
 		{
-			Metadata: bunovel.NewMetadata(goframework.NumberUUID(5), baseTime, nil),
-			Vote:     models.VoteValueUp,
-			UserID:   goframework.NumberUUID(1),
 			TargetID: goframework.NumberUUID(1),
 			Target:   "other-target",
+			UpVotes:  1,
+			Counts:   map[models.VoteValue]int{models.VoteValueUp: 1},
+			Ratings:  map[int]int{},
 		},
 	}
 
@@ -149,13 +237,20 @@ func TestVotesRepository_GetSummary(t *testing.T) {
 				TargetID:  goframework.NumberUUID(1),
 				UpVotes:   2,
 				DownVotes: 1,
+				Counts:    map[models.VoteValue]int{models.VoteValueUp: 2, models.VoteValueDown: 1},
+				Ratings:   map[int]int{},
 			},
 		},
 		{
-			name:      "Error/NotFound",
-			targetID:  goframework.NumberUUID(10),
-			target:    "target",
-			expectErr: bunovel.ErrNotFound,
+			name:     "Success/NoVotes",
+			targetID: goframework.NumberUUID(10),
+			target:   "target",
+			expect: &dao.VotesSummaryModel{
+				Target:   "target",
+				TargetID: goframework.NumberUUID(10),
+				Counts:   map[models.VoteValue]int{},
+				Ratings:  map[int]int{},
+			},
 		},
 	}
 
@@ -173,6 +268,95 @@ func TestVotesRepository_GetSummary(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestVotesRepository_GetSummaries(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VotesSummaryModel{
+		{
+			TargetID:  goframework.NumberUUID(1),
+			Target:    "target",
+			UpVotes:   2,
+			DownVotes: 1,
+			Counts:    map[models.VoteValue]int{models.VoteValueUp: 2, models.VoteValueDown: 1},
+			Ratings:   map[int]int{},
+		},
+		{
+			TargetID: goframework.NumberUUID(2),
+			Target:   "target",
+			UpVotes:  1,
+			Counts:   map[models.VoteValue]int{models.VoteValueUp: 1},
+			Ratings:  map[int]int{},
+		},
+		// Another target.
+		{
+			TargetID: goframework.NumberUUID(1),
+			Target:   "other-target",
+			UpVotes:  1,
+			Counts:   map[models.VoteValue]int{models.VoteValueUp: 1},
+			Ratings:  map[int]int{},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		target    string
+		targetIDs []uuid.UUID
+
+		expect    []*dao.VotesSummaryModel
+		expectErr error
+	}{
+		{
+			name:      "Success",
+			target:    "target",
+			targetIDs: []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2), goframework.NumberUUID(3)},
+			expect: []*dao.VotesSummaryModel{
+				{
+					TargetID:  goframework.NumberUUID(1),
+					Target:    "target",
+					UpVotes:   2,
+					DownVotes: 1,
+					Counts:    map[models.VoteValue]int{models.VoteValueUp: 2, models.VoteValueDown: 1},
+					Ratings:   map[int]int{},
+				},
+				{
+					TargetID: goframework.NumberUUID(2),
+					Target:   "target",
+					UpVotes:  1,
+					Counts:   map[models.VoteValue]int{models.VoteValueUp: 1},
+					Ratings:  map[int]int{},
+				},
+			},
+		},
+		{
+			name:      "Success/NoResults",
+			target:    "target",
+			targetIDs: []uuid.UUID{goframework.NumberUUID(3)},
+			expect:    []*dao.VotesSummaryModel{},
+		},
+		{
+			name:   "Success/NoTargets",
+			target: "target",
+			expect: []*dao.VotesSummaryModel{},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVotesRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.GetSummaries(ctx, d.target, d.targetIDs)
+				require.ErrorIs(t, err, d.expectErr)
+				require.ElementsMatch(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
 func TestVotesRepository_ListUserVotes(t *testing.T) {
 	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
 	defer db.Close()
@@ -211,15 +395,22 @@ func TestVotesRepository_ListUserVotes(t *testing.T) {
 			TargetID: goframework.NumberUUID(1),
 			Target:   "other-target",
 		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(6), baseTime.Add(10*time.Minute), nil),
+			Vote:     models.VoteValueDown,
+			UserID:   goframework.NumberUUID(2),
+			TargetID: goframework.NumberUUID(2),
+			Target:   "other-target",
+		},
 	}
 
 	data := []struct {
 		name string
 
 		userID uuid.UUID
-		target string
+		filter dao.UserVotesFilter
+		cursor *dao.VotesCursor
 		limit  int
-		offset int
 
 		expect    []*dao.VoteModel
 		expectErr error
@@ -227,7 +418,7 @@ func TestVotesRepository_ListUserVotes(t *testing.T) {
 		{
 			name:   "Success",
 			userID: goframework.NumberUUID(2),
-			target: "target",
+			filter: dao.UserVotesFilter{Targets: []string{"target"}},
 			expect: []*dao.VoteModel{
 				{
 					Metadata: bunovel.NewMetadata(goframework.NumberUUID(4), baseTime, lo.ToPtr(updateTime.Add(time.Hour))),
@@ -248,7 +439,7 @@ func TestVotesRepository_ListUserVotes(t *testing.T) {
 		{
 			name:   "Success/Limit",
 			userID: goframework.NumberUUID(2),
-			target: "target",
+			filter: dao.UserVotesFilter{Targets: []string{"target"}},
 			limit:  1,
 			expect: []*dao.VoteModel{
 				{
@@ -261,10 +452,136 @@ func TestVotesRepository_ListUserVotes(t *testing.T) {
 			},
 		},
 		{
-			name:   "Success/Offset",
+			name:   "Success/Cursor",
 			userID: goframework.NumberUUID(2),
-			target: "target",
-			offset: 1,
+			filter: dao.UserVotesFilter{Targets: []string{"target"}},
+			cursor: &dao.VotesCursor{
+				ActivityAt: updateTime.Add(time.Hour),
+				ID:         goframework.NumberUUID(4),
+			},
+			expect: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime.Add(30*time.Minute), nil),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+			},
+		},
+		// Votes with the same activity date are ordered by ID.
+		{
+			name:   "Success/CursorTie",
+			userID: goframework.NumberUUID(2),
+			filter: dao.UserVotesFilter{Targets: []string{"target"}},
+			cursor: &dao.VotesCursor{
+				ActivityAt: baseTime.Add(30 * time.Minute),
+				ID:         goframework.NumberUUID(3),
+			},
+			expect: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime.Add(30*time.Minute), nil),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+			},
+		},
+		{
+			name:   "Success/EndOfList",
+			userID: goframework.NumberUUID(2),
+			filter: dao.UserVotesFilter{Targets: []string{"target"}},
+			cursor: &dao.VotesCursor{
+				ActivityAt: baseTime.Add(30 * time.Minute),
+				ID:         goframework.NumberUUID(2),
+			},
+			expect: []*dao.VoteModel{},
+		},
+		{
+			name:   "Success/AllTargets",
+			userID: goframework.NumberUUID(2),
+			expect: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(4), baseTime, lo.ToPtr(updateTime.Add(time.Hour))),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(2),
+					Target:   "target",
+				},
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime.Add(30*time.Minute), nil),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(6), baseTime.Add(10*time.Minute), nil),
+					Vote:     models.VoteValueDown,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(2),
+					Target:   "other-target",
+				},
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(5), baseTime, nil),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "other-target",
+				},
+			},
+		},
+		{
+			name:   "Success/MultipleTargets",
+			userID: goframework.NumberUUID(2),
+			filter: dao.UserVotesFilter{Targets: []string{"target", "other-target"}},
+			limit:  3,
+			expect: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(4), baseTime, lo.ToPtr(updateTime.Add(time.Hour))),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(2),
+					Target:   "target",
+				},
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime.Add(30*time.Minute), nil),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(6), baseTime.Add(10*time.Minute), nil),
+					Vote:     models.VoteValueDown,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(2),
+					Target:   "other-target",
+				},
+			},
+		},
+		{
+			name:   "Success/Vote",
+			userID: goframework.NumberUUID(2),
+			filter: dao.UserVotesFilter{Vote: lo.ToPtr(models.VoteValueDown)},
+			expect: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(6), baseTime.Add(10*time.Minute), nil),
+					Vote:     models.VoteValueDown,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(2),
+					Target:   "other-target",
+				},
+			},
+		},
+		{
+			name:   "Success/DateRange",
+			userID: goframework.NumberUUID(2),
+			filter: dao.UserVotesFilter{
+				Since: lo.ToPtr(baseTime.Add(5 * time.Minute)),
+				Until: lo.ToPtr(baseTime.Add(30 * time.Minute)),
+			},
 			expect: []*dao.VoteModel{
 				{
 					Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime.Add(30*time.Minute), nil),
@@ -273,12 +590,19 @@ func TestVotesRepository_ListUserVotes(t *testing.T) {
 					TargetID: goframework.NumberUUID(1),
 					Target:   "target",
 				},
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(6), baseTime.Add(10*time.Minute), nil),
+					Vote:     models.VoteValueDown,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(2),
+					Target:   "other-target",
+				},
 			},
 		},
 		{
 			name:   "Success/NoResults",
 			userID: goframework.NumberUUID(10),
-			target: "target",
+			filter: dao.UserVotesFilter{Targets: []string{"target"}},
 			expect: []*dao.VoteModel{},
 		},
 	}
@@ -288,7 +612,7 @@ func TestVotesRepository_ListUserVotes(t *testing.T) {
 
 		for _, d := range data {
 			t.Run(d.name, func(st *testing.T) {
-				res, err := repository.ListUserVotes(ctx, d.userID, d.target, d.limit, d.offset)
+				res, err := repository.ListUserVotes(ctx, d.userID, d.filter, d.cursor, d.limit)
 				require.ErrorIs(t, err, d.expectErr)
 				require.Equal(t, d.expect, res)
 			})
@@ -297,7 +621,7 @@ func TestVotesRepository_ListUserVotes(t *testing.T) {
 	require.NoError(t, err)
 }
 
-func TestVotesRepository_Cast(t *testing.T) {
+func TestVotesRepository_ListTargetVotes(t *testing.T) {
 	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
 	defer db.Close()
 	defer sqlDB.Close()
@@ -310,80 +634,2344 @@ func TestVotesRepository_Cast(t *testing.T) {
 			TargetID: goframework.NumberUUID(1),
 			Target:   "target",
 		},
-	}
-
-	data := []struct {
-		name string
-
-		userID   uuid.UUID
-		targetID uuid.UUID
-		target   string
-		vote     *models.VoteValue
-		id       uuid.UUID
-		now      time.Time
-
-		expect    *dao.VoteModel
-		expectErr error
-	}{
 		{
-			name:     "Success",
-			userID:   goframework.NumberUUID(2),
-			targetID: goframework.NumberUUID(1),
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime.Add(30*time.Minute), nil),
+			Vote:     models.VoteValueDown,
+			UserID:   goframework.NumberUUID(2),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, lo.ToPtr(updateTime)),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(3),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+		},
+
+		// Discarded by a moderator.
+		{
+			Metadata:      bunovel.NewMetadata(goframework.NumberUUID(6), baseTime.Add(-time.Hour), nil),
+			Vote:          models.VoteValueDown,
+			UserID:        goframework.NumberUUID(4),
+			TargetID:      goframework.NumberUUID(1),
+			Target:        "target",
+			InvalidatedAt: lo.ToPtr(updateTime),
+		},
+
+		// Another target id.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(4), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(2),
+			Target:   "target",
+		},
+
+		// Another target.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(5), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "other-target",
+		},
+	}
+
+	data := []struct {
+		name string
+
+		targetID uuid.UUID
+		target   string
+		filter   dao.TargetVotesFilter
+		cursor   *dao.VotesCursor
+		limit    int
+
+		expect    []*dao.VoteModel
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			targetID: goframework.NumberUUID(1),
 			target:   "target",
-			vote:     lo.ToPtr(models.VoteValueDown),
-			id:       goframework.NumberUUID(2),
-			now:      updateTime,
-			expect: &dao.VoteModel{
-				Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), updateTime, nil),
-				Vote:     models.VoteValueDown,
-				UserID:   goframework.NumberUUID(2),
-				TargetID: goframework.NumberUUID(1),
-				Target:   "target",
+			expect: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, lo.ToPtr(updateTime)),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(3),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime.Add(30*time.Minute), nil),
+					Vote:     models.VoteValueDown,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(1),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
 			},
 		},
 		{
-			name:     "Success/Update",
-			userID:   goframework.NumberUUID(1),
+			name:     "Success/Vote",
 			targetID: goframework.NumberUUID(1),
 			target:   "target",
-			vote:     lo.ToPtr(models.VoteValueDown),
-			id:       goframework.NumberUUID(2),
-			now:      updateTime,
-			expect: &dao.VoteModel{
-				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &updateTime),
-				Vote:     models.VoteValueDown,
-				UserID:   goframework.NumberUUID(1),
-				TargetID: goframework.NumberUUID(1),
-				Target:   "target",
+			filter:   dao.TargetVotesFilter{Vote: lo.ToPtr(models.VoteValueUp)},
+			expect: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, lo.ToPtr(updateTime)),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(3),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(1),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
 			},
 		},
 		{
-			name:     "Success/Delete",
-			userID:   goframework.NumberUUID(1),
+			name:     "Success/IncludeInvalidated",
 			targetID: goframework.NumberUUID(1),
 			target:   "target",
-			id:       goframework.NumberUUID(2),
-			now:      updateTime,
+			filter:   dao.TargetVotesFilter{Vote: lo.ToPtr(models.VoteValueDown), IncludeInvalidated: true},
+			expect: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime.Add(30*time.Minute), nil),
+					Vote:     models.VoteValueDown,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+				{
+					Metadata:      bunovel.NewMetadata(goframework.NumberUUID(6), baseTime.Add(-time.Hour), nil),
+					Vote:          models.VoteValueDown,
+					UserID:        goframework.NumberUUID(4),
+					TargetID:      goframework.NumberUUID(1),
+					Target:        "target",
+					InvalidatedAt: lo.ToPtr(updateTime),
+				},
+			},
 		},
 		{
-			name:     "Success/DeleteMissing",
-			userID:   goframework.NumberUUID(2),
+			name:     "Success/Limit",
 			targetID: goframework.NumberUUID(1),
 			target:   "target",
-			id:       goframework.NumberUUID(2),
-			now:      updateTime,
+			limit:    1,
+			expect: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, lo.ToPtr(updateTime)),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(3),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+			},
+		},
+		{
+			name:     "Success/Cursor",
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			cursor: &dao.VotesCursor{
+				ActivityAt: baseTime.Add(30 * time.Minute),
+				ID:         goframework.NumberUUID(2),
+			},
+			expect: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(1),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+			},
+		},
+		{
+			name:     "Success/NoResults",
+			targetID: goframework.NumberUUID(10),
+			target:   "target",
+			expect:   []*dao.VoteModel{},
 		},
 	}
 
-	for _, d := range data {
-		t.Run(d.name, func(st *testing.T) {
-			err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
-				repository := dao.NewVotesRepository(tx)
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVotesRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.ListTargetVotes(ctx, d.targetID, d.target, d.filter, d.cursor, d.limit)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestVotesRepository_CountTargetVotes(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VoteModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+			Vote:     models.VoteValueDown,
+			UserID:   goframework.NumberUUID(2),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+		},
+		// Discarded by a moderator.
+		{
+			Metadata:      bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, nil),
+			Vote:          models.VoteValueUp,
+			UserID:        goframework.NumberUUID(3),
+			TargetID:      goframework.NumberUUID(1),
+			Target:        "target",
+			InvalidatedAt: lo.ToPtr(updateTime),
+		},
+		// Another target.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(4), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "other-target",
+		},
+	}
+
+	data := []struct {
+		name string
+
+		targetID uuid.UUID
+		target   string
+		filter   dao.TargetVotesFilter
+
+		expect    int
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			expect:   2,
+		},
+		{
+			name:     "Success/Vote",
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			filter:   dao.TargetVotesFilter{Vote: lo.ToPtr(models.VoteValueUp)},
+			expect:   1,
+		},
+		{
+			name:     "Success/IncludeInvalidated",
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			filter:   dao.TargetVotesFilter{Vote: lo.ToPtr(models.VoteValueUp), IncludeInvalidated: true},
+			expect:   2,
+		},
+		{
+			name:     "Success/NoResults",
+			targetID: goframework.NumberUUID(10),
+			target:   "target",
+			expect:   0,
+		},
+	}
 
-				res, err := repository.Cast(ctx, d.userID, d.targetID, d.target, d.vote, d.id, d.now)
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVotesRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.CountTargetVotes(ctx, d.targetID, d.target, d.filter)
 				require.ErrorIs(t, err, d.expectErr)
 				require.Equal(t, d.expect, res)
 			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestVotesRepository_CountUserVotes(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VoteModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, lo.ToPtr(updateTime)),
+			Vote:     models.VoteValueDown,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(2),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "other-target",
+		},
+		// Another user.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(4), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(2),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+		},
+	}
+
+	data := []struct {
+		name string
+
+		userID uuid.UUID
+		filter dao.UserVotesFilter
+
+		expect    int
+		expectErr error
+	}{
+		{
+			name:   "Success",
+			userID: goframework.NumberUUID(1),
+			expect: 3,
+		},
+		{
+			name:   "Success/Targets",
+			userID: goframework.NumberUUID(1),
+			filter: dao.UserVotesFilter{Targets: []string{"target"}},
+			expect: 2,
+		},
+		{
+			name:   "Success/Vote",
+			userID: goframework.NumberUUID(1),
+			filter: dao.UserVotesFilter{Vote: lo.ToPtr(models.VoteValueUp)},
+			expect: 2,
+		},
+		{
+			name:   "Success/DateRange",
+			userID: goframework.NumberUUID(1),
+			filter: dao.UserVotesFilter{Since: lo.ToPtr(baseTime.Add(time.Minute))},
+			expect: 1,
+		},
+		{
+			name:   "Success/NoResults",
+			userID: goframework.NumberUUID(10),
+			expect: 0,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVotesRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.CountUserVotes(ctx, d.userID, d.filter)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestVotesRepository_ListHotTargets(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VoteModel{
+		// Old target, many votes.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(2),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+		},
+		// Recent target, a single vote.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, lo.ToPtr(updateTime.Add(23*time.Hour))),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(2),
+			Target:   "target",
+		},
+		// Downvoted target.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(4), updateTime, nil),
+			Vote:     models.VoteValueDown,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(3),
+			Target:   "target",
+		},
+		// Outside of the window.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(5), baseTime.Add(-48*time.Hour), nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(4),
+			Target:   "target",
+		},
+		// Another target.
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(6), updateTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "other-target",
+		},
+	}
+
+	now := updateTime.Add(24 * time.Hour)
+	since := baseTime.Add(-time.Hour)
+
+	data := []struct {
+		name string
+
+		target  string
+		gravity float64
+		limit   int
+		offset  int
+
+		expect    []uuid.UUID
+		expectErr error
+	}{
+		{
+			name:    "Success",
+			target:  "target",
+			gravity: 1.8,
+			limit:   10,
+			expect:  []uuid.UUID{goframework.NumberUUID(2), goframework.NumberUUID(1), goframework.NumberUUID(3)},
+		},
+		{
+			name:    "Success/NoGravity",
+			target:  "target",
+			gravity: 0,
+			limit:   10,
+			expect:  []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2), goframework.NumberUUID(3)},
+		},
+		{
+			name:    "Success/Pagination",
+			target:  "target",
+			gravity: 1.8,
+			limit:   1,
+			offset:  1,
+			expect:  []uuid.UUID{goframework.NumberUUID(1)},
+		},
+		{
+			name:    "Success/NoResults",
+			target:  "fake-target",
+			gravity: 1.8,
+			limit:   10,
+			expect:  []uuid.UUID{},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVotesRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.ListHotTargets(ctx, d.target, d.gravity, since, now, d.limit, d.offset)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, lo.Map(res, func(item *dao.TargetScoreModel, _ int) uuid.UUID {
+					return item.TargetID
+				}))
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestVotesRepository_ListTopRatedTargets(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VotesSummaryModel{
+		// Best average, a single rating.
+		{
+			TargetID:    goframework.NumberUUID(1),
+			Target:      "story",
+			Counts:      map[models.VoteValue]int{models.VoteValueRating: 1},
+			Ratings:     map[int]int{5: 1},
+			RatingCount: 1,
+			RatingSum:   5,
+		},
+		// Good average, many ratings.
+		{
+			TargetID:    goframework.NumberUUID(2),
+			Target:      "story",
+			Counts:      map[models.VoteValue]int{models.VoteValueRating: 10},
+			Ratings:     map[int]int{4: 5, 5: 5},
+			RatingCount: 10,
+			RatingSum:   45,
+		},
+		// Bad average.
+		{
+			TargetID:    goframework.NumberUUID(3),
+			Target:      "story",
+			Counts:      map[models.VoteValue]int{models.VoteValueRating: 4},
+			Ratings:     map[int]int{2: 4},
+			RatingCount: 4,
+			RatingSum:   8,
+		},
+		// Not rated.
+		{
+			TargetID:        goframework.NumberUUID(4),
+			Target:          "story",
+			UpVotes:         1,
+			WeightedUpVotes: 1,
+			Counts:          map[models.VoteValue]int{models.VoteValueUp: 1},
+			Ratings:         map[int]int{},
+		},
+		// Another target.
+		{
+			TargetID:    goframework.NumberUUID(5),
+			Target:      "other-story",
+			Counts:      map[models.VoteValue]int{models.VoteValueRating: 1},
+			Ratings:     map[int]int{1: 1},
+			RatingCount: 1,
+			RatingSum:   1,
+		},
+	}
+
+	data := []struct {
+		name string
+
+		target      string
+		priorWeight float64
+		limit       int
+		offset      int
+
+		expect       []uuid.UUID
+		expectScores []float64
+		expectErr    error
+	}{
+		{
+			name:         "Success",
+			target:       "story",
+			limit:        10,
+			expect:       []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2), goframework.NumberUUID(3)},
+			expectScores: []float64{5, 4.5, 2},
+		},
+		// The prior is the average of all the ratings of the stories: 58 / 15.
+		{
+			name:         "Success/Bayesian",
+			target:       "story",
+			priorWeight:  10,
+			limit:        10,
+			expect:       []uuid.UUID{goframework.NumberUUID(2), goframework.NumberUUID(1), goframework.NumberUUID(3)},
+			expectScores: []float64{(580.0/15 + 45) / 20, (580.0/15 + 5) / 11, (580.0/15 + 8) / 14},
+		},
+		{
+			name:         "Success/Pagination",
+			target:       "story",
+			priorWeight:  10,
+			limit:        1,
+			offset:       1,
+			expect:       []uuid.UUID{goframework.NumberUUID(1)},
+			expectScores: []float64{(580.0/15 + 5) / 11},
+		},
+		{
+			name:         "Success/NoResults",
+			target:       "fake-story",
+			limit:        10,
+			expect:       []uuid.UUID{},
+			expectScores: []float64{},
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVotesRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.ListTopRatedTargets(ctx, d.target, d.priorWeight, d.limit, d.offset)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, lo.Map(res, func(item *dao.TargetScoreModel, _ int) uuid.UUID {
+					return item.TargetID
+				}))
+				require.Len(t, res, len(d.expectScores))
+				for i, score := range d.expectScores {
+					require.InDelta(t, score, res[i].Score, 1e-9)
+				}
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestVotesRepository_Cast(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VoteModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+			Weight:   1,
+		},
+		// Invalidated, so it does not count in the summary.
+		{
+			Metadata:           bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, nil),
+			Vote:               models.VoteValueDown,
+			UserID:             goframework.NumberUUID(3),
+			TargetID:           goframework.NumberUUID(1),
+			Target:             "target",
+			Weight:             1,
+			InvalidatedAt:      lo.ToPtr(baseTime),
+			InvalidatedBy:      lo.ToPtr(goframework.NumberUUID(100)),
+			InvalidationReason: lo.ToPtr("vote ring"),
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(4), baseTime, nil),
+			Vote:     models.VoteValueRating,
+			UserID:   goframework.NumberUUID(4),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "story",
+			Weight:   1,
+			Score:    lo.ToPtr(3),
+		},
+	}
+
+	data := []struct {
+		name string
+
+		userID       uuid.UUID
+		targetID     uuid.UUID
+		target       string
+		vote         *models.VoteValue
+		expectedVote *models.VoteValue
+		score        *int
+		weight       int
+		id           uuid.UUID
+		now          time.Time
+
+		expect         *dao.VoteModel
+		expectSummary  *dao.VotesSummaryModel
+		expectEvent    *dao.VoteEventModel
+		expectConflict *models.VoteConflictError
+		expectErr      error
+	}{
+		{
+			name:     "Success",
+			userID:   goframework.NumberUUID(2),
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			vote:     lo.ToPtr(models.VoteValueDown),
+			weight:   1,
+			id:       goframework.NumberUUID(2),
+			now:      updateTime,
+			expect: &dao.VoteModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), updateTime, nil),
+				Vote:     models.VoteValueDown,
+				UserID:   goframework.NumberUUID(2),
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Weight:   1,
+			},
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID:          goframework.NumberUUID(1),
+				Target:            "target",
+				UpVotes:           1,
+				DownVotes:         1,
+				WeightedUpVotes:   1,
+				WeightedDownVotes: 1,
+				Counts:            map[models.VoteValue]int{models.VoteValueUp: 1, models.VoteValueDown: 1},
+				Ratings:           map[int]int{},
+			},
+			expectEvent: &dao.VoteEventModel{
+				CreatedAt: updateTime,
+				Event:     models.VoteEventTypeCast,
+				UserID:    goframework.NumberUUID(2),
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				NewVote:   lo.ToPtr(models.VoteValueDown),
+			},
+		},
+		{
+			name:     "Success/NewTarget",
+			userID:   goframework.NumberUUID(1),
+			targetID: goframework.NumberUUID(2),
+			target:   "target",
+			vote:     lo.ToPtr(models.VoteValueUp),
+			weight:   1,
+			id:       goframework.NumberUUID(2),
+			now:      updateTime,
+			expect: &dao.VoteModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), updateTime, nil),
+				Vote:     models.VoteValueUp,
+				UserID:   goframework.NumberUUID(1),
+				TargetID: goframework.NumberUUID(2),
+				Target:   "target",
+				Weight:   1,
+			},
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID:        goframework.NumberUUID(2),
+				Target:          "target",
+				UpVotes:         1,
+				WeightedUpVotes: 1,
+				Counts:          map[models.VoteValue]int{models.VoteValueUp: 1},
+				Ratings:         map[int]int{},
+			},
+			expectEvent: &dao.VoteEventModel{
+				CreatedAt: updateTime,
+				Event:     models.VoteEventTypeCast,
+				UserID:    goframework.NumberUUID(1),
+				TargetID:  goframework.NumberUUID(2),
+				Target:    "target",
+				NewVote:   lo.ToPtr(models.VoteValueUp),
+			},
+		},
+		// Values other than up and down are only counted per value.
+		{
+			name:     "Success/Reaction",
+			userID:   goframework.NumberUUID(1),
+			targetID: goframework.NumberUUID(2),
+			target:   "target",
+			vote:     lo.ToPtr(models.VoteValue("funny")),
+			weight:   1,
+			id:       goframework.NumberUUID(2),
+			now:      updateTime,
+			expect: &dao.VoteModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), updateTime, nil),
+				Vote:     "funny",
+				UserID:   goframework.NumberUUID(1),
+				TargetID: goframework.NumberUUID(2),
+				Target:   "target",
+				Weight:   1,
+			},
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID: goframework.NumberUUID(2),
+				Target:   "target",
+				Counts:   map[models.VoteValue]int{"funny": 1},
+				Ratings:  map[int]int{},
+			},
+			expectEvent: &dao.VoteEventModel{
+				CreatedAt: updateTime,
+				Event:     models.VoteEventTypeCast,
+				UserID:    goframework.NumberUUID(1),
+				TargetID:  goframework.NumberUUID(2),
+				Target:    "target",
+				NewVote:   lo.ToPtr(models.VoteValue("funny")),
+			},
+		},
+		{
+			name:     "Success/Rating",
+			userID:   goframework.NumberUUID(2),
+			targetID: goframework.NumberUUID(1),
+			target:   "story",
+			vote:     lo.ToPtr(models.VoteValueRating),
+			score:    lo.ToPtr(5),
+			weight:   1,
+			id:       goframework.NumberUUID(2),
+			now:      updateTime,
+			expect: &dao.VoteModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), updateTime, nil),
+				Vote:     models.VoteValueRating,
+				UserID:   goframework.NumberUUID(2),
+				TargetID: goframework.NumberUUID(1),
+				Target:   "story",
+				Weight:   1,
+				Score:    lo.ToPtr(5),
+			},
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID:    goframework.NumberUUID(1),
+				Target:      "story",
+				Counts:      map[models.VoteValue]int{models.VoteValueRating: 2},
+				Ratings:     map[int]int{3: 1, 5: 1},
+				RatingCount: 2,
+				RatingSum:   8,
+			},
+			expectEvent: &dao.VoteEventModel{
+				CreatedAt: updateTime,
+				Event:     models.VoteEventTypeCast,
+				UserID:    goframework.NumberUUID(2),
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "story",
+				NewVote:   lo.ToPtr(models.VoteValueRating),
+				NewScore:  lo.ToPtr(5),
+			},
+		},
+		// Changing the score of a rating is recorded as a flip.
+		{
+			name:     "Success/UpdateRating",
+			userID:   goframework.NumberUUID(4),
+			targetID: goframework.NumberUUID(1),
+			target:   "story",
+			vote:     lo.ToPtr(models.VoteValueRating),
+			score:    lo.ToPtr(5),
+			weight:   1,
+			id:       goframework.NumberUUID(2),
+			now:      updateTime,
+			expect: &dao.VoteModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(4), baseTime, &updateTime),
+				Vote:     models.VoteValueRating,
+				UserID:   goframework.NumberUUID(4),
+				TargetID: goframework.NumberUUID(1),
+				Target:   "story",
+				Weight:   1,
+				Score:    lo.ToPtr(5),
+			},
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID:    goframework.NumberUUID(1),
+				Target:      "story",
+				Counts:      map[models.VoteValue]int{models.VoteValueRating: 1},
+				Ratings:     map[int]int{5: 1},
+				RatingCount: 1,
+				RatingSum:   5,
+			},
+			expectEvent: &dao.VoteEventModel{
+				CreatedAt: updateTime,
+				Event:     models.VoteEventTypeFlip,
+				UserID:    goframework.NumberUUID(4),
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "story",
+				OldVote:   lo.ToPtr(models.VoteValueRating),
+				NewVote:   lo.ToPtr(models.VoteValueRating),
+				OldScore:  lo.ToPtr(3),
+				NewScore:  lo.ToPtr(5),
+			},
+		},
+		{
+			name:     "Success/Update",
+			userID:   goframework.NumberUUID(1),
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			vote:     lo.ToPtr(models.VoteValueDown),
+			weight:   1,
+			id:       goframework.NumberUUID(2),
+			now:      updateTime,
+			expect: &dao.VoteModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &updateTime),
+				Vote:     models.VoteValueDown,
+				UserID:   goframework.NumberUUID(1),
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Weight:   1,
+			},
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID:          goframework.NumberUUID(1),
+				Target:            "target",
+				DownVotes:         1,
+				WeightedDownVotes: 1,
+				Counts:            map[models.VoteValue]int{models.VoteValueDown: 1},
+				Ratings:           map[int]int{},
+			},
+			expectEvent: &dao.VoteEventModel{
+				CreatedAt: updateTime,
+				Event:     models.VoteEventTypeFlip,
+				UserID:    goframework.NumberUUID(1),
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				OldVote:   lo.ToPtr(models.VoteValueUp),
+				NewVote:   lo.ToPtr(models.VoteValueDown),
+			},
+		},
+		{
+			name:     "Success/UpdateSameValue",
+			userID:   goframework.NumberUUID(1),
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			vote:     lo.ToPtr(models.VoteValueUp),
+			weight:   1,
+			id:       goframework.NumberUUID(2),
+			now:      updateTime,
+			expect: &dao.VoteModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &updateTime),
+				Vote:     models.VoteValueUp,
+				UserID:   goframework.NumberUUID(1),
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Weight:   1,
+			},
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID:        goframework.NumberUUID(1),
+				Target:          "target",
+				UpVotes:         1,
+				WeightedUpVotes: 1,
+				Counts:          map[models.VoteValue]int{models.VoteValueUp: 1},
+				Ratings:         map[int]int{},
+			},
+		},
+		{
+			name:         "Success/ExpectedVote",
+			userID:       goframework.NumberUUID(1),
+			targetID:     goframework.NumberUUID(1),
+			target:       "target",
+			vote:         lo.ToPtr(models.VoteValueDown),
+			weight:       1,
+			expectedVote: lo.ToPtr(models.VoteValueUp),
+			id:           goframework.NumberUUID(2),
+			now:          updateTime,
+			expect: &dao.VoteModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &updateTime),
+				Vote:     models.VoteValueDown,
+				UserID:   goframework.NumberUUID(1),
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Weight:   1,
+			},
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID:          goframework.NumberUUID(1),
+				Target:            "target",
+				DownVotes:         1,
+				WeightedDownVotes: 1,
+				Counts:            map[models.VoteValue]int{models.VoteValueDown: 1},
+				Ratings:           map[int]int{},
+			},
+			expectEvent: &dao.VoteEventModel{
+				CreatedAt: updateTime,
+				Event:     models.VoteEventTypeFlip,
+				UserID:    goframework.NumberUUID(1),
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				OldVote:   lo.ToPtr(models.VoteValueUp),
+				NewVote:   lo.ToPtr(models.VoteValueDown),
+			},
+		},
+		{
+			name:         "Success/ExpectedNoVote",
+			userID:       goframework.NumberUUID(2),
+			targetID:     goframework.NumberUUID(1),
+			target:       "target",
+			vote:         lo.ToPtr(models.VoteValueDown),
+			weight:       1,
+			expectedVote: lo.ToPtr(models.VoteValue("")),
+			id:           goframework.NumberUUID(2),
+			now:          updateTime,
+			expect: &dao.VoteModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), updateTime, nil),
+				Vote:     models.VoteValueDown,
+				UserID:   goframework.NumberUUID(2),
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Weight:   1,
+			},
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID:          goframework.NumberUUID(1),
+				Target:            "target",
+				UpVotes:           1,
+				DownVotes:         1,
+				WeightedUpVotes:   1,
+				WeightedDownVotes: 1,
+				Counts:            map[models.VoteValue]int{models.VoteValueUp: 1, models.VoteValueDown: 1},
+				Ratings:           map[int]int{},
+			},
+			expectEvent: &dao.VoteEventModel{
+				CreatedAt: updateTime,
+				Event:     models.VoteEventTypeCast,
+				UserID:    goframework.NumberUUID(2),
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				NewVote:   lo.ToPtr(models.VoteValueDown),
+			},
+		},
+		{
+			name:           "Error/ExpectedVoteConflict",
+			userID:         goframework.NumberUUID(1),
+			targetID:       goframework.NumberUUID(1),
+			target:         "target",
+			expectedVote:   lo.ToPtr(models.VoteValueDown),
+			id:             goframework.NumberUUID(2),
+			now:            updateTime,
+			expectConflict: &models.VoteConflictError{CurrentVote: lo.ToPtr(models.VoteValueUp)},
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID:        goframework.NumberUUID(1),
+				Target:          "target",
+				UpVotes:         1,
+				WeightedUpVotes: 1,
+				Counts:          map[models.VoteValue]int{models.VoteValueUp: 1},
+				Ratings:         map[int]int{},
+			},
+		},
+		{
+			name:           "Error/ExpectedVoteConflictNoVote",
+			userID:         goframework.NumberUUID(2),
+			targetID:       goframework.NumberUUID(1),
+			target:         "target",
+			vote:           lo.ToPtr(models.VoteValueDown),
+			weight:         1,
+			expectedVote:   lo.ToPtr(models.VoteValueUp),
+			id:             goframework.NumberUUID(2),
+			now:            updateTime,
+			expectConflict: &models.VoteConflictError{},
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID:        goframework.NumberUUID(1),
+				Target:          "target",
+				UpVotes:         1,
+				WeightedUpVotes: 1,
+				Counts:          map[models.VoteValue]int{models.VoteValueUp: 1},
+				Ratings:         map[int]int{},
+			},
+		},
+		{
+			name:     "Success/Delete",
+			userID:   goframework.NumberUUID(1),
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			id:       goframework.NumberUUID(2),
+			now:      updateTime,
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Counts:   map[models.VoteValue]int{},
+				Ratings:  map[int]int{},
+			},
+			expectEvent: &dao.VoteEventModel{
+				CreatedAt: updateTime,
+				Event:     models.VoteEventTypeRetract,
+				UserID:    goframework.NumberUUID(1),
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				OldVote:   lo.ToPtr(models.VoteValueUp),
+			},
+		},
+		{
+			name:     "Success/DeleteMissing",
+			userID:   goframework.NumberUUID(2),
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			id:       goframework.NumberUUID(2),
+			now:      updateTime,
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID:        goframework.NumberUUID(1),
+				Target:          "target",
+				UpVotes:         1,
+				WeightedUpVotes: 1,
+				Counts:          map[models.VoteValue]int{models.VoteValueUp: 1},
+				Ratings:         map[int]int{},
+			},
+		},
+		{
+			name:     "Success/DeleteOnTargetWithoutVotes",
+			userID:   goframework.NumberUUID(2),
+			targetID: goframework.NumberUUID(3),
+			target:   "target",
+			id:       goframework.NumberUUID(2),
+			now:      updateTime,
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID: goframework.NumberUUID(3),
+				Target:   "target",
+				Counts:   map[models.VoteValue]int{},
+				Ratings:  map[int]int{},
+			},
+		},
+		{
+			name:     "Success/Weighted",
+			userID:   goframework.NumberUUID(2),
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			vote:     lo.ToPtr(models.VoteValueUp),
+			weight:   3,
+			id:       goframework.NumberUUID(2),
+			now:      updateTime,
+			expect: &dao.VoteModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), updateTime, nil),
+				Vote:     models.VoteValueUp,
+				UserID:   goframework.NumberUUID(2),
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Weight:   3,
+			},
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID:        goframework.NumberUUID(1),
+				Target:          "target",
+				UpVotes:         2,
+				WeightedUpVotes: 4,
+				Counts:          map[models.VoteValue]int{models.VoteValueUp: 2},
+				Ratings:         map[int]int{},
+			},
+			expectEvent: &dao.VoteEventModel{
+				CreatedAt: updateTime,
+				Event:     models.VoteEventTypeCast,
+				UserID:    goframework.NumberUUID(2),
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				NewVote:   lo.ToPtr(models.VoteValueUp),
+			},
+		},
+		// The weight of the voter changed since the last cast.
+		{
+			name:     "Success/UpdateWeight",
+			userID:   goframework.NumberUUID(1),
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			vote:     lo.ToPtr(models.VoteValueUp),
+			weight:   2,
+			id:       goframework.NumberUUID(2),
+			now:      updateTime,
+			expect: &dao.VoteModel{
+				Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, &updateTime),
+				Vote:     models.VoteValueUp,
+				UserID:   goframework.NumberUUID(1),
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Weight:   2,
+			},
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID:        goframework.NumberUUID(1),
+				Target:          "target",
+				UpVotes:         1,
+				WeightedUpVotes: 2,
+				Counts:          map[models.VoteValue]int{models.VoteValueUp: 1},
+				Ratings:         map[int]int{},
+			},
+		},
+		{
+			name:     "Success/UpdateInvalidated",
+			userID:   goframework.NumberUUID(3),
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			vote:     lo.ToPtr(models.VoteValueUp),
+			weight:   1,
+			id:       goframework.NumberUUID(4),
+			now:      updateTime,
+			expect: &dao.VoteModel{
+				Metadata:           bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, &updateTime),
+				Vote:               models.VoteValueUp,
+				UserID:             goframework.NumberUUID(3),
+				TargetID:           goframework.NumberUUID(1),
+				Target:             "target",
+				Weight:             1,
+				InvalidatedAt:      lo.ToPtr(baseTime),
+				InvalidatedBy:      lo.ToPtr(goframework.NumberUUID(100)),
+				InvalidationReason: lo.ToPtr("vote ring"),
+			},
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID:        goframework.NumberUUID(1),
+				Target:          "target",
+				UpVotes:         1,
+				WeightedUpVotes: 1,
+				Counts:          map[models.VoteValue]int{models.VoteValueUp: 1},
+				Ratings:         map[int]int{},
+			},
+			expectEvent: &dao.VoteEventModel{
+				CreatedAt: updateTime,
+				Event:     models.VoteEventTypeFlip,
+				UserID:    goframework.NumberUUID(3),
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				OldVote:   lo.ToPtr(models.VoteValueDown),
+				NewVote:   lo.ToPtr(models.VoteValueUp),
+			},
+		},
+		{
+			name:      "Error/DeleteInvalidated",
+			userID:    goframework.NumberUUID(3),
+			targetID:  goframework.NumberUUID(1),
+			target:    "target",
+			id:        goframework.NumberUUID(4),
+			now:       updateTime,
+			expectErr: dao.ErrVoteInvalidated,
+			expectSummary: &dao.VotesSummaryModel{
+				TargetID:        goframework.NumberUUID(1),
+				Target:          "target",
+				UpVotes:         1,
+				WeightedUpVotes: 1,
+				Counts:          map[models.VoteValue]int{models.VoteValueUp: 1},
+				Ratings:         map[int]int{},
+			},
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(st *testing.T) {
+			err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+				repository := dao.NewVotesRepository(tx)
+
+				// Summaries of the fixtures.
+				_, err := tx.NewInsert().Model(&[]*dao.VotesSummaryModel{
+					{
+						TargetID:        goframework.NumberUUID(1),
+						Target:          "target",
+						UpVotes:         1,
+						WeightedUpVotes: 1,
+						Counts:          map[models.VoteValue]int{models.VoteValueUp: 1},
+						Ratings:         map[int]int{},
+					},
+					{
+						TargetID:    goframework.NumberUUID(1),
+						Target:      "story",
+						Counts:      map[models.VoteValue]int{models.VoteValueRating: 1},
+						Ratings:     map[int]int{3: 1},
+						RatingCount: 1,
+						RatingSum:   3,
+					},
+				}).Exec(ctx)
+				require.NoError(t, err)
+
+				res, err := repository.Cast(ctx, d.userID, d.targetID, d.target, d.vote, d.expectedVote, d.score, d.weight, d.id, d.now)
+				if d.expectConflict != nil {
+					var conflict *models.VoteConflictError
+					require.ErrorAs(t, err, &conflict)
+					require.Equal(t, d.expectConflict, conflict)
+				} else {
+					require.ErrorIs(t, err, d.expectErr)
+				}
+				require.Equal(t, d.expect, res)
+
+				if d.expectSummary != nil {
+					summary, err := repository.GetSummary(ctx, d.targetID, d.target)
+					require.NoError(t, err)
+					require.Equal(t, d.expectSummary, summary)
+				}
+
+				events, err := dao.NewVoteEventsRepository(tx).List(ctx, dao.VoteEventsFilter{UserID: &d.userID}, 10, 0)
+				require.NoError(t, err)
+				if d.expectEvent == nil {
+					require.Empty(t, events)
+				} else {
+					require.Len(t, events, 1)
+					// IDs are generated by the database.
+					events[0].ID = 0
+					require.Equal(t, d.expectEvent, events[0])
+				}
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+// A user whose vote was invalidated cannot get rid of the invalidation by retracting the vote, then voting again.
+func TestVotesRepository_CastAfterInvalidation(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VoteModel{
+		{
+			Metadata:           bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			Vote:               models.VoteValueUp,
+			UserID:             goframework.NumberUUID(1),
+			TargetID:           goframework.NumberUUID(1),
+			Target:             "target",
+			Weight:             1,
+			InvalidatedAt:      lo.ToPtr(baseTime),
+			InvalidatedBy:      lo.ToPtr(goframework.NumberUUID(100)),
+			InvalidationReason: lo.ToPtr("vote ring"),
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVotesRepository(tx)
+
+		_, err := repository.Cast(
+			ctx, goframework.NumberUUID(1), goframework.NumberUUID(1), "target", nil, nil, nil, 1, goframework.NumberUUID(2), updateTime,
+		)
+		require.ErrorIs(t, err, dao.ErrVoteInvalidated)
+
+		res, err := repository.Cast(
+			ctx, goframework.NumberUUID(1), goframework.NumberUUID(1), "target",
+			lo.ToPtr(models.VoteValueUp), nil, nil, 1, goframework.NumberUUID(3), updateTime,
+		)
+		require.NoError(t, err)
+		require.Equal(t, goframework.NumberUUID(1), res.ID)
+		require.Equal(t, lo.ToPtr(baseTime), res.InvalidatedAt)
+
+		vote, err := repository.Get(ctx, goframework.NumberUUID(1), goframework.NumberUUID(1), "target")
+		require.NoError(t, err)
+		require.Equal(t, lo.ToPtr(baseTime), vote.InvalidatedAt)
+		require.Equal(t, lo.ToPtr(goframework.NumberUUID(100)), vote.InvalidatedBy)
+
+		// The vote never counted in the summary.
+		summary, err := repository.GetSummary(ctx, goframework.NumberUUID(1), "target")
+		require.NoError(t, err)
+		require.Equal(t, &dao.VotesSummaryModel{
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+			Counts:   map[models.VoteValue]int{},
+			Ratings:  map[int]int{},
+		}, summary)
+	})
+	require.NoError(t, err)
+}
+
+func TestVotesRepository_CastConcurrently(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	ctx := context.Background()
+	repository := dao.NewVotesRepository(db)
+
+	// Casts run in their own transactions, so they can actually race. Clean up the votes they commit.
+	defer func() {
+		for _, model := range []interface{}{(*dao.VoteModel)(nil), (*dao.VoteEventModel)(nil), (*dao.VotesSummaryModel)(nil)} {
+			_, err := db.NewDelete().Model(model).Where("target = ?", "concurrent-target").Exec(ctx)
+			require.NoError(t, err)
+		}
+	}()
+
+	// The same first vote, sent twice at once (for example, from two tabs).
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = repository.Cast(
+				ctx, goframework.NumberUUID(1), goframework.NumberUUID(1), "concurrent-target",
+				lo.ToPtr(models.VoteValueUp), nil, nil, 1, goframework.NumberUUID(10+i), baseTime,
+			)
+		}(i)
+	}
+	wg.Wait()
+
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+
+	summary, err := repository.GetSummary(ctx, goframework.NumberUUID(1), "concurrent-target")
+	require.NoError(t, err)
+	require.Equal(t, &dao.VotesSummaryModel{
+		TargetID:        goframework.NumberUUID(1),
+		Target:          "concurrent-target",
+		UpVotes:         1,
+		WeightedUpVotes: 1,
+		Counts:          map[models.VoteValue]int{models.VoteValueUp: 1},
+		Ratings:         map[int]int{},
+	}, summary)
+}
+
+// TestVotesRepository_CastConcurrentlyWithExpectedVote sends two conditional first votes at once. The user had no
+// vote when both were sent, but only the first one to run can still expect it.
+func TestVotesRepository_CastConcurrentlyWithExpectedVote(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	ctx := context.Background()
+	repository := dao.NewVotesRepository(db)
+
+	defer func() {
+		for _, model := range []interface{}{(*dao.VoteModel)(nil), (*dao.VoteEventModel)(nil), (*dao.VotesSummaryModel)(nil)} {
+			_, err := db.NewDelete().Model(model).Where("target = ?", "concurrent-expected-target").Exec(ctx)
+			require.NoError(t, err)
+		}
+	}()
+
+	votes := []models.VoteValue{models.VoteValueUp, models.VoteValueDown}
+	errs := make([]error, len(votes))
+	var wg sync.WaitGroup
+	for i := range votes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = repository.Cast(
+				ctx, goframework.NumberUUID(1), goframework.NumberUUID(1), "concurrent-expected-target",
+				lo.ToPtr(votes[i]), lo.ToPtr(models.VoteValue("")), nil, 1, goframework.NumberUUID(10+i), baseTime,
+			)
+		}(i)
+	}
+	wg.Wait()
+
+	// Exactly one cast wins, and the other one reports the vote of the winner, rather than a constraint violation.
+	winner, loser := 0, 1
+	if errs[0] != nil {
+		winner, loser = 1, 0
+	}
+
+	require.NoError(t, errs[winner])
+
+	var conflict *models.VoteConflictError
+	require.ErrorAs(t, errs[loser], &conflict)
+	require.Equal(t, lo.ToPtr(votes[winner]), conflict.CurrentVote)
+
+	summary, err := repository.GetSummary(ctx, goframework.NumberUUID(1), "concurrent-expected-target")
+	require.NoError(t, err)
+	require.Equal(t, map[models.VoteValue]int{votes[winner]: 1}, summary.Counts)
+}
+
+// TestVotesSummaryBackfill makes sure the migration that replaced the votes_summary view with a table fills it with
+// the counts the view used to compute.
+func TestVotesSummaryBackfill(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VoteModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+			Weight:   1,
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(2),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+			Weight:   1,
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, nil),
+			Vote:     models.VoteValueDown,
+			UserID:   goframework.NumberUUID(3),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+			Weight:   1,
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(4), baseTime, nil),
+			Vote:     models.VoteValueDown,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(2),
+			Target:   "target",
+			Weight:   1,
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(5), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "other-target",
+			Weight:   1,
+		},
+	}
+
+	type summaryCounts struct {
+		TargetID  uuid.UUID `bun:"target_id"`
+		Target    string    `bun:"target"`
+		UpVotes   int       `bun:"up_votes"`
+		DownVotes int       `bun:"down_votes"`
+	}
+
+	// readStatement returns the statement of a migration that starts with the given prefix.
+	readStatement := func(file, prefix string) string {
+		content, err := fs.ReadFile(migrations.Migrations, file)
+		require.NoError(t, err)
+
+		for _, statement := range strings.Split(string(content), "--bun:split") {
+			if index := strings.Index(statement, prefix); index >= 0 {
+				return strings.TrimSuffix(strings.TrimSpace(statement[index+len(prefix):]), ";")
+			}
+		}
+
+		require.FailNow(t, "statement not found", "%s in %s", prefix, file)
+		return ""
+	}
+
+	view := readStatement("20230913043700_create_tables.up.sql", "CREATE VIEW votes_summary AS")
+	backfill := readStatement("20261018090000_votes_summary_table.up.sql", "INSERT INTO votes_summary")
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		expect := make([]*summaryCounts, 0)
+		require.NoError(t, tx.NewRaw("SELECT * FROM ("+view+") AS v ORDER BY target_id, target").Scan(ctx, &expect))
+		require.Equal(t, []*summaryCounts{
+			{TargetID: goframework.NumberUUID(1), Target: "other-target", UpVotes: 1},
+			{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 2, DownVotes: 1},
+			{TargetID: goframework.NumberUUID(2), Target: "target", DownVotes: 1},
+		}, expect)
+
+		_, err := tx.NewDelete().Model((*dao.VotesSummaryModel)(nil)).Where("TRUE").Exec(ctx)
+		require.NoError(t, err)
+		_, err = tx.NewRaw("INSERT INTO votes_summary " + backfill).Exec(ctx)
+		require.NoError(t, err)
+
+		res := make([]*summaryCounts, 0)
+		require.NoError(t, tx.NewRaw("SELECT target_id, target, up_votes, down_votes FROM votes_summary ORDER BY target_id, target").Scan(ctx, &res))
+		require.Equal(t, expect, res)
+	})
+	require.NoError(t, err)
+}
+
+func TestVotesRepository_GetTargetLock(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.TargetLockModel{
+		{
+			TargetID:  goframework.NumberUUID(1),
+			Target:    "target",
+			CreatedAt: baseTime,
+			LockedBy:  goframework.NumberUUID(100),
+			ClosesAt:  updateTime,
+		},
+	}
+
+	data := []struct {
+		name string
+
+		targetID uuid.UUID
+		target   string
+
+		expect    *dao.TargetLockModel
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			expect: &dao.TargetLockModel{
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				CreatedAt: baseTime,
+				LockedBy:  goframework.NumberUUID(100),
+				ClosesAt:  updateTime,
+			},
+		},
+		{
+			name:      "Error/NotFound",
+			targetID:  goframework.NumberUUID(1),
+			target:    "other-target",
+			expectErr: bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVotesRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.GetTargetLock(ctx, d.targetID, d.target)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+// TestVotesRepository_GetTargetLockConcurrently locks a target while a cast already checked it had no lock. The lock
+// waits for the cast, rather than closing the target under it.
+func TestVotesRepository_GetTargetLockConcurrently(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	ctx := context.Background()
+	repository := dao.NewVotesRepository(db)
+	locksRepository := dao.NewTargetLocksRepository(db)
+
+	defer func() {
+		for _, model := range []interface{}{
+			(*dao.VoteModel)(nil), (*dao.VoteEventModel)(nil), (*dao.VotesSummaryModel)(nil), (*dao.TargetLockModel)(nil),
+		} {
+			_, err := db.NewDelete().Model(model).Where("target = ?", "concurrent-lock-target").Exec(ctx)
+			require.NoError(t, err)
+		}
+	}()
+
+	checked := make(chan struct{})
+	locked := make(chan struct{})
+
+	var castErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		castErr = repository.RunInTx(ctx, func(ctx context.Context, txRepository dao.VotesRepository) error {
+			if _, err := txRepository.GetTargetLock(ctx, goframework.NumberUUID(1), "concurrent-lock-target"); !goerrors.Is(err, bunovel.ErrNotFound) {
+				return goerrors.Join(goerrors.New("target should not be locked yet"), err)
+			}
+			close(checked)
+
+			// Give the moderator time to lock the target, if nothing keeps them from it.
+			select {
+			case <-locked:
+				return goerrors.New("target was locked between the check and the cast")
+			case <-time.After(200 * time.Millisecond):
+			}
+
+			_, err := txRepository.Cast(
+				ctx, goframework.NumberUUID(10), goframework.NumberUUID(1), "concurrent-lock-target",
+				lo.ToPtr(models.VoteValueUp), nil, nil, 1, goframework.NumberUUID(20), baseTime,
+			)
+			return err
+		})
+	}()
+
+	<-checked
+	_, err := locksRepository.Lock(
+		ctx, goframework.NumberUUID(1), "concurrent-lock-target", goframework.NumberUUID(100), baseTime, baseTime,
+	)
+	close(locked)
+	wg.Wait()
+
+	require.NoError(t, err)
+	require.NoError(t, castErr)
+
+	// The vote was cast before the lock.
+	_, err = repository.Get(ctx, goframework.NumberUUID(10), goframework.NumberUUID(1), "concurrent-lock-target")
+	require.NoError(t, err)
+}
+
+func TestVotesRepository_QueueSummaryUpdate(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	// Use high IDs, so they don't collide with the ones generated by the database.
+	fixtures := []*dao.OutboxMessageModel{
+		{
+			ID:            100,
+			CreatedAt:     baseTime,
+			Target:        "target",
+			TargetID:      goframework.NumberUUID(1),
+			UserID:        goframework.NumberUUID(1),
+			UpVotes:       10,
+			DownVotes:     5,
+			Attempts:      3,
+			NextAttemptAt: baseTime.Add(time.Minute),
+			LastError:     lo.ToPtr("foo"),
+			Counts:        map[models.VoteValue]int{models.VoteValueUp: 10, models.VoteValueDown: 5},
+			Ratings:       map[int]int{},
+		},
+		{
+			ID:            101,
+			CreatedAt:     baseTime,
+			Target:        "target",
+			TargetID:      goframework.NumberUUID(2),
+			UserID:        goframework.NumberUUID(1),
+			UpVotes:       10,
+			Attempts:      10,
+			NextAttemptAt: baseTime,
+			DeadAt:        lo.ToPtr(baseTime),
+			Counts:        map[models.VoteValue]int{models.VoteValueUp: 10},
+			Ratings:       map[int]int{},
+		},
+		// Claimed by a dispatcher, until a minute after the update.
+		{
+			ID:            102,
+			CreatedAt:     baseTime,
+			Target:        "target",
+			TargetID:      goframework.NumberUUID(4),
+			UserID:        goframework.NumberUUID(1),
+			UpVotes:       10,
+			NextAttemptAt: updateTime.Add(time.Minute),
+			Counts:        map[models.VoteValue]int{models.VoteValueUp: 10},
+			Ratings:       map[int]int{},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		userID  uuid.UUID
+		summary *dao.VotesSummaryModel
+		now     time.Time
+
+		expect    *dao.OutboxMessageModel
+		expectErr error
+	}{
+		{
+			name:   "Success",
+			userID: goframework.NumberUUID(2),
+			summary: &dao.VotesSummaryModel{
+				TargetID:          goframework.NumberUUID(3),
+				Target:            "target",
+				UpVotes:           1,
+				DownVotes:         2,
+				WeightedUpVotes:   1,
+				WeightedDownVotes: 2,
+				Counts:            map[models.VoteValue]int{models.VoteValueUp: 1, models.VoteValueDown: 2},
+				Ratings:           map[int]int{},
+			},
+			now: updateTime,
+			expect: &dao.OutboxMessageModel{
+				CreatedAt:         updateTime,
+				Target:            "target",
+				TargetID:          goframework.NumberUUID(3),
+				UserID:            goframework.NumberUUID(2),
+				UpVotes:           1,
+				DownVotes:         2,
+				WeightedUpVotes:   1,
+				WeightedDownVotes: 2,
+				NextAttemptAt:     updateTime,
+				Counts:            map[models.VoteValue]int{models.VoteValueUp: 1, models.VoteValueDown: 2},
+				Ratings:           map[int]int{},
+			},
+		},
+		{
+			name:   "Success/OverwritePending",
+			userID: goframework.NumberUUID(2),
+			summary: &dao.VotesSummaryModel{
+				TargetID:          goframework.NumberUUID(1),
+				Target:            "target",
+				UpVotes:           11,
+				DownVotes:         5,
+				WeightedUpVotes:   12,
+				WeightedDownVotes: 5,
+				Counts:            map[models.VoteValue]int{models.VoteValueUp: 11, models.VoteValueDown: 5},
+				Ratings:           map[int]int{},
+			},
+			now: updateTime,
+			expect: &dao.OutboxMessageModel{
+				ID:                100,
+				CreatedAt:         baseTime,
+				Version:           1,
+				Target:            "target",
+				TargetID:          goframework.NumberUUID(1),
+				UserID:            goframework.NumberUUID(2),
+				UpVotes:           11,
+				DownVotes:         5,
+				WeightedUpVotes:   12,
+				WeightedDownVotes: 5,
+				NextAttemptAt:     updateTime,
+				Counts:            map[models.VoteValue]int{models.VoteValueUp: 11, models.VoteValueDown: 5},
+				Ratings:           map[int]int{},
+			},
+		},
+		{
+			name:   "Success/KeepLease",
+			userID: goframework.NumberUUID(2),
+			summary: &dao.VotesSummaryModel{
+				TargetID:        goframework.NumberUUID(4),
+				Target:          "target",
+				UpVotes:         11,
+				WeightedUpVotes: 11,
+				Counts:          map[models.VoteValue]int{models.VoteValueUp: 11},
+				Ratings:         map[int]int{},
+			},
+			now: updateTime,
+			expect: &dao.OutboxMessageModel{
+				ID:              102,
+				CreatedAt:       baseTime,
+				Version:         1,
+				Target:          "target",
+				TargetID:        goframework.NumberUUID(4),
+				UserID:          goframework.NumberUUID(2),
+				UpVotes:         11,
+				WeightedUpVotes: 11,
+				NextAttemptAt:   updateTime.Add(time.Minute),
+				Counts:          map[models.VoteValue]int{models.VoteValueUp: 11},
+				Ratings:         map[int]int{},
+			},
+		},
+		{
+			name:   "Success/IgnoreDeadLettered",
+			userID: goframework.NumberUUID(2),
+			summary: &dao.VotesSummaryModel{
+				TargetID:        goframework.NumberUUID(2),
+				Target:          "target",
+				UpVotes:         11,
+				WeightedUpVotes: 11,
+				Counts:          map[models.VoteValue]int{models.VoteValueUp: 11},
+				Ratings:         map[int]int{},
+			},
+			now: updateTime,
+			expect: &dao.OutboxMessageModel{
+				CreatedAt:       updateTime,
+				Target:          "target",
+				TargetID:        goframework.NumberUUID(2),
+				UserID:          goframework.NumberUUID(2),
+				UpVotes:         11,
+				WeightedUpVotes: 11,
+				NextAttemptAt:   updateTime,
+				Counts:          map[models.VoteValue]int{models.VoteValueUp: 11},
+				Ratings:         map[int]int{},
+			},
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(st *testing.T) {
+			err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+				repository := dao.NewVotesRepository(tx)
+
+				err := repository.QueueSummaryUpdate(ctx, d.userID, d.summary, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+
+				message := new(dao.OutboxMessageModel)
+				err = tx.NewSelect().Model(message).
+					Where("target_id = ?", d.summary.TargetID).
+					Where("target = ?", d.summary.Target).
+					Where("dead_at IS NULL").
+					Scan(ctx)
+				require.NoError(t, err)
+
+				// IDs of new messages are generated by the database.
+				if d.expect.ID == 0 {
+					message.ID = 0
+				}
+				require.Equal(t, d.expect, message)
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestVotesRepository_GetIdempotencyKey(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.IdempotencyKeyModel{
+		{
+			UserID:      goframework.NumberUUID(1),
+			Key:         "key",
+			CreatedAt:   baseTime,
+			ExpiresAt:   baseTime.Add(time.Hour),
+			RequestHash: "hash",
+			Response:    json.RawMessage(`{"upVotes": 1}`),
+		},
+		{
+			UserID:      goframework.NumberUUID(1),
+			Key:         "expired-key",
+			CreatedAt:   baseTime,
+			ExpiresAt:   baseTime.Add(time.Minute),
+			RequestHash: "hash",
+			Response:    json.RawMessage(`{"upVotes": 1}`),
+		},
+	}
+
+	data := []struct {
+		name string
+
+		userID uuid.UUID
+		key    string
+		now    time.Time
+
+		expect    *dao.IdempotencyKeyModel
+		expectErr error
+	}{
+		{
+			name:   "Success",
+			userID: goframework.NumberUUID(1),
+			key:    "key",
+			now:    baseTime.Add(30 * time.Minute),
+			expect: &dao.IdempotencyKeyModel{
+				UserID:      goframework.NumberUUID(1),
+				Key:         "key",
+				CreatedAt:   baseTime,
+				ExpiresAt:   baseTime.Add(time.Hour),
+				RequestHash: "hash",
+				Response:    json.RawMessage(`{"upVotes": 1}`),
+			},
+		},
+		{
+			name:      "Error/Expired",
+			userID:    goframework.NumberUUID(1),
+			key:       "expired-key",
+			now:       baseTime.Add(30 * time.Minute),
+			expectErr: bunovel.ErrNotFound,
+		},
+		// Keys are scoped to the user that sent them.
+		{
+			name:      "Error/OtherUser",
+			userID:    goframework.NumberUUID(2),
+			key:       "key",
+			now:       baseTime.Add(30 * time.Minute),
+			expectErr: bunovel.ErrNotFound,
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVotesRepository(tx)
+
+		for _, d := range data {
+			t.Run(d.name, func(st *testing.T) {
+				res, err := repository.GetIdempotencyKey(ctx, d.userID, d.key, d.now)
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expect, res)
+			})
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestVotesRepository_ClaimIdempotencyKey(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.IdempotencyKeyModel{
+		{
+			UserID:      goframework.NumberUUID(1),
+			Key:         "key",
+			CreatedAt:   baseTime,
+			ExpiresAt:   baseTime.Add(time.Hour),
+			RequestHash: "hash",
+			Response:    json.RawMessage(`{"upVotes": 1}`),
+		},
+		{
+			UserID:      goframework.NumberUUID(1),
+			Key:         "expired-key",
+			CreatedAt:   baseTime,
+			ExpiresAt:   baseTime.Add(time.Minute),
+			RequestHash: "hash",
+			Response:    json.RawMessage(`{"upVotes": 1}`),
+		},
+	}
+
+	data := []struct {
+		name string
+
+		userID      uuid.UUID
+		key         string
+		requestHash string
+		now         time.Time
+
+		expectClaimed bool
+		expect        *dao.IdempotencyKeyModel
+		expectErr     error
+	}{
+		{
+			name:          "Success",
+			userID:        goframework.NumberUUID(1),
+			key:           "new-key",
+			requestHash:   "new-hash",
+			now:           updateTime,
+			expectClaimed: true,
+			expect: &dao.IdempotencyKeyModel{
+				UserID:      goframework.NumberUUID(1),
+				Key:         "new-key",
+				CreatedAt:   updateTime,
+				ExpiresAt:   updateTime.Add(time.Hour),
+				RequestHash: "new-hash",
+			},
+		},
+		{
+			name:          "Success/OtherUser",
+			userID:        goframework.NumberUUID(2),
+			key:           "key",
+			requestHash:   "new-hash",
+			now:           baseTime.Add(30 * time.Minute),
+			expectClaimed: true,
+			expect: &dao.IdempotencyKeyModel{
+				UserID:      goframework.NumberUUID(2),
+				Key:         "key",
+				CreatedAt:   baseTime.Add(30 * time.Minute),
+				ExpiresAt:   baseTime.Add(90 * time.Minute),
+				RequestHash: "new-hash",
+			},
+		},
+		{
+			name:          "Success/Expired",
+			userID:        goframework.NumberUUID(1),
+			key:           "expired-key",
+			requestHash:   "new-hash",
+			now:           baseTime.Add(30 * time.Minute),
+			expectClaimed: true,
+			expect: &dao.IdempotencyKeyModel{
+				UserID:      goframework.NumberUUID(1),
+				Key:         "expired-key",
+				CreatedAt:   baseTime.Add(30 * time.Minute),
+				ExpiresAt:   baseTime.Add(90 * time.Minute),
+				RequestHash: "new-hash",
+			},
+		},
+		{
+			name:        "Success/AlreadyClaimed",
+			userID:      goframework.NumberUUID(1),
+			key:         "key",
+			requestHash: "new-hash",
+			now:         baseTime.Add(30 * time.Minute),
+			expect: &dao.IdempotencyKeyModel{
+				UserID:      goframework.NumberUUID(1),
+				Key:         "key",
+				CreatedAt:   baseTime,
+				ExpiresAt:   baseTime.Add(time.Hour),
+				RequestHash: "hash",
+				Response:    json.RawMessage(`{"upVotes": 1}`),
+			},
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(st *testing.T) {
+			err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+				repository := dao.NewVotesRepository(tx)
+
+				claimed, err := repository.ClaimIdempotencyKey(ctx, d.userID, d.key, d.requestHash, d.now, d.now.Add(time.Hour))
+				require.ErrorIs(t, err, d.expectErr)
+				require.Equal(t, d.expectClaimed, claimed)
+
+				model := new(dao.IdempotencyKeyModel)
+				err = tx.NewSelect().Model(model).
+					Where("user_id = ?", d.userID).
+					Where("idempotency_key = ?", d.key).
+					Scan(ctx)
+				require.NoError(t, err)
+				require.Equal(t, d.expect, model)
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestVotesRepository_SaveIdempotencyResponse(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.IdempotencyKeyModel{
+		{
+			UserID:      goframework.NumberUUID(1),
+			Key:         "key",
+			CreatedAt:   baseTime,
+			ExpiresAt:   baseTime.Add(time.Hour),
+			RequestHash: "hash",
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVotesRepository(tx)
+
+		err := repository.SaveIdempotencyResponse(ctx, goframework.NumberUUID(1), "key", json.RawMessage(`{"upVotes":1}`))
+		require.NoError(t, err)
+
+		model, err := repository.GetIdempotencyKey(ctx, goframework.NumberUUID(1), "key", baseTime)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"upVotes":1}`, string(model.Response))
+	})
+	require.NoError(t, err)
+}
+
+func TestVotesRepository_PurgeIdempotencyKeys(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.IdempotencyKeyModel{
+		{
+			UserID:      goframework.NumberUUID(1),
+			Key:         "key",
+			CreatedAt:   baseTime,
+			ExpiresAt:   baseTime.Add(time.Hour),
+			RequestHash: "hash",
+		},
+		{
+			UserID:      goframework.NumberUUID(1),
+			Key:         "expired-key",
+			CreatedAt:   baseTime,
+			ExpiresAt:   baseTime.Add(time.Minute),
+			RequestHash: "hash",
+		},
+		{
+			UserID:      goframework.NumberUUID(2),
+			Key:         "expired-key",
+			CreatedAt:   baseTime,
+			ExpiresAt:   baseTime.Add(time.Minute),
+			RequestHash: "hash",
+		},
+	}
+
+	err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+		repository := dao.NewVotesRepository(tx)
+
+		deleted, err := repository.PurgeIdempotencyKeys(ctx, baseTime.Add(30*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, 2, deleted)
+
+		count, err := tx.NewSelect().Model((*dao.IdempotencyKeyModel)(nil)).Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+	require.NoError(t, err)
+}
+
+func TestVotesRepository_Invalidate(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VoteModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+			Weight:   3,
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+			Vote:     models.VoteValueDown,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(2),
+			Target:   "target",
+			Weight:   2,
+		},
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(2),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+			Weight:   1,
+		},
+		// Already invalidated.
+		{
+			Metadata:           bunovel.NewMetadata(goframework.NumberUUID(4), baseTime, nil),
+			Vote:               models.VoteValueUp,
+			UserID:             goframework.NumberUUID(3),
+			TargetID:           goframework.NumberUUID(1),
+			Target:             "target",
+			Weight:             1,
+			InvalidatedAt:      lo.ToPtr(baseTime),
+			InvalidatedBy:      lo.ToPtr(goframework.NumberUUID(101)),
+			InvalidationReason: lo.ToPtr("spam"),
+		},
+	}
+
+	summaries := []*dao.VotesSummaryModel{
+		{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 2, WeightedUpVotes: 4, Counts: map[models.VoteValue]int{models.VoteValueUp: 2}, Ratings: map[int]int{}},
+		{TargetID: goframework.NumberUUID(2), Target: "target", DownVotes: 1, WeightedDownVotes: 2, Counts: map[models.VoteValue]int{models.VoteValueDown: 1}, Ratings: map[int]int{}},
+	}
+
+	data := []struct {
+		name string
+
+		filter dao.ModeratedVotesFilter
+
+		expect          []*dao.VoteModel
+		expectSummaries []*dao.VotesSummaryModel
+		expectErr       error
+	}{
+		{
+			name:   "Success/User",
+			filter: dao.ModeratedVotesFilter{UserID: lo.ToPtr(goframework.NumberUUID(1))},
+			expect: []*dao.VoteModel{
+				{
+					Metadata:           bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+					Vote:               models.VoteValueUp,
+					UserID:             goframework.NumberUUID(1),
+					TargetID:           goframework.NumberUUID(1),
+					Target:             "target",
+					Weight:             3,
+					InvalidatedAt:      lo.ToPtr(updateTime),
+					InvalidatedBy:      lo.ToPtr(goframework.NumberUUID(100)),
+					InvalidationReason: lo.ToPtr("vote ring"),
+				},
+				{
+					Metadata:           bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+					Vote:               models.VoteValueDown,
+					UserID:             goframework.NumberUUID(1),
+					TargetID:           goframework.NumberUUID(2),
+					Target:             "target",
+					Weight:             2,
+					InvalidatedAt:      lo.ToPtr(updateTime),
+					InvalidatedBy:      lo.ToPtr(goframework.NumberUUID(100)),
+					InvalidationReason: lo.ToPtr("vote ring"),
+				},
+			},
+			expectSummaries: []*dao.VotesSummaryModel{
+				{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 1, WeightedUpVotes: 1, Counts: map[models.VoteValue]int{models.VoteValueUp: 1}, Ratings: map[int]int{}},
+				{TargetID: goframework.NumberUUID(2), Target: "target", Counts: map[models.VoteValue]int{}, Ratings: map[int]int{}},
+			},
+		},
+		{
+			name:   "Success/Target",
+			filter: dao.ModeratedVotesFilter{TargetID: lo.ToPtr(goframework.NumberUUID(1)), Target: "target"},
+			expect: []*dao.VoteModel{
+				{
+					Metadata:           bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+					Vote:               models.VoteValueUp,
+					UserID:             goframework.NumberUUID(1),
+					TargetID:           goframework.NumberUUID(1),
+					Target:             "target",
+					Weight:             3,
+					InvalidatedAt:      lo.ToPtr(updateTime),
+					InvalidatedBy:      lo.ToPtr(goframework.NumberUUID(100)),
+					InvalidationReason: lo.ToPtr("vote ring"),
+				},
+				{
+					Metadata:           bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, nil),
+					Vote:               models.VoteValueUp,
+					UserID:             goframework.NumberUUID(2),
+					TargetID:           goframework.NumberUUID(1),
+					Target:             "target",
+					Weight:             1,
+					InvalidatedAt:      lo.ToPtr(updateTime),
+					InvalidatedBy:      lo.ToPtr(goframework.NumberUUID(100)),
+					InvalidationReason: lo.ToPtr("vote ring"),
+				},
+			},
+			expectSummaries: []*dao.VotesSummaryModel{
+				{TargetID: goframework.NumberUUID(1), Target: "target", Counts: map[models.VoteValue]int{}, Ratings: map[int]int{}},
+				{TargetID: goframework.NumberUUID(2), Target: "target", DownVotes: 1, WeightedDownVotes: 2, Counts: map[models.VoteValue]int{models.VoteValueDown: 1}, Ratings: map[int]int{}},
+			},
+		},
+		{
+			name:            "Success/AlreadyInvalidated",
+			filter:          dao.ModeratedVotesFilter{UserID: lo.ToPtr(goframework.NumberUUID(3))},
+			expect:          []*dao.VoteModel{},
+			expectSummaries: summaries,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(st *testing.T) {
+			err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+				repository := dao.NewVotesRepository(tx)
+
+				_, err := tx.NewInsert().Model(&summaries).Exec(ctx)
+				require.NoError(t, err)
+
+				res, err := repository.Invalidate(ctx, d.filter, goframework.NumberUUID(100), "vote ring", updateTime)
+				require.ErrorIs(t, err, d.expectErr)
+				require.ElementsMatch(t, d.expect, res)
+
+				updatedSummaries, err := repository.GetSummaries(ctx, "target", []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2)})
+				require.NoError(t, err)
+				require.ElementsMatch(t, d.expectSummaries, updatedSummaries)
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestVotesRepository_Restore(t *testing.T) {
+	db, sqlDB := bunovel.GetTestPostgres(t, []fs.FS{migrations.Migrations})
+	defer db.Close()
+	defer sqlDB.Close()
+
+	fixtures := []*dao.VoteModel{
+		{
+			Metadata: bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+			Vote:     models.VoteValueUp,
+			UserID:   goframework.NumberUUID(1),
+			TargetID: goframework.NumberUUID(1),
+			Target:   "target",
+			Weight:   1,
+		},
+		{
+			Metadata:           bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+			Vote:               models.VoteValueDown,
+			UserID:             goframework.NumberUUID(2),
+			TargetID:           goframework.NumberUUID(1),
+			Target:             "target",
+			Weight:             2,
+			InvalidatedAt:      lo.ToPtr(baseTime),
+			InvalidatedBy:      lo.ToPtr(goframework.NumberUUID(100)),
+			InvalidationReason: lo.ToPtr("vote ring"),
+		},
+		{
+			Metadata:           bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, nil),
+			Vote:               models.VoteValueUp,
+			UserID:             goframework.NumberUUID(2),
+			TargetID:           goframework.NumberUUID(2),
+			Target:             "target",
+			Weight:             3,
+			InvalidatedAt:      lo.ToPtr(baseTime),
+			InvalidatedBy:      lo.ToPtr(goframework.NumberUUID(100)),
+			InvalidationReason: lo.ToPtr("vote ring"),
+		},
+	}
+
+	summaries := []*dao.VotesSummaryModel{
+		{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 1, WeightedUpVotes: 1, Counts: map[models.VoteValue]int{models.VoteValueUp: 1}, Ratings: map[int]int{}},
+	}
+
+	data := []struct {
+		name string
+
+		filter dao.ModeratedVotesFilter
+
+		expect          []*dao.VoteModel
+		expectSummaries []*dao.VotesSummaryModel
+		expectErr       error
+	}{
+		{
+			name:   "Success/User",
+			filter: dao.ModeratedVotesFilter{UserID: lo.ToPtr(goframework.NumberUUID(2))},
+			expect: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(2), baseTime, nil),
+					Vote:     models.VoteValueDown,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+					Weight:   2,
+				},
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, nil),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(2),
+					Target:   "target",
+					Weight:   3,
+				},
+			},
+			expectSummaries: []*dao.VotesSummaryModel{
+				{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 1, DownVotes: 1, WeightedUpVotes: 1, WeightedDownVotes: 2, Counts: map[models.VoteValue]int{models.VoteValueUp: 1, models.VoteValueDown: 1}, Ratings: map[int]int{}},
+				{TargetID: goframework.NumberUUID(2), Target: "target", UpVotes: 1, WeightedUpVotes: 3, Counts: map[models.VoteValue]int{models.VoteValueUp: 1}, Ratings: map[int]int{}},
+			},
+		},
+		{
+			name:   "Success/UserOnTarget",
+			filter: dao.ModeratedVotesFilter{UserID: lo.ToPtr(goframework.NumberUUID(2)), TargetID: lo.ToPtr(goframework.NumberUUID(2)), Target: "target"},
+			expect: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(3), baseTime, nil),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(2),
+					TargetID: goframework.NumberUUID(2),
+					Target:   "target",
+					Weight:   3,
+				},
+			},
+			expectSummaries: []*dao.VotesSummaryModel{
+				{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 1, WeightedUpVotes: 1, Counts: map[models.VoteValue]int{models.VoteValueUp: 1}, Ratings: map[int]int{}},
+				{TargetID: goframework.NumberUUID(2), Target: "target", UpVotes: 1, WeightedUpVotes: 3, Counts: map[models.VoteValue]int{models.VoteValueUp: 1}, Ratings: map[int]int{}},
+			},
+		},
+		{
+			name:            "Success/NotInvalidated",
+			filter:          dao.ModeratedVotesFilter{UserID: lo.ToPtr(goframework.NumberUUID(1))},
+			expect:          []*dao.VoteModel{},
+			expectSummaries: summaries,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(st *testing.T) {
+			err := bunovel.RunTransactionalTest(db, fixtures, func(ctx context.Context, tx bun.Tx) {
+				repository := dao.NewVotesRepository(tx)
+
+				_, err := tx.NewInsert().Model(&summaries).Exec(ctx)
+				require.NoError(t, err)
+
+				res, err := repository.Restore(ctx, d.filter)
+				require.ErrorIs(t, err, d.expectErr)
+				require.ElementsMatch(t, d.expect, res)
+
+				updatedSummaries, err := repository.GetSummaries(ctx, "target", []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2)})
+				require.NoError(t, err)
+				require.ElementsMatch(t, d.expectSummaries, updatedSummaries)
+			})
 			require.NoError(t, err)
 		})
 	}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type CastBallotHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewCastBallotHandler(service services.CastBallotService) CastBallotHandler {
+	return &castBallotHandlerImpl{
+		service: service,
+	}
+}
+
+type castBallotHandlerImpl struct {
+	service services.CastBallotService
+}
+
+func (h *castBallotHandlerImpl) Handle(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+
+	request := new(models.CastBallotForm)
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	ballot, err := h.service.Cast(c, token, *request, time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrBallotAlreadyCast, http.StatusConflict},
+			{bunovel.ErrNotFound, http.StatusNotFound},
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, ballot)
+}
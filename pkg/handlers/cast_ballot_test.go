@@ -0,0 +1,155 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/handlers"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCastBallotHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+		body          interface{}
+
+		shouldCallService     bool
+		shouldCallServiceWith models.CastBallotForm
+		serviceResp           *models.PollBallot
+		serviceErr            error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:          "Success",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"pollID":  goframework.NumberUUID(1).String(),
+				"choices": []int{1, 0},
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.CastBallotForm{
+				PollID:  goframework.NumberUUID(1),
+				Choices: []int{1, 0},
+			},
+			serviceResp: &models.PollBallot{
+				PollID:    goframework.NumberUUID(1),
+				UserID:    goframework.NumberUUID(100),
+				CreatedAt: baseTime,
+				Choices:   []int{1, 0},
+			},
+			expect: map[string]interface{}{
+				"pollID":    goframework.NumberUUID(1).String(),
+				"userID":    goframework.NumberUUID(100).String(),
+				"createdAt": baseTime.Format(time.RFC3339),
+				"choices":   []interface{}{float64(1), float64(0)},
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:          "Error/ErrBallotAlreadyCast",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"pollID":  goframework.NumberUUID(1).String(),
+				"choices": []int{1},
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.CastBallotForm{
+				PollID:  goframework.NumberUUID(1),
+				Choices: []int{1},
+			},
+			serviceErr:   services.ErrBallotAlreadyCast,
+			expectStatus: http.StatusConflict,
+		},
+		{
+			name:          "Error/ErrNotFound",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"pollID":  goframework.NumberUUID(1).String(),
+				"choices": []int{1},
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.CastBallotForm{
+				PollID:  goframework.NumberUUID(1),
+				Choices: []int{1},
+			},
+			serviceErr:   bunovel.ErrNotFound,
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:          "Error/ErrInvalidCredentials",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"pollID":  goframework.NumberUUID(1).String(),
+				"choices": []int{1},
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.CastBallotForm{
+				PollID:  goframework.NumberUUID(1),
+				Choices: []int{1},
+			},
+			serviceErr:   goframework.ErrInvalidCredentials,
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:          "Error/ErrInvalidEntity",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"pollID":  goframework.NumberUUID(1).String(),
+				"choices": []int{5},
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.CastBallotForm{
+				PollID:  goframework.NumberUUID(1),
+				Choices: []int{5},
+			},
+			serviceErr:   goframework.ErrInvalidEntity,
+			expectStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewCastBallotService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			if d.shouldCallService {
+				service.
+					On("Cast", c, d.authorization, d.shouldCallServiceWith, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewCastBallotHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
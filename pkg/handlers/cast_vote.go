@@ -1,16 +1,22 @@
 package handlers
 
 import (
+	goerrors "errors"
 	"github.com/a-novel/go-apis"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/a-novel/votes-service/pkg/models"
 	"github.com/a-novel/votes-service/pkg/services"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 )
 
+// IdempotencyKeyHeader lets clients retry a vote safely: requests sent with the same key are only executed once.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 type CastVoteHandler interface {
 	Handle(c *gin.Context)
 }
@@ -34,9 +40,25 @@ func (h *castVoteHandlerImpl) Handle(c *gin.Context) {
 		return
 	}
 
-	summary, err := h.service.Cast(c, token, *request, uuid.New(), time.Now())
+	summary, err := h.service.Cast(c, token, *request, c.GetHeader(IdempotencyKeyHeader), uuid.New(), time.Now())
 	if err != nil {
+		// Send the current vote back, so the client can reconcile its state.
+		var conflict *models.VoteConflictError
+		if goerrors.As(err, &conflict) {
+			c.AbortWithStatusJSON(http.StatusConflict, conflict)
+			return
+		}
+
+		var rateLimited *models.RateLimitError
+		if goerrors.As(err, &rateLimited) {
+			// Retry-After is expressed in whole seconds.
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(rateLimited.RetryAfter.Seconds()))))
+			_ = c.AbortWithError(http.StatusTooManyRequests, err)
+			return
+		}
+
 		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrTargetLocked, http.StatusLocked},
 			{goframework.ErrInvalidCredentials, http.StatusForbidden},
 			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
 		}, true)
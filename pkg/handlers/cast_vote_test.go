@@ -3,9 +3,11 @@ package handlers_test
 import (
 	"bytes"
 	"encoding/json"
+	goerrors "errors"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/a-novel/votes-service/pkg/handlers"
 	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
 	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
 	"github.com/gin-gonic/gin"
 	"github.com/samber/lo"
@@ -14,13 +16,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestCastVoteHandler(t *testing.T) {
 	data := []struct {
 		name string
 
-		authorization string
+		authorization  string
+		idempotencyKey string
 
 		body interface{}
 
@@ -31,6 +35,7 @@ func TestCastVoteHandler(t *testing.T) {
 
 		expect       interface{}
 		expectStatus int
+		expectHeader map[string]string
 	}{
 		{
 			name:          "Success",
@@ -47,15 +52,107 @@ func TestCastVoteHandler(t *testing.T) {
 				Vote:     lo.ToPtr(models.VoteValueUp),
 			},
 			serviceResp: &models.VotesSummary{
-				UpVotes:   128,
-				DownVotes: 64,
+				UpVotes:           128,
+				DownVotes:         64,
+				WeightedUpVotes:   128,
+				WeightedDownVotes: 64,
 			},
 			expect: map[string]interface{}{
-				"upVotes":   float64(128),
-				"downVotes": float64(64),
+				"upVotes":           float64(128),
+				"downVotes":         float64(64),
+				"weightedUpVotes":   float64(128),
+				"weightedDownVotes": float64(64),
 			},
 			expectStatus: http.StatusOK,
 		},
+		{
+			name:           "Success/IdempotencyKey",
+			authorization:  "Bearer my-token",
+			idempotencyKey: "my-key",
+			body: map[string]interface{}{
+				"targetID": goframework.NumberUUID(1).String(),
+				"target":   "target",
+				"vote":     "up",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			serviceResp: &models.VotesSummary{
+				UpVotes:           128,
+				DownVotes:         64,
+				WeightedUpVotes:   128,
+				WeightedDownVotes: 64,
+			},
+			expect: map[string]interface{}{
+				"upVotes":           float64(128),
+				"downVotes":         float64(64),
+				"weightedUpVotes":   float64(128),
+				"weightedDownVotes": float64(64),
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:          "Error/VoteConflict",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"targetID":     goframework.NumberUUID(1).String(),
+				"target":       "target",
+				"vote":         "up",
+				"expectedVote": "",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.VoteForm{
+				TargetID:     goframework.NumberUUID(1),
+				Target:       "target",
+				Vote:         lo.ToPtr(models.VoteValueUp),
+				ExpectedVote: lo.ToPtr(models.VoteValue("")),
+			},
+			serviceErr: goerrors.Join(
+				services.ErrVoteConflict, &models.VoteConflictError{CurrentVote: lo.ToPtr(models.VoteValueDown)},
+			),
+			expect: map[string]interface{}{
+				"currentVote": "down",
+			},
+			expectStatus: http.StatusConflict,
+		},
+		{
+			name:          "Error/TargetLocked",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"targetID": goframework.NumberUUID(1).String(),
+				"target":   "target",
+				"vote":     "up",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			serviceErr:   services.ErrTargetLocked,
+			expectStatus: http.StatusLocked,
+		},
+		{
+			name:          "Error/RateLimited",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"targetID": goframework.NumberUUID(1).String(),
+				"target":   "target",
+				"vote":     "up",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			serviceErr:   goerrors.Join(services.ErrRateLimited, &models.RateLimitError{RetryAfter: 1500 * time.Millisecond}),
+			expectStatus: http.StatusTooManyRequests,
+			expectHeader: map[string]string{"Retry-After": "2"},
+		},
 		{
 			name:          "Success/NoVote",
 			authorization: "Bearer my-token",
@@ -69,12 +166,16 @@ func TestCastVoteHandler(t *testing.T) {
 				Target:   "target",
 			},
 			serviceResp: &models.VotesSummary{
-				UpVotes:   128,
-				DownVotes: 64,
+				UpVotes:           128,
+				DownVotes:         64,
+				WeightedUpVotes:   128,
+				WeightedDownVotes: 64,
 			},
 			expect: map[string]interface{}{
-				"upVotes":   float64(128),
-				"downVotes": float64(64),
+				"upVotes":           float64(128),
+				"downVotes":         float64(64),
+				"weightedUpVotes":   float64(128),
+				"weightedDownVotes": float64(64),
 			},
 			expectStatus: http.StatusOK,
 		},
@@ -125,10 +226,13 @@ func TestCastVoteHandler(t *testing.T) {
 			c, _ := gin.CreateTestContext(w)
 			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
 			c.Request.Header.Set("Authorization", d.authorization)
+			if d.idempotencyKey != "" {
+				c.Request.Header.Set(handlers.IdempotencyKeyHeader, d.idempotencyKey)
+			}
 
 			if d.shouldCallService {
 				service.
-					On("Cast", c, d.authorization, d.shouldCallServiceWith, mock.Anything, mock.Anything).
+					On("Cast", c, d.authorization, d.shouldCallServiceWith, d.idempotencyKey, mock.Anything, mock.Anything).
 					Return(d.serviceResp, d.serviceErr)
 			}
 
@@ -136,6 +240,9 @@ func TestCastVoteHandler(t *testing.T) {
 			handler.Handle(c)
 
 			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			for header, value := range d.expectHeader {
+				require.Equal(t, value, w.Header().Get(header))
+			}
 			if d.expect != nil {
 				var body interface{}
 				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
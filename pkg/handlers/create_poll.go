@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+type CreatePollHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewCreatePollHandler(service services.CreatePollService) CreatePollHandler {
+	return &createPollHandlerImpl{
+		service: service,
+	}
+}
+
+type createPollHandlerImpl struct {
+	service services.CreatePollService
+}
+
+func (h *createPollHandlerImpl) Handle(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+
+	request := new(models.CreatePollForm)
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	poll, err := h.service.Create(c, token, *request, uuid.New(), time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, poll)
+}
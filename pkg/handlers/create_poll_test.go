@@ -0,0 +1,148 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/handlers"
+	"github.com/a-novel/votes-service/pkg/models"
+	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreatePollHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+		body          interface{}
+
+		shouldCallService     bool
+		shouldCallServiceWith models.CreatePollForm
+		serviceResp           *models.Poll
+		serviceErr            error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:          "Success",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"question":    "Which chapter is the best?",
+				"options":     []string{"first", "second"},
+				"multiSelect": true,
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.CreatePollForm{
+				Question:    "Which chapter is the best?",
+				Options:     []string{"first", "second"},
+				MultiSelect: true,
+			},
+			serviceResp: &models.Poll{
+				ID:          goframework.NumberUUID(1),
+				CreatedAt:   baseTime,
+				CreatedBy:   goframework.NumberUUID(100),
+				Question:    "Which chapter is the best?",
+				Options:     []string{"first", "second"},
+				MultiSelect: true,
+				OpensAt:     baseTime,
+			},
+			expect: map[string]interface{}{
+				"id":          goframework.NumberUUID(1).String(),
+				"createdAt":   baseTime.Format(time.RFC3339),
+				"createdBy":   goframework.NumberUUID(100).String(),
+				"question":    "Which chapter is the best?",
+				"options":     []interface{}{"first", "second"},
+				"multiSelect": true,
+				"opensAt":     baseTime.Format(time.RFC3339),
+				"hideResults": false,
+				"ranked":      false,
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:          "Error/ErrInvalidCredentials",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"question": "Which chapter is the best?",
+				"options":  []string{"first", "second"},
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.CreatePollForm{
+				Question: "Which chapter is the best?",
+				Options:  []string{"first", "second"},
+			},
+			serviceErr:   goframework.ErrInvalidCredentials,
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:          "Error/ErrInvalidEntity",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"question": "Which chapter is the best?",
+				"options":  []string{"first"},
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.CreatePollForm{
+				Question: "Which chapter is the best?",
+				Options:  []string{"first"},
+			},
+			serviceErr:   goframework.ErrInvalidEntity,
+			expectStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:          "Error/ServiceFailure",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"question": "Which chapter is the best?",
+				"options":  []string{"first", "second"},
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.CreatePollForm{
+				Question: "Which chapter is the best?",
+				Options:  []string{"first", "second"},
+			},
+			serviceErr:   fooErr,
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewCreatePollService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			if d.shouldCallService {
+				service.
+					On("Create", c, d.authorization, d.shouldCallServiceWith, mock.Anything, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewCreatePollHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
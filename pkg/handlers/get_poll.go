@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type GetPollHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewGetPollHandler(service services.GetPollService) GetPollHandler {
+	return &getPollHandlerImpl{
+		service: service,
+	}
+}
+
+type getPollHandlerImpl struct {
+	service services.GetPollService
+}
+
+func (h *getPollHandlerImpl) Handle(c *gin.Context) {
+	query := new(models.GetPollQuery)
+	if err := c.BindQuery(query); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	poll, err := h.service.Get(c, query.ID.Value())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{bunovel.ErrNotFound, http.StatusNotFound},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, poll)
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type GetPollResultsHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewGetPollResultsHandler(service services.GetPollResultsService) GetPollResultsHandler {
+	return &getPollResultsHandlerImpl{
+		service: service,
+	}
+}
+
+type getPollResultsHandlerImpl struct {
+	service services.GetPollResultsService
+}
+
+func (h *getPollResultsHandlerImpl) Handle(c *gin.Context) {
+	query := new(models.GetPollQuery)
+	if err := c.BindQuery(query); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	results, err := h.service.Get(c, query.ID.Value(), time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{services.ErrPollResultsHidden, http.StatusForbidden},
+			{bunovel.ErrNotFound, http.StatusNotFound},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,106 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/handlers"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetPollResultsHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		query string
+
+		shouldCallService     bool
+		shouldCallServiceWith uuid.UUID
+		serviceResp           *models.PollResults
+		serviceErr            error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:                  "Success",
+			query:                 "?id=" + goframework.NumberUUID(1).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(1),
+			serviceResp: &models.PollResults{
+				PollID:  goframework.NumberUUID(1),
+				Ballots: 5,
+				Votes:   []int{3, 0, 2},
+				Final:   true,
+			},
+			expect: map[string]interface{}{
+				"pollID":  goframework.NumberUUID(1).String(),
+				"ballots": float64(5),
+				"votes":   []interface{}{float64(3), float64(0), float64(2)},
+				"final":   true,
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:                  "Error/ErrPollResultsHidden",
+			query:                 "?id=" + goframework.NumberUUID(1).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(1),
+			serviceErr:            services.ErrPollResultsHidden,
+			expectStatus:          http.StatusForbidden,
+		},
+		{
+			name:                  "Error/ErrInvalidEntity",
+			query:                 "?id=" + goframework.NumberUUID(1).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(1),
+			serviceErr:            goframework.ErrInvalidEntity,
+			expectStatus:          http.StatusUnprocessableEntity,
+		},
+		{
+			name:                  "Error/ErrNotFound",
+			query:                 "?id=" + goframework.NumberUUID(1).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(1),
+			serviceErr:            bunovel.ErrNotFound,
+			expectStatus:          http.StatusNotFound,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewGetPollResultsService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/"+d.query, nil)
+
+			if d.shouldCallService {
+				service.
+					On("Get", c, d.shouldCallServiceWith, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewGetPollResultsHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
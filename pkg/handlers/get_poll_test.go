@@ -0,0 +1,107 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/handlers"
+	"github.com/a-novel/votes-service/pkg/models"
+	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetPollHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		query string
+
+		shouldCallService     bool
+		shouldCallServiceWith uuid.UUID
+		serviceResp           *models.Poll
+		serviceErr            error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:                  "Success",
+			query:                 "?id=" + goframework.NumberUUID(1).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(1),
+			serviceResp: &models.Poll{
+				ID:          goframework.NumberUUID(1),
+				CreatedAt:   baseTime,
+				CreatedBy:   goframework.NumberUUID(100),
+				Question:    "Which chapter is the best?",
+				Options:     []string{"first", "second"},
+				OpensAt:     baseTime,
+				ClosesAt:    &updateTime,
+				HideResults: true,
+			},
+			expect: map[string]interface{}{
+				"id":          goframework.NumberUUID(1).String(),
+				"createdAt":   baseTime.Format(time.RFC3339),
+				"createdBy":   goframework.NumberUUID(100).String(),
+				"question":    "Which chapter is the best?",
+				"options":     []interface{}{"first", "second"},
+				"multiSelect": false,
+				"opensAt":     baseTime.Format(time.RFC3339),
+				"closesAt":    updateTime.Format(time.RFC3339),
+				"hideResults": true,
+				"ranked":      false,
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:                  "Error/ErrNotFound",
+			query:                 "?id=" + goframework.NumberUUID(1).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(1),
+			serviceErr:            bunovel.ErrNotFound,
+			expectStatus:          http.StatusNotFound,
+		},
+		{
+			name:                  "Error/ServiceFailure",
+			query:                 "?id=" + goframework.NumberUUID(1).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(1),
+			serviceErr:            fooErr,
+			expectStatus:          http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewGetPollService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/"+d.query, nil)
+
+			if d.shouldCallService {
+				service.
+					On("Get", c, d.shouldCallServiceWith).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewGetPollHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/handlers"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetRunoffResultsHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		query string
+
+		shouldCallService     bool
+		shouldCallServiceWith uuid.UUID
+		serviceResp           *models.RunoffResults
+		serviceErr            error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:                  "Success",
+			query:                 "?id=" + goframework.NumberUUID(1).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(1),
+			serviceResp: &models.RunoffResults{
+				PollID:  goframework.NumberUUID(1),
+				Ballots: 3,
+				Rounds: []*models.RunoffRound{
+					{Votes: []int{1, 1, 1}, Eliminated: lo.ToPtr(2)},
+					{Votes: []int{1, 2, 0}},
+				},
+				Winner: lo.ToPtr(1),
+				Final:  true,
+			},
+			expect: map[string]interface{}{
+				"pollID":  goframework.NumberUUID(1).String(),
+				"ballots": float64(3),
+				"rounds": []interface{}{
+					map[string]interface{}{
+						"votes":      []interface{}{float64(1), float64(1), float64(1)},
+						"exhausted":  float64(0),
+						"eliminated": float64(2),
+					},
+					map[string]interface{}{
+						"votes":     []interface{}{float64(1), float64(2), float64(0)},
+						"exhausted": float64(0),
+					},
+				},
+				"winner": float64(1),
+				"final":  true,
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:                  "Error/ErrPollResultsHidden",
+			query:                 "?id=" + goframework.NumberUUID(1).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(1),
+			serviceErr:            services.ErrPollResultsHidden,
+			expectStatus:          http.StatusForbidden,
+		},
+		{
+			name:                  "Error/ErrInvalidEntity",
+			query:                 "?id=" + goframework.NumberUUID(1).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(1),
+			serviceErr:            goframework.ErrInvalidEntity,
+			expectStatus:          http.StatusUnprocessableEntity,
+		},
+		{
+			name:                  "Error/ErrNotFound",
+			query:                 "?id=" + goframework.NumberUUID(1).String(),
+			shouldCallService:     true,
+			shouldCallServiceWith: goframework.NumberUUID(1),
+			serviceErr:            bunovel.ErrNotFound,
+			expectStatus:          http.StatusNotFound,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewGetRunoffResultsService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/"+d.query, nil)
+
+			if d.shouldCallService {
+				service.
+					On("Get", c, d.shouldCallServiceWith, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewGetRunoffResultsHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type GetUserVotesHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewGetUserVotesHandler(service services.GetUserVotesService) GetUserVotesHandler {
+	return &getUserVotesHandlerImpl{
+		service: service,
+	}
+}
+
+type getUserVotesHandlerImpl struct {
+	service services.GetUserVotesService
+}
+
+func (h *getUserVotesHandlerImpl) Handle(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+
+	request := new(models.GetUserVotesForm)
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	votes, err := h.service.Get(c, token, request.Target, request.TargetIDs)
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"votes": votes})
+}
@@ -0,0 +1,131 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/handlers"
+	"github.com/a-novel/votes-service/pkg/models"
+	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetUserVotesHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+
+		body interface{}
+
+		shouldCallService              bool
+		shouldCallServiceWithTarget    string
+		shouldCallServiceWithTargetIDs []uuid.UUID
+		serviceResp                    map[uuid.UUID]*models.Vote
+		serviceErr                     error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:          "Success",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"target":    "target",
+				"targetIDs": []string{goframework.NumberUUID(1).String(), goframework.NumberUUID(2).String()},
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithTarget:    "target",
+			shouldCallServiceWithTargetIDs: []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2)},
+			serviceResp: map[uuid.UUID]*models.Vote{
+				goframework.NumberUUID(1): {
+					ID:        goframework.NumberUUID(10),
+					UpdatedAt: baseTime,
+					Vote:      models.VoteValueUp,
+					UserID:    goframework.NumberUUID(100),
+					TargetID:  goframework.NumberUUID(1),
+					Target:    "target",
+				},
+				goframework.NumberUUID(2): nil,
+			},
+			expect: map[string]interface{}{
+				"votes": map[string]interface{}{
+					goframework.NumberUUID(1).String(): map[string]interface{}{
+						"id":        goframework.NumberUUID(10).String(),
+						"updatedAt": baseTime.Format(time.RFC3339),
+						"vote":      "up",
+						"userID":    goframework.NumberUUID(100).String(),
+						"targetID":  goframework.NumberUUID(1).String(),
+						"target":    "target",
+					},
+					goframework.NumberUUID(2).String(): nil,
+				},
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:          "Error/ErrInvalidCredentials",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"target":    "target",
+				"targetIDs": []string{goframework.NumberUUID(1).String()},
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithTarget:    "target",
+			shouldCallServiceWithTargetIDs: []uuid.UUID{goframework.NumberUUID(1)},
+			serviceErr:                     goframework.ErrInvalidCredentials,
+			expectStatus:                   http.StatusForbidden,
+		},
+		{
+			name:          "Error/ErrInvalidEntity",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"target":    "target",
+				"targetIDs": []string{goframework.NumberUUID(1).String()},
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithTarget:    "target",
+			shouldCallServiceWithTargetIDs: []uuid.UUID{goframework.NumberUUID(1)},
+			serviceErr:                     goframework.ErrInvalidEntity,
+			expectStatus:                   http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewGetUserVotesService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			if d.shouldCallService {
+				service.
+					On("Get", c, d.authorization, d.shouldCallServiceWithTarget, d.shouldCallServiceWithTargetIDs).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewGetUserVotesHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
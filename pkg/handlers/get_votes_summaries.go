@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type GetVotesSummariesHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewGetVotesSummariesHandler(service services.GetVotesSummariesService) GetVotesSummariesHandler {
+	return &getVotesSummariesHandlerImpl{
+		service: service,
+	}
+}
+
+type getVotesSummariesHandlerImpl struct {
+	service services.GetVotesSummariesService
+}
+
+func (h *getVotesSummariesHandlerImpl) Handle(c *gin.Context) {
+	request := new(models.GetVotesSummariesForm)
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	summaries, err := h.service.Get(c, request.Target, request.TargetIDs)
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"summaries": summaries})
+}
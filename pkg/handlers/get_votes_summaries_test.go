@@ -0,0 +1,108 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/handlers"
+	"github.com/a-novel/votes-service/pkg/models"
+	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetVotesSummariesHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		body interface{}
+
+		shouldCallService              bool
+		shouldCallServiceWithTarget    string
+		shouldCallServiceWithTargetIDs []uuid.UUID
+		serviceResp                    map[uuid.UUID]*models.VotesSummary
+		serviceErr                     error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name: "Success",
+			body: map[string]interface{}{
+				"target":    "target",
+				"targetIDs": []string{goframework.NumberUUID(1).String(), goframework.NumberUUID(2).String()},
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithTarget:    "target",
+			shouldCallServiceWithTargetIDs: []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2)},
+			serviceResp: map[uuid.UUID]*models.VotesSummary{
+				goframework.NumberUUID(1): {UpVotes: 128, DownVotes: 64, WeightedUpVotes: 128, WeightedDownVotes: 64},
+				goframework.NumberUUID(2): {},
+			},
+			expect: map[string]interface{}{
+				"summaries": map[string]interface{}{
+					goframework.NumberUUID(1).String(): map[string]interface{}{
+						"upVotes":           float64(128),
+						"downVotes":         float64(64),
+						"weightedUpVotes":   float64(128),
+						"weightedDownVotes": float64(64),
+					},
+					goframework.NumberUUID(2).String(): map[string]interface{}{
+						"upVotes":           float64(0),
+						"downVotes":         float64(0),
+						"weightedUpVotes":   float64(0),
+						"weightedDownVotes": float64(0),
+					},
+				},
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name: "Error/ErrInvalidEntity",
+			body: map[string]interface{}{
+				"target":    "target",
+				"targetIDs": []string{goframework.NumberUUID(1).String()},
+			},
+			shouldCallService:              true,
+			shouldCallServiceWithTarget:    "target",
+			shouldCallServiceWithTargetIDs: []uuid.UUID{goframework.NumberUUID(1)},
+			serviceErr:                     goframework.ErrInvalidEntity,
+			expectStatus:                   http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewGetVotesSummariesService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+
+			if d.shouldCallService {
+				service.
+					On("Get", c, d.shouldCallServiceWithTarget, d.shouldCallServiceWithTargetIDs).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewGetVotesSummariesHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
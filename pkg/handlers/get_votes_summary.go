@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"github.com/a-novel/bunovel"
 	"github.com/a-novel/go-apis"
 	"github.com/a-novel/votes-service/pkg/models"
 	"github.com/a-novel/votes-service/pkg/services"
@@ -32,9 +31,7 @@ func (h *getVotesSummaryHandlerImpl) Handle(c *gin.Context) {
 
 	summary, err := h.service.Get(c, query.TargetID.Value(), query.Target)
 	if err != nil {
-		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
-			{bunovel.ErrNotFound, http.StatusNotFound},
-		}, false)
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{}, false)
 		return
 	}
 
@@ -2,7 +2,6 @@ package handlers_test
 
 import (
 	"encoding/json"
-	"github.com/a-novel/bunovel"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/a-novel/votes-service/pkg/handlers"
 	"github.com/a-novel/votes-service/pkg/models"
@@ -37,23 +36,73 @@ func TestGetVotesSummaryHandler(t *testing.T) {
 			shouldCallServiceWithTargetID: goframework.NumberUUID(1),
 			shouldCallServiceWithTarget:   "target",
 			serviceResp: &models.VotesSummary{
-				UpVotes:   128,
-				DownVotes: 64,
+				UpVotes:           128,
+				DownVotes:         64,
+				WeightedUpVotes:   128,
+				WeightedDownVotes: 64,
 			},
 			expect: map[string]interface{}{
-				"upVotes":   float64(128),
-				"downVotes": float64(64),
+				"upVotes":           float64(128),
+				"downVotes":         float64(64),
+				"weightedUpVotes":   float64(128),
+				"weightedDownVotes": float64(64),
 			},
 			expectStatus: http.StatusOK,
 		},
 		{
-			name:                          "Error/ErrNotFound",
+			name:                          "Success/WithScores",
 			query:                         "?targetID=01010101-0101-0101-0101-010101010101&target=target",
 			shouldCallService:             true,
 			shouldCallServiceWithTargetID: goframework.NumberUUID(1),
 			shouldCallServiceWithTarget:   "target",
-			serviceErr:                    bunovel.ErrNotFound,
-			expectStatus:                  http.StatusNotFound,
+			serviceResp: &models.VotesSummary{
+				UpVotes:           10,
+				DownVotes:         10,
+				WeightedUpVotes:   10,
+				WeightedDownVotes: 10,
+				Scores: &models.VotesScores{
+					Ratio:       0.5,
+					Wilson:      0.25,
+					Controversy: 20,
+				},
+			},
+			expect: map[string]interface{}{
+				"upVotes":           float64(10),
+				"downVotes":         float64(10),
+				"weightedUpVotes":   float64(10),
+				"weightedDownVotes": float64(10),
+				"scores": map[string]interface{}{
+					"net":         float64(0),
+					"ratio":       0.5,
+					"wilson":      0.25,
+					"controversy": float64(20),
+				},
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:                          "Success/NoVotes",
+			query:                         "?targetID=01010101-0101-0101-0101-010101010101&target=target",
+			shouldCallService:             true,
+			shouldCallServiceWithTargetID: goframework.NumberUUID(1),
+			shouldCallServiceWithTarget:   "target",
+			serviceResp:                   &models.VotesSummary{},
+			expect: map[string]interface{}{
+				"upVotes":           float64(0),
+				"downVotes":         float64(0),
+				"weightedUpVotes":   float64(0),
+				"weightedDownVotes": float64(0),
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:                          "Error/ServiceFailure",
+			query:                         "?targetID=01010101-0101-0101-0101-010101010101&target=target",
+			shouldCallService:             true,
+			shouldCallServiceWithTargetID: goframework.NumberUUID(1),
+			shouldCallServiceWithTarget:   "target",
+			serviceErr:                    fooErr,
+			expectStatus:                  http.StatusInternalServerError,
 		},
 	}
 
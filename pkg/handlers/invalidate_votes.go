@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type InvalidateVotesHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewInvalidateVotesHandler(service services.InvalidateVotesService) InvalidateVotesHandler {
+	return &invalidateVotesHandlerImpl{
+		service: service,
+	}
+}
+
+type invalidateVotesHandlerImpl struct {
+	service services.InvalidateVotesService
+}
+
+func (h *invalidateVotesHandlerImpl) Handle(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+
+	request := new(models.ModerateVotesForm)
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	count, err := h.service.Invalidate(c, token, *request, time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invalidated": count})
+}
@@ -0,0 +1,115 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/handlers"
+	"github.com/a-novel/votes-service/pkg/models"
+	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInvalidateVotesHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+		body          interface{}
+
+		shouldCallService     bool
+		shouldCallServiceWith models.ModerateVotesForm
+		serviceResp           int
+		serviceErr            error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:          "Success",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"targetID": goframework.NumberUUID(1).String(),
+				"target":   "target",
+				"reason":   "brigading",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.ModerateVotesForm{
+				TargetID: lo.ToPtr(goframework.NumberUUID(1)),
+				Target:   "target",
+				Reason:   "brigading",
+			},
+			serviceResp: 12,
+			expect: map[string]interface{}{
+				"invalidated": float64(12),
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:          "Error/ErrInvalidCredentials",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"userID": goframework.NumberUUID(10).String(),
+				"reason": "vote ring",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+				Reason: "vote ring",
+			},
+			serviceErr:   goframework.ErrInvalidCredentials,
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:          "Error/ErrInvalidEntity",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"target": "target",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.ModerateVotesForm{
+				Target: "target",
+			},
+			serviceErr:   goframework.ErrInvalidEntity,
+			expectStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewInvalidateVotesService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			if d.shouldCallService {
+				service.
+					On("Invalidate", c, d.authorization, d.shouldCallServiceWith, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewInvalidateVotesHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type ListHotTargetsHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewListHotTargetsHandler(service services.ListHotTargetsService) ListHotTargetsHandler {
+	return &listHotTargetsHandlerImpl{
+		service: service,
+	}
+}
+
+type listHotTargetsHandlerImpl struct {
+	service services.ListHotTargetsService
+}
+
+func (h *listHotTargetsHandlerImpl) Handle(c *gin.Context) {
+	query := new(models.ListHotTargetsQuery)
+	if err := c.BindQuery(query); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	targets, err := h.service.List(c, query, time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"targets": targets})
+}
@@ -0,0 +1,99 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/handlers"
+	"github.com/a-novel/votes-service/pkg/models"
+	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListHotTargetsHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		query string
+
+		shouldCallService     bool
+		shouldCallServiceWith *models.ListHotTargetsQuery
+		serviceResp           []*models.RankedTarget
+		serviceErr            error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:              "Success",
+			query:             "?target=target&limit=10&offset=5",
+			shouldCallService: true,
+			shouldCallServiceWith: &models.ListHotTargetsQuery{
+				Target: "target",
+				Limit:  10,
+				Offset: 5,
+			},
+			serviceResp: []*models.RankedTarget{
+				{TargetID: goframework.NumberUUID(1), Score: 1.5},
+				{TargetID: goframework.NumberUUID(2), Score: 0.5},
+			},
+			expect: map[string]interface{}{
+				"targets": []interface{}{
+					map[string]interface{}{
+						"targetID": goframework.NumberUUID(1).String(),
+						"score":    1.5,
+					},
+					map[string]interface{}{
+						"targetID": goframework.NumberUUID(2).String(),
+						"score":    0.5,
+					},
+				},
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:              "Error/ErrInvalidEntity",
+			query:             "?target=target&limit=10&offset=5",
+			shouldCallService: true,
+			shouldCallServiceWith: &models.ListHotTargetsQuery{
+				Target: "target",
+				Limit:  10,
+				Offset: 5,
+			},
+			serviceErr:   goframework.ErrInvalidEntity,
+			expectStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewListHotTargetsService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/"+d.query, nil)
+
+			if d.shouldCallService {
+				service.
+					On("List", c, d.shouldCallServiceWith, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewListHotTargetsHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ListTargetVotesHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewListTargetVotesHandler(service services.ListTargetVotesService) ListTargetVotesHandler {
+	return &listTargetVotesHandlerImpl{
+		service: service,
+	}
+}
+
+type listTargetVotesHandlerImpl struct {
+	service services.ListTargetVotesService
+}
+
+func (h *listTargetVotesHandlerImpl) Handle(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+
+	query := new(models.ListTargetVotesQuery)
+	if err := c.BindQuery(query); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	page, err := h.service.List(c, token, query)
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,143 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/handlers"
+	"github.com/a-novel/votes-service/pkg/models"
+	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListTargetVotesHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+
+		query string
+
+		shouldCallService     bool
+		shouldCallServiceWith *models.ListTargetVotesQuery
+		serviceResp           *models.VotesPage
+		serviceErr            error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:              "Success",
+			authorization:     "Bearer my-token",
+			query:             "?targetID=01010101-0101-0101-0101-010101010101&target=target&vote=up&limit=10&cursor=cursor",
+			shouldCallService: true,
+			shouldCallServiceWith: &models.ListTargetVotesQuery{
+				TargetID: "01010101-0101-0101-0101-010101010101",
+				Target:   "target",
+				Vote:     models.VoteValueUp,
+				Limit:    10,
+				Cursor:   "cursor",
+			},
+			serviceResp: &models.VotesPage{
+				Votes: []*models.Vote{
+					{
+						ID:        goframework.NumberUUID(10),
+						UpdatedAt: baseTime,
+						Vote:      models.VoteValueUp,
+						UserID:    goframework.NumberUUID(100),
+						TargetID:  goframework.NumberUUID(1),
+						Target:    "target",
+					},
+				},
+				Total:      3,
+				NextCursor: "next-cursor",
+			},
+			expect: map[string]interface{}{
+				"votes": []interface{}{
+					map[string]interface{}{
+						"id":        goframework.NumberUUID(10).String(),
+						"updatedAt": baseTime.Format(time.RFC3339),
+						"vote":      "up",
+						"userID":    goframework.NumberUUID(100).String(),
+						"targetID":  goframework.NumberUUID(1).String(),
+						"target":    "target",
+					},
+				},
+				"total":      float64(3),
+				"nextCursor": "next-cursor",
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:              "Error/ErrInvalidCredentials",
+			authorization:     "Bearer my-token",
+			query:             "?targetID=01010101-0101-0101-0101-010101010101&target=target&limit=10",
+			shouldCallService: true,
+			shouldCallServiceWith: &models.ListTargetVotesQuery{
+				TargetID: "01010101-0101-0101-0101-010101010101",
+				Target:   "target",
+				Limit:    10,
+			},
+			serviceErr:   goframework.ErrInvalidCredentials,
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:              "Error/ErrInvalidEntity",
+			authorization:     "Bearer my-token",
+			query:             "?targetID=01010101-0101-0101-0101-010101010101&target=target",
+			shouldCallService: true,
+			shouldCallServiceWith: &models.ListTargetVotesQuery{
+				TargetID: "01010101-0101-0101-0101-010101010101",
+				Target:   "target",
+			},
+			serviceErr:   goframework.ErrInvalidEntity,
+			expectStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "Error/Unknown",
+			authorization:     "Bearer my-token",
+			query:             "?targetID=01010101-0101-0101-0101-010101010101&target=target&limit=10",
+			shouldCallService: true,
+			shouldCallServiceWith: &models.ListTargetVotesQuery{
+				TargetID: "01010101-0101-0101-0101-010101010101",
+				Target:   "target",
+				Limit:    10,
+			},
+			serviceErr:   fooErr,
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewListTargetVotesService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/"+d.query, nil)
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			if d.shouldCallService {
+				service.
+					On("List", c, d.authorization, d.shouldCallServiceWith).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewListTargetVotesHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
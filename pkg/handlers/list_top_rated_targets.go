@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ListTopRatedTargetsHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewListTopRatedTargetsHandler(service services.ListTopRatedTargetsService) ListTopRatedTargetsHandler {
+	return &listTopRatedTargetsHandlerImpl{
+		service: service,
+	}
+}
+
+type listTopRatedTargetsHandlerImpl struct {
+	service services.ListTopRatedTargetsService
+}
+
+func (h *listTopRatedTargetsHandlerImpl) Handle(c *gin.Context) {
+	query := new(models.ListTopRatedTargetsQuery)
+	if err := c.BindQuery(query); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	targets, err := h.service.List(c, query)
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"targets": targets})
+}
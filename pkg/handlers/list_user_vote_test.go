@@ -24,7 +24,7 @@ func TestListUserVoteHandler(t *testing.T) {
 
 		shouldCallService     bool
 		shouldCallServiceWith *models.ListUserVotesQuery
-		serviceResp           []*models.Vote
+		serviceResp           *models.VotesPage
 		serviceErr            error
 
 		expect       interface{}
@@ -33,30 +33,34 @@ func TestListUserVoteHandler(t *testing.T) {
 		{
 			name:              "Success",
 			authorization:     "Bearer my-token",
-			query:             "?target=target&limit=10&offset=5",
+			query:             "?target=target&limit=10&cursor=cursor",
 			shouldCallService: true,
 			shouldCallServiceWith: &models.ListUserVotesQuery{
-				Target: "target",
-				Limit:  10,
-				Offset: 5,
+				Targets: []string{"target"},
+				Limit:   10,
+				Cursor:  "cursor",
 			},
-			serviceResp: []*models.Vote{
-				{
-					ID:        goframework.NumberUUID(10),
-					UpdatedAt: baseTime,
-					Vote:      models.VoteValueUp,
-					UserID:    goframework.NumberUUID(100),
-					TargetID:  goframework.NumberUUID(1),
-					Target:    "target",
-				},
-				{
-					ID:        goframework.NumberUUID(20),
-					UpdatedAt: updateTime,
-					Vote:      models.VoteValueDown,
-					UserID:    goframework.NumberUUID(100),
-					TargetID:  goframework.NumberUUID(2),
-					Target:    "target",
+			serviceResp: &models.VotesPage{
+				Votes: []*models.Vote{
+					{
+						ID:        goframework.NumberUUID(10),
+						UpdatedAt: baseTime,
+						Vote:      models.VoteValueUp,
+						UserID:    goframework.NumberUUID(100),
+						TargetID:  goframework.NumberUUID(1),
+						Target:    "target",
+					},
+					{
+						ID:        goframework.NumberUUID(20),
+						UpdatedAt: updateTime,
+						Vote:      models.VoteValueDown,
+						UserID:    goframework.NumberUUID(100),
+						TargetID:  goframework.NumberUUID(2),
+						Target:    "target",
+					},
 				},
+				Total:      12,
+				NextCursor: "next-cursor",
 			},
 			expect: map[string]interface{}{
 				"votes": []interface{}{
@@ -77,18 +81,59 @@ func TestListUserVoteHandler(t *testing.T) {
 						"target":    "target",
 					},
 				},
+				"total":      float64(12),
+				"nextCursor": "next-cursor",
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:              "Success/LastPage",
+			authorization:     "Bearer my-token",
+			query:             "?target=target&limit=10",
+			shouldCallService: true,
+			shouldCallServiceWith: &models.ListUserVotesQuery{
+				Targets: []string{"target"},
+				Limit:   10,
+			},
+			serviceResp: &models.VotesPage{
+				Votes: []*models.Vote{},
+			},
+			expect: map[string]interface{}{
+				"votes": []interface{}{},
+				"total": float64(0),
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:              "Success/Filters",
+			authorization:     "Bearer my-token",
+			query:             "?target=target&target=other-target&vote=down&since=2020-05-04T08:00:00Z&until=2020-05-04T09:00:00Z&limit=10",
+			shouldCallService: true,
+			shouldCallServiceWith: &models.ListUserVotesQuery{
+				Targets: []string{"target", "other-target"},
+				Vote:    models.VoteValueDown,
+				Since:   baseTime,
+				Until:   updateTime,
+				Limit:   10,
+			},
+			serviceResp: &models.VotesPage{
+				Votes: []*models.Vote{},
+			},
+			expect: map[string]interface{}{
+				"votes": []interface{}{},
+				"total": float64(0),
 			},
 			expectStatus: http.StatusOK,
 		},
 		{
 			name:              "Error/ErrInvalidCredentials",
 			authorization:     "Bearer my-token",
-			query:             "?target=target&limit=10&offset=5",
+			query:             "?target=target&limit=10&cursor=cursor",
 			shouldCallService: true,
 			shouldCallServiceWith: &models.ListUserVotesQuery{
-				Target: "target",
-				Limit:  10,
-				Offset: 5,
+				Targets: []string{"target"},
+				Limit:   10,
+				Cursor:  "cursor",
 			},
 			serviceErr:   goframework.ErrInvalidCredentials,
 			expectStatus: http.StatusForbidden,
@@ -96,12 +141,12 @@ func TestListUserVoteHandler(t *testing.T) {
 		{
 			name:              "Error/ErrInvalidEntity",
 			authorization:     "Bearer my-token",
-			query:             "?target=target&limit=10&offset=5",
+			query:             "?target=target&limit=10&cursor=cursor",
 			shouldCallService: true,
 			shouldCallServiceWith: &models.ListUserVotesQuery{
-				Target: "target",
-				Limit:  10,
-				Offset: 5,
+				Targets: []string{"target"},
+				Limit:   10,
+				Cursor:  "cursor",
 			},
 			serviceErr:   goframework.ErrInvalidEntity,
 			expectStatus: http.StatusUnprocessableEntity,
@@ -32,7 +32,7 @@ func (h *listUserVotesHandlerImpl) Handle(c *gin.Context) {
 		return
 	}
 
-	votes, err := h.service.List(c, token, query)
+	page, err := h.service.List(c, token, query)
 	if err != nil {
 		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
 			{goframework.ErrInvalidCredentials, http.StatusForbidden},
@@ -41,5 +41,5 @@ func (h *listUserVotesHandlerImpl) Handle(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"votes": votes})
+	c.JSON(http.StatusOK, page)
 }
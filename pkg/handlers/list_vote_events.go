@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ListVoteEventsHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewListVoteEventsHandler(service services.ListVoteEventsService) ListVoteEventsHandler {
+	return &listVoteEventsHandlerImpl{
+		service: service,
+	}
+}
+
+type listVoteEventsHandlerImpl struct {
+	service services.ListVoteEventsService
+}
+
+func (h *listVoteEventsHandlerImpl) Handle(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+
+	query := new(models.ListVoteEventsQuery)
+	if err := c.BindQuery(query); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	events, err := h.service.List(c, token, query)
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"events": events})
+}
@@ -0,0 +1,145 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/handlers"
+	"github.com/a-novel/votes-service/pkg/models"
+	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListVoteEventsHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+
+		query string
+
+		shouldCallService     bool
+		shouldCallServiceWith *models.ListVoteEventsQuery
+		serviceResp           []*models.VoteEvent
+		serviceErr            error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:              "Success",
+			authorization:     "Bearer my-token",
+			query:             "?targetID=01010101-0101-0101-0101-010101010101&target=target&limit=10&offset=5",
+			shouldCallService: true,
+			shouldCallServiceWith: &models.ListVoteEventsQuery{
+				TargetID: "01010101-0101-0101-0101-010101010101",
+				Target:   "target",
+				Limit:    10,
+				Offset:   5,
+			},
+			serviceResp: []*models.VoteEvent{
+				{
+					ID:        2,
+					CreatedAt: updateTime,
+					Event:     models.VoteEventTypeFlip,
+					UserID:    goframework.NumberUUID(10),
+					TargetID:  goframework.NumberUUID(1),
+					Target:    "target",
+					OldVote:   lo.ToPtr(models.VoteValueUp),
+					NewVote:   lo.ToPtr(models.VoteValueDown),
+				},
+				{
+					ID:        1,
+					CreatedAt: baseTime,
+					Event:     models.VoteEventTypeCast,
+					UserID:    goframework.NumberUUID(10),
+					TargetID:  goframework.NumberUUID(1),
+					Target:    "target",
+					NewVote:   lo.ToPtr(models.VoteValueUp),
+				},
+			},
+			expect: map[string]interface{}{
+				"events": []interface{}{
+					map[string]interface{}{
+						"id":        float64(2),
+						"createdAt": updateTime.Format(time.RFC3339),
+						"event":     "flip",
+						"userID":    goframework.NumberUUID(10).String(),
+						"targetID":  goframework.NumberUUID(1).String(),
+						"target":    "target",
+						"oldVote":   "up",
+						"newVote":   "down",
+					},
+					map[string]interface{}{
+						"id":        float64(1),
+						"createdAt": baseTime.Format(time.RFC3339),
+						"event":     "cast",
+						"userID":    goframework.NumberUUID(10).String(),
+						"targetID":  goframework.NumberUUID(1).String(),
+						"target":    "target",
+						"oldVote":   nil,
+						"newVote":   "up",
+					},
+				},
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:              "Error/ErrInvalidCredentials",
+			authorization:     "Bearer my-token",
+			query:             "?userID=01010101-0101-0101-0101-010101010101&limit=10",
+			shouldCallService: true,
+			shouldCallServiceWith: &models.ListVoteEventsQuery{
+				UserID: "01010101-0101-0101-0101-010101010101",
+				Limit:  10,
+			},
+			serviceErr:   goframework.ErrInvalidCredentials,
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:              "Error/ErrInvalidEntity",
+			authorization:     "Bearer my-token",
+			query:             "?limit=10",
+			shouldCallService: true,
+			shouldCallServiceWith: &models.ListVoteEventsQuery{
+				Limit: 10,
+			},
+			serviceErr:   goframework.ErrInvalidEntity,
+			expectStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewListVoteEventsService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/"+d.query, nil)
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			if d.shouldCallService {
+				service.
+					On("List", c, d.authorization, d.shouldCallServiceWith).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewListVoteEventsHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
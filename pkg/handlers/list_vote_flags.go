@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ListVoteFlagsHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewListVoteFlagsHandler(service services.ListVoteFlagsService) ListVoteFlagsHandler {
+	return &listVoteFlagsHandlerImpl{
+		service: service,
+	}
+}
+
+type listVoteFlagsHandlerImpl struct {
+	service services.ListVoteFlagsService
+}
+
+func (h *listVoteFlagsHandlerImpl) Handle(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+
+	query := new(models.ListVoteFlagsQuery)
+	if err := c.BindQuery(query); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	flags, err := h.service.List(c, token, query)
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"flags": flags})
+}
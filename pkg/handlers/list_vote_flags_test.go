@@ -0,0 +1,126 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/handlers"
+	"github.com/a-novel/votes-service/pkg/models"
+	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListVoteFlagsHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+
+		query string
+
+		shouldCallService     bool
+		shouldCallServiceWith *models.ListVoteFlagsQuery
+		serviceResp           []*models.VoteFlag
+		serviceErr            error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:              "Success",
+			authorization:     "Bearer my-token",
+			query:             "?kind=new_voters&status=pending&limit=10&offset=5",
+			shouldCallService: true,
+			shouldCallServiceWith: &models.ListVoteFlagsQuery{
+				Kind:   models.VoteFlagKindNewVoters,
+				Status: models.VoteFlagStatusPending,
+				Limit:  10,
+				Offset: 5,
+			},
+			serviceResp: []*models.VoteFlag{
+				{
+					ID:          goframework.NumberUUID(1),
+					CreatedAt:   baseTime,
+					Kind:        models.VoteFlagKindNewVoters,
+					Target:      "target",
+					TargetIDs:   []uuid.UUID{goframework.NumberUUID(10)},
+					UserIDs:     []uuid.UUID{goframework.NumberUUID(20), goframework.NumberUUID(21)},
+					Occurrences: 2,
+					Status:      models.VoteFlagStatusPending,
+				},
+			},
+			expect: map[string]interface{}{
+				"flags": []interface{}{
+					map[string]interface{}{
+						"id":          goframework.NumberUUID(1).String(),
+						"createdAt":   baseTime.Format(time.RFC3339),
+						"kind":        "new_voters",
+						"target":      "target",
+						"targetIDs":   []interface{}{goframework.NumberUUID(10).String()},
+						"userIDs":     []interface{}{goframework.NumberUUID(20).String(), goframework.NumberUUID(21).String()},
+						"occurrences": float64(2),
+						"status":      "pending",
+					},
+				},
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:              "Error/ErrInvalidCredentials",
+			authorization:     "Bearer my-token",
+			query:             "?limit=10",
+			shouldCallService: true,
+			shouldCallServiceWith: &models.ListVoteFlagsQuery{
+				Limit: 10,
+			},
+			serviceErr:   goframework.ErrInvalidCredentials,
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:              "Error/ErrInvalidEntity",
+			authorization:     "Bearer my-token",
+			query:             "?kind=foo&limit=10",
+			shouldCallService: true,
+			shouldCallServiceWith: &models.ListVoteFlagsQuery{
+				Kind:  "foo",
+				Limit: 10,
+			},
+			serviceErr:   goframework.ErrInvalidEntity,
+			expectStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewListVoteFlagsService(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/"+d.query, nil)
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			if d.shouldCallService {
+				service.
+					On("List", c, d.authorization, d.shouldCallServiceWith).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewListVoteFlagsHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
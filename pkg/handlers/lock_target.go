@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type LockTargetHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewLockTargetHandler(service services.LockTargetService) LockTargetHandler {
+	return &lockTargetHandlerImpl{
+		service: service,
+	}
+}
+
+type lockTargetHandlerImpl struct {
+	service services.LockTargetService
+}
+
+func (h *lockTargetHandlerImpl) Handle(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+
+	request := new(models.LockTargetForm)
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	lock, err := h.service.Lock(c, token, *request, time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, lock)
+}
@@ -0,0 +1,128 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/handlers"
+	"github.com/a-novel/votes-service/pkg/models"
+	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLockTargetHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+		body          interface{}
+
+		shouldCallService     bool
+		shouldCallServiceWith models.LockTargetForm
+		serviceResp           *models.TargetLock
+		serviceErr            error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:          "Success",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"targetID": goframework.NumberUUID(1).String(),
+				"target":   "target",
+				"closesAt": updateTime.Format(time.RFC3339),
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.LockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				ClosesAt: lo.ToPtr(updateTime),
+			},
+			serviceResp: &models.TargetLock{
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				CreatedAt: baseTime,
+				LockedBy:  goframework.NumberUUID(100),
+				ClosesAt:  updateTime,
+			},
+			expect: map[string]interface{}{
+				"targetID":  goframework.NumberUUID(1).String(),
+				"target":    "target",
+				"createdAt": baseTime.Format(time.RFC3339),
+				"lockedBy":  goframework.NumberUUID(100).String(),
+				"closesAt":  updateTime.Format(time.RFC3339),
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:          "Error/ErrInvalidCredentials",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"targetID": goframework.NumberUUID(1).String(),
+				"target":   "target",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.LockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			serviceErr:   goframework.ErrInvalidCredentials,
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:          "Error/ErrInvalidEntity",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"targetID": goframework.NumberUUID(1).String(),
+				"target":   "foo",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.LockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "foo",
+			},
+			serviceErr:   goframework.ErrInvalidEntity,
+			expectStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewLockTargetService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			if d.shouldCallService {
+				service.
+					On("Lock", c, d.authorization, d.shouldCallServiceWith, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewLockTargetHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
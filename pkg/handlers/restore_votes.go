@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type RestoreVotesHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewRestoreVotesHandler(service services.RestoreVotesService) RestoreVotesHandler {
+	return &restoreVotesHandlerImpl{
+		service: service,
+	}
+}
+
+type restoreVotesHandlerImpl struct {
+	service services.RestoreVotesService
+}
+
+func (h *restoreVotesHandlerImpl) Handle(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+
+	request := new(models.ModerateVotesForm)
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	count, err := h.service.Restore(c, token, *request, time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"restored": count})
+}
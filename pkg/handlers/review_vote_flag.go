@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type ReviewVoteFlagHandler interface {
+	Handle(c *gin.Context)
+}
+
+func NewReviewVoteFlagHandler(service services.ReviewVoteFlagService) ReviewVoteFlagHandler {
+	return &reviewVoteFlagHandlerImpl{
+		service: service,
+	}
+}
+
+type reviewVoteFlagHandlerImpl struct {
+	service services.ReviewVoteFlagService
+}
+
+func (h *reviewVoteFlagHandlerImpl) Handle(c *gin.Context) {
+	token := c.GetHeader("Authorization")
+
+	request := new(models.ReviewVoteFlagForm)
+	if err := c.BindJSON(request); err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	flag, err := h.service.Review(c, token, *request, time.Now())
+	if err != nil {
+		apis.ErrorToHTTPCode(c, err, []apis.HTTPError{
+			{goframework.ErrInvalidCredentials, http.StatusForbidden},
+			{goframework.ErrInvalidEntity, http.StatusUnprocessableEntity},
+			{bunovel.ErrNotFound, http.StatusNotFound},
+			{services.ErrVoteFlagPending, http.StatusConflict},
+		}, false)
+		return
+	}
+
+	c.JSON(http.StatusOK, flag)
+}
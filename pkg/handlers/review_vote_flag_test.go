@@ -0,0 +1,169 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/handlers"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReviewVoteFlagHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+		body          interface{}
+
+		shouldCallService     bool
+		shouldCallServiceWith models.ReviewVoteFlagForm
+		serviceResp           *models.VoteFlag
+		serviceErr            error
+
+		expect       interface{}
+		expectStatus int
+	}{
+		{
+			name:          "Success",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"id":     goframework.NumberUUID(1).String(),
+				"status": "dismissed",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.ReviewVoteFlagForm{
+				ID:     goframework.NumberUUID(1),
+				Status: models.VoteFlagStatusDismissed,
+			},
+			serviceResp: &models.VoteFlag{
+				ID:          goframework.NumberUUID(1),
+				CreatedAt:   baseTime,
+				Kind:        models.VoteFlagKindFlipBurst,
+				Target:      "target",
+				TargetIDs:   []uuid.UUID{goframework.NumberUUID(10)},
+				UserIDs:     []uuid.UUID{goframework.NumberUUID(20)},
+				Occurrences: 30,
+				Status:      models.VoteFlagStatusDismissed,
+				ReviewedBy:  lo.ToPtr(goframework.NumberUUID(100)),
+				ReviewedAt:  lo.ToPtr(updateTime),
+			},
+			expect: map[string]interface{}{
+				"id":          goframework.NumberUUID(1).String(),
+				"createdAt":   baseTime.Format(time.RFC3339),
+				"kind":        "flip_burst",
+				"target":      "target",
+				"targetIDs":   []interface{}{goframework.NumberUUID(10).String()},
+				"userIDs":     []interface{}{goframework.NumberUUID(20).String()},
+				"occurrences": float64(30),
+				"status":      "dismissed",
+				"reviewedBy":  goframework.NumberUUID(100).String(),
+				"reviewedAt":  updateTime.Format(time.RFC3339),
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:          "Error/ErrNotFound",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"id":     goframework.NumberUUID(1).String(),
+				"status": "confirmed",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.ReviewVoteFlagForm{
+				ID:     goframework.NumberUUID(1),
+				Status: models.VoteFlagStatusConfirmed,
+			},
+			serviceErr:   bunovel.ErrNotFound,
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:          "Error/ErrVoteFlagPending",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"id":     goframework.NumberUUID(1).String(),
+				"status": "pending",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.ReviewVoteFlagForm{
+				ID:     goframework.NumberUUID(1),
+				Status: models.VoteFlagStatusPending,
+			},
+			serviceErr:   services.ErrVoteFlagPending,
+			expectStatus: http.StatusConflict,
+		},
+		{
+			name:          "Error/ErrInvalidCredentials",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"id":     goframework.NumberUUID(1).String(),
+				"status": "confirmed",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.ReviewVoteFlagForm{
+				ID:     goframework.NumberUUID(1),
+				Status: models.VoteFlagStatusConfirmed,
+			},
+			serviceErr:   goframework.ErrInvalidCredentials,
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:          "Error/ErrInvalidEntity",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"id":     goframework.NumberUUID(1).String(),
+				"status": "foo",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.ReviewVoteFlagForm{
+				ID:     goframework.NumberUUID(1),
+				Status: "foo",
+			},
+			serviceErr:   goframework.ErrInvalidEntity,
+			expectStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewReviewVoteFlagService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			if d.shouldCallService {
+				service.
+					On("Review", c, d.authorization, d.shouldCallServiceWith, mock.Anything).
+					Return(d.serviceResp, d.serviceErr)
+			}
+
+			handler := handlers.NewReviewVoteFlagHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+			if d.expect != nil {
+				var body interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				require.Equal(t, d.expect, body)
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
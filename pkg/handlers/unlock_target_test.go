@@ -0,0 +1,103 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/handlers"
+	"github.com/a-novel/votes-service/pkg/models"
+	servicesmocks "github.com/a-novel/votes-service/pkg/services/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUnlockTargetHandler(t *testing.T) {
+	data := []struct {
+		name string
+
+		authorization string
+		body          interface{}
+
+		shouldCallService     bool
+		shouldCallServiceWith models.UnlockTargetForm
+		serviceErr            error
+
+		expectStatus int
+	}{
+		{
+			name:          "Success",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"targetID": goframework.NumberUUID(1).String(),
+				"target":   "target",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.UnlockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			expectStatus: http.StatusNoContent,
+		},
+		{
+			name:          "Error/ErrNotFound",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"targetID": goframework.NumberUUID(1).String(),
+				"target":   "target",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.UnlockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			serviceErr:   bunovel.ErrNotFound,
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:          "Error/ErrInvalidCredentials",
+			authorization: "Bearer my-token",
+			body: map[string]interface{}{
+				"targetID": goframework.NumberUUID(1).String(),
+				"target":   "target",
+			},
+			shouldCallService: true,
+			shouldCallServiceWith: models.UnlockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			serviceErr:   goframework.ErrInvalidCredentials,
+			expectStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			service := servicesmocks.NewUnlockTargetService(t)
+
+			mrshBody, err := json.Marshal(d.body)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", bytes.NewReader(mrshBody))
+			c.Request.Header.Set("Authorization", d.authorization)
+
+			if d.shouldCallService {
+				service.
+					On("Unlock", c, d.authorization, d.shouldCallServiceWith).
+					Return(d.serviceErr)
+			}
+
+			handler := handlers.NewUnlockTargetHandler(service)
+			handler.Handle(c)
+
+			require.Equal(t, d.expectStatus, w.Code, c.Errors.String())
+
+			service.AssertExpectations(t)
+		})
+	}
+}
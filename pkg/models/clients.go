@@ -5,4 +5,35 @@ import (
 	"github.com/google/uuid"
 )
 
-type CheckVoteClient func(ctx context.Context, id, userID uuid.UUID, upVotes, downVotes int) error
+// TargetHandler connects the votes to the service that owns their target.
+type TargetHandler interface {
+	// Authorize makes sure a user is allowed to vote on a target. It is called synchronously, before the vote is
+	// saved.
+	Authorize(ctx context.Context, userID, targetID uuid.UUID) error
+	// Publish sends the updated counters of a target to its owner. It is called asynchronously, from the outbox.
+	Publish(ctx context.Context, update *TargetUpdate) error
+	// IsOwner reports whether a user is the author of a target, such as the author of a post. Handlers that cannot
+	// tell report no owner.
+	IsOwner(ctx context.Context, userID, targetID uuid.UUID) (bool, error)
+}
+
+// TargetUpdate carries the latest counters of a target. Successive votes on a target are merged into a single update,
+// so UserID is the author of the last one.
+type TargetUpdate struct {
+	Target   string
+	TargetID uuid.UUID
+	UserID   uuid.UUID
+	Summary  *VotesSummary
+	// Weighted is true when the target weighs its votes.
+	Weighted bool
+}
+
+// Counts returns the counters the target should display: the weighted ones when the target weighs its votes, the
+// raw ones otherwise.
+func (update *TargetUpdate) Counts() (upVotes int, downVotes int) {
+	if update.Weighted {
+		return update.Summary.WeightedUpVotes, update.Summary.WeightedDownVotes
+	}
+
+	return update.Summary.UpVotes, update.Summary.DownVotes
+}
@@ -1,9 +1,79 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 type VoteForm struct {
 	TargetID uuid.UUID  `json:"targetID" form:"targetID"`
 	Target   string     `json:"target" form:"target"`
 	Vote     *VoteValue `json:"vote" form:"vote"`
+	// Score rates a rated target, instead of Vote. Omitting it removes the current rating.
+	Score *int `json:"score,omitempty" form:"score"`
+	// ExpectedVote makes the cast conditional: it is rejected if the current vote of the user differs. An empty value
+	// expects the user to have no vote on the target. The cast is unconditional when the field is omitted.
+	ExpectedVote *VoteValue `json:"expectedVote,omitempty" form:"expectedVote"`
+}
+
+type GetVotesSummariesForm struct {
+	Target    string      `json:"target" form:"target"`
+	TargetIDs []uuid.UUID `json:"targetIDs" form:"targetIDs"`
+}
+
+type GetUserVotesForm struct {
+	Target    string      `json:"target" form:"target"`
+	TargetIDs []uuid.UUID `json:"targetIDs" form:"targetIDs"`
+}
+
+type ReviewVoteFlagForm struct {
+	ID     uuid.UUID      `json:"id" form:"id"`
+	Status VoteFlagStatus `json:"status" form:"status"`
+}
+
+// ModerateVotesForm selects the votes of a user, the votes on a target, or the votes of a user on a target.
+type ModerateVotesForm struct {
+	UserID   *uuid.UUID `json:"userID,omitempty" form:"userID"`
+	TargetID *uuid.UUID `json:"targetID,omitempty" form:"targetID"`
+	Target   string     `json:"target" form:"target"`
+	// Reason is required to invalidate votes, and ignored when restoring them.
+	Reason string `json:"reason" form:"reason"`
+}
+
+type LockTargetForm struct {
+	TargetID uuid.UUID `json:"targetID" form:"targetID"`
+	Target   string    `json:"target" form:"target"`
+	// ClosesAt schedules the close of the target. The target is locked right away when it is omitted.
+	ClosesAt *time.Time `json:"closesAt,omitempty" form:"closesAt"`
+}
+
+type UnlockTargetForm struct {
+	TargetID uuid.UUID `json:"targetID" form:"targetID"`
+	Target   string    `json:"target" form:"target"`
+}
+
+type CreatePollForm struct {
+	Question    string   `json:"question" form:"question"`
+	Options     []string `json:"options" form:"options"`
+	MultiSelect bool     `json:"multiSelect" form:"multiSelect"`
+	// OpensAt schedules the opening of the poll. It opens right away when omitted.
+	OpensAt *time.Time `json:"opensAt,omitempty" form:"opensAt"`
+	// ClosesAt is required when the results are hidden.
+	ClosesAt    *time.Time `json:"closesAt,omitempty" form:"closesAt"`
+	HideResults bool       `json:"hideResults" form:"hideResults"`
+	// Ranked polls cannot be multi-select.
+	Ranked bool `json:"ranked" form:"ranked"`
+}
+
+type CastBallotForm struct {
+	PollID uuid.UUID `json:"pollID" form:"pollID"`
+	// Choices are the positions of the chosen options.
+	Choices []int `json:"choices" form:"choices"`
+}
+
+type CastRankingForm struct {
+	PollID uuid.UUID `json:"pollID" form:"pollID"`
+	// Ranking lists the positions of the ranked options, from the most to the least preferred. Users do not need to
+	// rank every option.
+	Ranking []int `json:"ranking" form:"ranking"`
 }
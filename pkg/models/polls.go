@@ -0,0 +1,82 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Poll struct {
+	ID        uuid.UUID `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	CreatedBy uuid.UUID `json:"createdBy"`
+
+	Question string `json:"question"`
+	// Options are identified by their position in the list.
+	Options     []string  `json:"options"`
+	MultiSelect bool      `json:"multiSelect"`
+	OpensAt     time.Time `json:"opensAt"`
+	// ClosesAt is nil when the poll accepts ballots forever.
+	ClosesAt *time.Time `json:"closesAt,omitempty"`
+	// HideResults hides the results until the poll closes.
+	HideResults bool `json:"hideResults"`
+	// Ranked polls take ordered ballots, and elect a single option through instant runoff.
+	Ranked bool `json:"ranked"`
+}
+
+// IsOpen reports whether the poll accepts ballots at the given date.
+func (poll *Poll) IsOpen(now time.Time) bool {
+	return !now.Before(poll.OpensAt) && (poll.ClosesAt == nil || now.Before(*poll.ClosesAt))
+}
+
+// IsClosed reports whether the poll stopped accepting ballots at the given date.
+func (poll *Poll) IsClosed(now time.Time) bool {
+	return poll.ClosesAt != nil && !now.Before(*poll.ClosesAt)
+}
+
+type PollBallot struct {
+	PollID    uuid.UUID `json:"pollID"`
+	UserID    uuid.UUID `json:"userID"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Choices are the positions of the chosen options.
+	Choices []int `json:"choices"`
+}
+
+type PollResults struct {
+	PollID uuid.UUID `json:"pollID"`
+	// Ballots is the number of users who took part in the poll. On multi-select polls, it can be lower than the sum
+	// of the votes.
+	Ballots int `json:"ballots"`
+	// Votes gives the number of ballots that chose each option, in the order of the options.
+	Votes []int `json:"votes"`
+	// Final is true once the poll is closed, and its results cannot change anymore.
+	Final bool `json:"final"`
+}
+
+type PollRanking struct {
+	PollID    uuid.UUID `json:"pollID"`
+	UserID    uuid.UUID `json:"userID"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Ranking lists the positions of the ranked options, from the most to the least preferred.
+	Ranking []int `json:"ranking"`
+}
+
+type RunoffRound struct {
+	// Votes gives the number of ballots counted for each option, in the order of the options. Eliminated options
+	// have no votes.
+	Votes []int `json:"votes"`
+	// Exhausted is the number of ballots whose ranked options were all eliminated.
+	Exhausted int `json:"exhausted"`
+	// Eliminated is the option eliminated at the end of the round. It is nil on the last round.
+	Eliminated *int `json:"eliminated,omitempty"`
+}
+
+type RunoffResults struct {
+	PollID  uuid.UUID `json:"pollID"`
+	Ballots int       `json:"ballots"`
+	// Rounds lists the counts of each round of the runoff, the first round counting the first preferences.
+	Rounds []*RunoffRound `json:"rounds"`
+	// Winner is nil when no ballot was cast.
+	Winner *int `json:"winner,omitempty"`
+	// Final is true once the poll is closed, and its results cannot change anymore.
+	Final bool `json:"final"`
+}
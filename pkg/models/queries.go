@@ -2,20 +2,71 @@ package models
 
 import (
 	"github.com/a-novel/go-apis"
+	"time"
 )
 
 type ListUserVotesQuery struct {
+	// Targets restricts the list to some targets. Leave empty to list the votes on every target.
+	Targets []string  `json:"targets" form:"target"`
+	Vote    VoteValue `json:"vote" form:"vote"`
+	// Since and Until bound the date of the last update of the votes, inclusively. Zero values are ignored.
+	Since time.Time `json:"since" form:"since"`
+	Until time.Time `json:"until" form:"until"`
+	Limit int       `json:"limit" form:"limit"`
+	// Cursor is the nextCursor of the previous page. Leave empty to get the first page.
+	Cursor string `json:"cursor" form:"cursor"`
+}
+
+type GetUserVoteQuery struct {
+	TargetID apis.StringUUID `json:"targetID" form:"targetID"`
+	Target   string          `json:"target" form:"target"`
+}
+
+type GetVotesSummaryQuery struct {
+	TargetID apis.StringUUID `json:"targetID" form:"targetID"`
+	Target   string          `json:"target" form:"target"`
+}
+
+type GetPollQuery struct {
+	ID apis.StringUUID `json:"id" form:"id"`
+}
+
+type ListHotTargetsQuery struct {
 	Target string `json:"target" form:"target"`
 	Limit  int    `json:"limit" form:"limit"`
 	Offset int    `json:"offset" form:"offset"`
 }
 
-type GetUserVoteQuery struct {
+type ListTopRatedTargetsQuery struct {
+	Target string `json:"target" form:"target"`
+	// Bayesian ranks the targets by their Bayesian average, so targets with few ratings do not outrank the others.
+	Bayesian bool `json:"bayesian" form:"bayesian"`
+	Limit    int  `json:"limit" form:"limit"`
+	Offset   int  `json:"offset" form:"offset"`
+}
+
+type ListVoteEventsQuery struct {
+	UserID   apis.StringUUID `json:"userID" form:"userID"`
 	TargetID apis.StringUUID `json:"targetID" form:"targetID"`
 	Target   string          `json:"target" form:"target"`
+	Limit    int             `json:"limit" form:"limit"`
+	Offset   int             `json:"offset" form:"offset"`
 }
 
-type GetVotesSummaryQuery struct {
+type ListTargetVotesQuery struct {
 	TargetID apis.StringUUID `json:"targetID" form:"targetID"`
 	Target   string          `json:"target" form:"target"`
+	Vote     VoteValue       `json:"vote" form:"vote"`
+	Limit    int             `json:"limit" form:"limit"`
+	// Cursor is the nextCursor of the previous page. Leave empty to get the first page.
+	Cursor string `json:"cursor" form:"cursor"`
+	// Invalidated also lists the votes discarded by moderators. Only moderators can set it.
+	Invalidated bool `json:"invalidated" form:"invalidated"`
+}
+
+type ListVoteFlagsQuery struct {
+	Kind   VoteFlagKind   `json:"kind" form:"kind"`
+	Status VoteFlagStatus `json:"status" form:"status"`
+	Limit  int            `json:"limit" form:"limit"`
+	Offset int            `json:"offset" form:"offset"`
 }
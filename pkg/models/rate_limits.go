@@ -0,0 +1,39 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RateLimit is a token bucket: up to Burst actions are allowed at once, then one action every Interval. A zero Burst
+// disables the limit.
+type RateLimit struct {
+	Burst    int
+	Interval time.Duration
+}
+
+// RateLimitBucket is the bucket of the actions performed under a key, limited by Limit.
+type RateLimitBucket struct {
+	Key   string
+	Limit RateLimit
+}
+
+// RateLimiter keeps track of the actions performed under a key.
+type RateLimiter interface {
+	// Allow consumes a token from each bucket of an action, only if all of them have one, so an action rejected by a
+	// limit does not count against the others. Otherwise, no token is consumed, and the delay until every bucket has
+	// a token is returned.
+	Allow(ctx context.Context, buckets []RateLimitBucket, now time.Time) (time.Duration, error)
+	// Purge forgets the buckets that refilled completely, as they are the same as new buckets.
+	Purge(ctx context.Context, now time.Time) error
+}
+
+// RateLimitError is returned when an action is rejected by a RateLimiter.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (err *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limited: retry after %s", err.RetryAfter)
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TargetLock closes a target to votes. A lock with a future ClosesAt is a scheduled close: votes are accepted until
+// then.
+type TargetLock struct {
+	TargetID  uuid.UUID `json:"targetID"`
+	Target    string    `json:"target"`
+	CreatedAt time.Time `json:"createdAt"`
+	LockedBy  uuid.UUID `json:"lockedBy"`
+	ClosesAt  time.Time `json:"closesAt"`
+}
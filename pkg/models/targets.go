@@ -0,0 +1,39 @@
+package models
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// DefaultVoteWeight is the weight of the votes on targets that do not weigh them.
+const DefaultVoteWeight = 1
+
+// Target is a kind of entity users can vote on.
+type Target struct {
+	Name string
+	// Values lists the votes accepted on the target. Removing a vote is always allowed while the target is open.
+	Values []VoteValue
+	// Rating is set on targets users rate with a score, rather than vote on. Values is then VoteValueRating only.
+	Rating *RatingScale
+	// Open is false when the target does not accept votes anymore.
+	Open bool
+	// AuthorsSeeVoters lets the authors of the target see who voted on it, besides the moderators. Authors are
+	// resolved by the Handler.
+	AuthorsSeeVoters bool
+
+	Handler TargetHandler
+	// WeightResolver is nil when every vote on the target weighs DefaultVoteWeight. Otherwise, the target is
+	// notified of its weighted counters.
+	WeightResolver WeightResolver
+}
+
+// RatingScale is the range of the scores accepted on a rated target, bounds included.
+type RatingScale struct {
+	Min int
+	Max int
+}
+
+// WeightResolver gives the weight of a vote, when it is cast.
+type WeightResolver interface {
+	Resolve(ctx context.Context, userID, targetID uuid.UUID) (int, error)
+}
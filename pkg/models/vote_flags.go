@@ -0,0 +1,45 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type VoteFlagKind string
+
+var (
+	// VoteFlagKindCoVoting flags groups of users who repeatedly voted the same way on the same targets, within
+	// minutes of each other.
+	VoteFlagKindCoVoting VoteFlagKind = "co_voting"
+	// VoteFlagKindNewVoters flags targets that received the same vote from many users who only started voting
+	// recently.
+	VoteFlagKindNewVoters VoteFlagKind = "new_voters"
+	// VoteFlagKindFlipBurst flags users who changed many of their votes in a short time.
+	VoteFlagKindFlipBurst VoteFlagKind = "flip_burst"
+)
+
+type VoteFlagStatus string
+
+var (
+	VoteFlagStatusPending   VoteFlagStatus = "pending"
+	VoteFlagStatusConfirmed VoteFlagStatus = "confirmed"
+	VoteFlagStatusDismissed VoteFlagStatus = "dismissed"
+)
+
+// VoteFlag is a suspicious voting pattern, waiting for a moderator to review it.
+type VoteFlag struct {
+	ID        uuid.UUID `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	Kind      VoteFlagKind `json:"kind"`
+	Target    string       `json:"target"`
+	TargetIDs []uuid.UUID  `json:"targetIDs"`
+	UserIDs   []uuid.UUID  `json:"userIDs"`
+	// Occurrences measures the strength of the pattern: the number of targets voted on together, the number of
+	// new voters, or the number of changed votes, depending on the kind.
+	Occurrences int `json:"occurrences"`
+
+	Status     VoteFlagStatus `json:"status"`
+	ReviewedBy *uuid.UUID     `json:"reviewedBy,omitempty"`
+	ReviewedAt *time.Time     `json:"reviewedAt,omitempty"`
+}
@@ -1,15 +1,36 @@
 package models
 
 import (
+	"fmt"
 	"github.com/google/uuid"
+	"regexp"
 	"time"
 )
 
+// VoteValue is a value from the vocabulary of a target. Up and down are the classic values, and the only ones counted
+// in the up and down votes of a summary, but targets may accept any other value, such as reactions.
 type VoteValue string
 
 var (
 	VoteValueUp   VoteValue = "up"
 	VoteValueDown VoteValue = "down"
+	// VoteValueRating is the value of every vote on a rated target. The vote itself carries the score.
+	VoteValueRating VoteValue = "rating"
+)
+
+var voteValueRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// IsValid reports whether the value is well-formed: a lowercase identifier, up to 32 characters long.
+func (value VoteValue) IsValid() bool {
+	return voteValueRegexp.MatchString(string(value))
+}
+
+type VoteEventType string
+
+var (
+	VoteEventTypeCast    VoteEventType = "cast"
+	VoteEventTypeFlip    VoteEventType = "flip"
+	VoteEventTypeRetract VoteEventType = "retract"
 )
 
 type Vote struct {
@@ -20,9 +41,87 @@ type Vote struct {
 	UserID   uuid.UUID `json:"userID"`
 	TargetID uuid.UUID `json:"targetID"`
 	Target   string    `json:"target"`
+	// Score is only set on the votes of rated targets.
+	Score *int `json:"score,omitempty"`
+
+	// InvalidatedAt is set when a moderator discarded the vote. It does not count in the summary of the target.
+	InvalidatedAt *time.Time `json:"invalidatedAt,omitempty"`
+}
+
+// VoteConflictError is returned when a conditional cast expected another current vote. It is sent back to the
+// client, so it can reconcile its state.
+type VoteConflictError struct {
+	// CurrentVote is nil when the user has no vote on the target.
+	CurrentVote *VoteValue `json:"currentVote"`
+}
+
+func (err *VoteConflictError) Error() string {
+	if err.CurrentVote == nil {
+		return "vote conflict: no current vote"
+	}
+
+	return fmt.Sprintf("vote conflict: current vote is %s", *err.CurrentVote)
+}
+
+type VotesPage struct {
+	Votes []*Vote `json:"votes"`
+	// Total is the number of votes matching the query, across all pages.
+	Total int `json:"total"`
+	// NextCursor is empty on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 type VotesSummary struct {
 	UpVotes   int `json:"upVotes"`
 	DownVotes int `json:"downVotes"`
+	// WeightedUpVotes and WeightedDownVotes sum the weights of the votes. They match the raw counts on targets that
+	// do not weigh their votes.
+	WeightedUpVotes   int `json:"weightedUpVotes"`
+	WeightedDownVotes int `json:"weightedDownVotes"`
+	// Counts gives the number of votes for each value of the target. Values without votes are omitted.
+	Counts map[VoteValue]int `json:"counts,omitempty"`
+	// Rating is only set on rated targets, once they have been rated at least once.
+	Rating *RatingSummary `json:"rating,omitempty"`
+
+	Scores *VotesScores `json:"scores,omitempty"`
+}
+
+type RatingSummary struct {
+	Count   int     `json:"count"`
+	Average float64 `json:"average"`
+	// Histogram gives the number of ratings for each score. Scores without ratings are omitted.
+	Histogram map[int]int `json:"histogram"`
+}
+
+// VotesScores are computed from the raw counts of a summary, so every consumer sorts targets the same way.
+type VotesScores struct {
+	// Net is the difference between up and down votes.
+	Net int `json:"net"`
+	// Ratio is the proportion of up votes, between 0 and 1.
+	Ratio float64 `json:"ratio"`
+	// Wilson is the lower bound of the Wilson score confidence interval for the ratio of up votes. It is the
+	// preferred value for sorting, as it does not favor targets with few votes.
+	Wilson float64 `json:"wilson"`
+	// Controversy grows with the number of votes, and the closer the up and down votes are.
+	Controversy float64 `json:"controversy"`
+}
+
+type RankedTarget struct {
+	TargetID uuid.UUID `json:"targetID"`
+	Score    float64   `json:"score"`
+}
+
+type VoteEvent struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	Event    VoteEventType `json:"event"`
+	UserID   uuid.UUID     `json:"userID"`
+	TargetID uuid.UUID     `json:"targetID"`
+	Target   string        `json:"target"`
+	OldVote  *VoteValue    `json:"oldVote"`
+	NewVote  *VoteValue    `json:"newVote"`
+	// OldScore and NewScore are only set on the events of rated targets.
+	OldScore *int `json:"oldScore,omitempty"`
+	NewScore *int `json:"newScore,omitempty"`
 }
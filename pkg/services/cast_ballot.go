@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	apiclients "github.com/a-novel/go-apis/clients"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/samber/lo"
+	"time"
+)
+
+type CastBallotService interface {
+	// Cast records the ballot of the user on a poll. Users cast a single ballot per poll, and cannot change it.
+	Cast(ctx context.Context, tokenRaw string, form models.CastBallotForm, now time.Time) (*models.PollBallot, error)
+}
+
+func NewCastBallotService(repository dao.PollsRepository, authClient apiclients.AuthClient) CastBallotService {
+	return &castBallotServiceImpl{
+		repository: repository,
+		authClient: authClient,
+	}
+}
+
+type castBallotServiceImpl struct {
+	repository dao.PollsRepository
+	authClient apiclients.AuthClient
+}
+
+func (s *castBallotServiceImpl) Cast(ctx context.Context, tokenRaw string, form models.CastBallotForm, now time.Time) (*models.PollBallot, error) {
+	token, err := s.authClient.IntrospectToken(ctx, tokenRaw)
+	if err != nil {
+		return nil, goerrors.Join(ErrIntrospectToken, err)
+	}
+	if !token.OK {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
+	}
+
+	pollModel, err := s.repository.Get(ctx, form.PollID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetPoll, err)
+	}
+
+	poll := adapters.PollToModel(pollModel)
+	if poll.Ranked {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrPollRanked)
+	}
+	if !poll.IsOpen(now) {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrPollNotOpen)
+	}
+
+	if err := checkPollChoices(poll, form.Choices); err != nil {
+		return nil, err
+	}
+
+	cast, err := s.repository.CastBallot(ctx, form.PollID, token.Token.Payload.ID, form.Choices, now)
+	if err != nil {
+		return nil, goerrors.Join(ErrCastBallot, err)
+	}
+	if !cast {
+		return nil, ErrBallotAlreadyCast
+	}
+
+	return &models.PollBallot{
+		PollID:    form.PollID,
+		UserID:    token.Token.Payload.ID,
+		CreatedAt: now,
+		Choices:   form.Choices,
+	}, nil
+}
+
+// checkPollChoices makes sure the choices are distinct options of the poll. Single-select polls require exactly one
+// choice.
+func checkPollChoices(poll *models.Poll, choices []int) error {
+	if !poll.MultiSelect && len(choices) > 1 {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidChoices)
+	}
+	if !isPollSelection(poll, choices) {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidChoices)
+	}
+
+	return nil
+}
+
+// isPollSelection reports whether positions is a non-empty list of distinct options of the poll.
+func isPollSelection(poll *models.Poll, positions []int) bool {
+	if len(positions) == 0 || len(lo.Uniq(positions)) != len(positions) {
+		return false
+	}
+
+	return lo.EveryBy(positions, func(position int) bool {
+		return position >= 0 && position < len(poll.Options)
+	})
+}
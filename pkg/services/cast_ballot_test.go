@@ -0,0 +1,280 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCastBallotService(t *testing.T) {
+	userToken := &apiclients.UserTokenStatus{
+		OK: true,
+		Token: &apiclients.UserToken{
+			Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+		},
+	}
+
+	openPoll := &dao.PollModel{
+		Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		CreatedBy: goframework.NumberUUID(200),
+		PollModelCore: dao.PollModelCore{
+			Question: "Which feature next?",
+			Options:  []string{"Dark mode", "Bookmarks", "Offline reading"},
+			OpensAt:  baseTime,
+		},
+	}
+
+	multiSelectPoll := &dao.PollModel{
+		Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		CreatedBy: goframework.NumberUUID(200),
+		PollModelCore: dao.PollModelCore{
+			Question:    "Which feature next?",
+			Options:     []string{"Dark mode", "Bookmarks", "Offline reading"},
+			MultiSelect: true,
+			OpensAt:     baseTime,
+		},
+	}
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		form     models.CastBallotForm
+		now      time.Time
+
+		authClientResp *apiclients.UserTokenStatus
+		authClientErr  error
+
+		shouldCallGet bool
+		getResp       *dao.PollModel
+		getErr        error
+
+		shouldCallCast bool
+		castResp       bool
+		castErr        error
+
+		expect    *models.PollBallot
+		expectErr error
+	}{
+		{
+			name:           "Success",
+			tokenRaw:       "token",
+			form:           models.CastBallotForm{PollID: goframework.NumberUUID(1), Choices: []int{1}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        openPoll,
+			shouldCallCast: true,
+			castResp:       true,
+			expect: &models.PollBallot{
+				PollID:    goframework.NumberUUID(1),
+				UserID:    goframework.NumberUUID(100),
+				CreatedAt: updateTime,
+				Choices:   []int{1},
+			},
+		},
+		{
+			name:           "Success/MultiSelect",
+			tokenRaw:       "token",
+			form:           models.CastBallotForm{PollID: goframework.NumberUUID(1), Choices: []int{2, 0}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        multiSelectPoll,
+			shouldCallCast: true,
+			castResp:       true,
+			expect: &models.PollBallot{
+				PollID:    goframework.NumberUUID(1),
+				UserID:    goframework.NumberUUID(100),
+				CreatedAt: updateTime,
+				Choices:   []int{2, 0},
+			},
+		},
+		{
+			name:           "Error/AlreadyCast",
+			tokenRaw:       "token",
+			form:           models.CastBallotForm{PollID: goframework.NumberUUID(1), Choices: []int{1}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        openPoll,
+			shouldCallCast: true,
+			castResp:       false,
+			expectErr:      services.ErrBallotAlreadyCast,
+		},
+		{
+			name:           "Error/CastFailure",
+			tokenRaw:       "token",
+			form:           models.CastBallotForm{PollID: goframework.NumberUUID(1), Choices: []int{1}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        openPoll,
+			shouldCallCast: true,
+			castErr:        fooErr,
+			expectErr:      services.ErrCastBallot,
+		},
+		{
+			name:           "Error/MultipleChoicesOnSingleSelect",
+			tokenRaw:       "token",
+			form:           models.CastBallotForm{PollID: goframework.NumberUUID(1), Choices: []int{0, 1}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        openPoll,
+			expectErr:      services.ErrInvalidChoices,
+		},
+		{
+			name:           "Error/NoChoice",
+			tokenRaw:       "token",
+			form:           models.CastBallotForm{PollID: goframework.NumberUUID(1)},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        multiSelectPoll,
+			expectErr:      services.ErrInvalidChoices,
+		},
+		{
+			name:           "Error/DuplicateChoices",
+			tokenRaw:       "token",
+			form:           models.CastBallotForm{PollID: goframework.NumberUUID(1), Choices: []int{1, 1}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        multiSelectPoll,
+			expectErr:      services.ErrInvalidChoices,
+		},
+		{
+			name:           "Error/ChoiceOutOfRange",
+			tokenRaw:       "token",
+			form:           models.CastBallotForm{PollID: goframework.NumberUUID(1), Choices: []int{3}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        openPoll,
+			expectErr:      services.ErrInvalidChoices,
+		},
+		{
+			name:           "Error/NegativeChoice",
+			tokenRaw:       "token",
+			form:           models.CastBallotForm{PollID: goframework.NumberUUID(1), Choices: []int{-1}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        openPoll,
+			expectErr:      services.ErrInvalidChoices,
+		},
+		{
+			name:           "Error/NotOpenYet",
+			tokenRaw:       "token",
+			form:           models.CastBallotForm{PollID: goframework.NumberUUID(1), Choices: []int{1}},
+			now:            baseTime.Add(-time.Hour),
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        openPoll,
+			expectErr:      services.ErrPollNotOpen,
+		},
+		{
+			name:           "Error/Closed",
+			tokenRaw:       "token",
+			form:           models.CastBallotForm{PollID: goframework.NumberUUID(1), Choices: []int{1}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp: &dao.PollModel{
+				Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CreatedBy: goframework.NumberUUID(200),
+				PollModelCore: dao.PollModelCore{
+					Question: "Which feature next?",
+					Options:  []string{"Dark mode", "Bookmarks", "Offline reading"},
+					OpensAt:  baseTime,
+					ClosesAt: lo.ToPtr(updateTime),
+				},
+			},
+			expectErr: services.ErrPollNotOpen,
+		},
+		{
+			name:           "Error/RankedPoll",
+			tokenRaw:       "token",
+			form:           models.CastBallotForm{PollID: goframework.NumberUUID(1), Choices: []int{1}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp: &dao.PollModel{
+				Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CreatedBy: goframework.NumberUUID(200),
+				PollModelCore: dao.PollModelCore{
+					Question: "Which story should be featured?",
+					Options:  []string{"Story A", "Story B"},
+					OpensAt:  baseTime,
+					Ranked:   true,
+				},
+			},
+			expectErr: services.ErrPollRanked,
+		},
+		{
+			name:           "Error/PollNotFound",
+			tokenRaw:       "token",
+			form:           models.CastBallotForm{PollID: goframework.NumberUUID(1), Choices: []int{1}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getErr:         bunovel.ErrNotFound,
+			expectErr:      bunovel.ErrNotFound,
+		},
+		{
+			name:           "Error/NotAuthenticated",
+			tokenRaw:       "token",
+			form:           models.CastBallotForm{PollID: goframework.NumberUUID(1), Choices: []int{1}},
+			now:            updateTime,
+			authClientResp: &apiclients.UserTokenStatus{},
+			expectErr:      goframework.ErrInvalidCredentials,
+		},
+		{
+			name:          "Error/AuthClientFailure",
+			tokenRaw:      "token",
+			form:          models.CastBallotForm{PollID: goframework.NumberUUID(1), Choices: []int{1}},
+			now:           updateTime,
+			authClientErr: fooErr,
+			expectErr:     fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewPollsRepository(t)
+			authClient := apiclientsmocks.NewAuthClient(t)
+
+			authClient.On("IntrospectToken", context.Background(), d.tokenRaw).Return(d.authClientResp, d.authClientErr)
+
+			if d.shouldCallGet {
+				repository.On("Get", context.Background(), d.form.PollID).Return(d.getResp, d.getErr)
+			}
+
+			if d.shouldCallCast {
+				repository.
+					On("CastBallot", context.Background(), d.form.PollID, d.authClientResp.Token.Payload.ID, d.form.Choices, d.now).
+					Return(d.castResp, d.castErr)
+			}
+
+			service := services.NewCastBallotService(repository, authClient)
+			res, err := service.Cast(context.Background(), d.tokenRaw, d.form, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+			authClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	apiclients "github.com/a-novel/go-apis/clients"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"time"
+)
+
+type CastRankingService interface {
+	// Cast records the ranked ballot of the user on a ranked poll. Users cast a single ranked ballot per poll, and
+	// cannot change it.
+	Cast(ctx context.Context, tokenRaw string, form models.CastRankingForm, now time.Time) (*models.PollRanking, error)
+}
+
+func NewCastRankingService(repository dao.PollsRepository, authClient apiclients.AuthClient) CastRankingService {
+	return &castRankingServiceImpl{
+		repository: repository,
+		authClient: authClient,
+	}
+}
+
+type castRankingServiceImpl struct {
+	repository dao.PollsRepository
+	authClient apiclients.AuthClient
+}
+
+func (s *castRankingServiceImpl) Cast(ctx context.Context, tokenRaw string, form models.CastRankingForm, now time.Time) (*models.PollRanking, error) {
+	token, err := s.authClient.IntrospectToken(ctx, tokenRaw)
+	if err != nil {
+		return nil, goerrors.Join(ErrIntrospectToken, err)
+	}
+	if !token.OK {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
+	}
+
+	pollModel, err := s.repository.Get(ctx, form.PollID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetPoll, err)
+	}
+
+	poll := adapters.PollToModel(pollModel)
+	if !poll.Ranked {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrPollNotRanked)
+	}
+	if !poll.IsOpen(now) {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrPollNotOpen)
+	}
+
+	if !isPollSelection(poll, form.Ranking) {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidRanking)
+	}
+
+	cast, err := s.repository.CastRanking(ctx, form.PollID, token.Token.Payload.ID, form.Ranking, now)
+	if err != nil {
+		return nil, goerrors.Join(ErrCastRanking, err)
+	}
+	if !cast {
+		return nil, ErrBallotAlreadyCast
+	}
+
+	return &models.PollRanking{
+		PollID:    form.PollID,
+		UserID:    token.Token.Payload.ID,
+		CreatedAt: now,
+		Ranking:   form.Ranking,
+	}, nil
+}
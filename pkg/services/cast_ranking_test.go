@@ -0,0 +1,231 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCastRankingService(t *testing.T) {
+	userToken := &apiclients.UserTokenStatus{
+		OK: true,
+		Token: &apiclients.UserToken{
+			Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+		},
+	}
+
+	rankedPoll := &dao.PollModel{
+		Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		CreatedBy: goframework.NumberUUID(200),
+		PollModelCore: dao.PollModelCore{
+			Question: "Which story should be featured?",
+			Options:  []string{"Story A", "Story B", "Story C"},
+			OpensAt:  baseTime,
+			Ranked:   true,
+		},
+	}
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		form     models.CastRankingForm
+		now      time.Time
+
+		authClientResp *apiclients.UserTokenStatus
+		authClientErr  error
+
+		shouldCallGet bool
+		getResp       *dao.PollModel
+		getErr        error
+
+		shouldCallCast bool
+		castResp       bool
+		castErr        error
+
+		expect    *models.PollRanking
+		expectErr error
+	}{
+		{
+			name:           "Success",
+			tokenRaw:       "token",
+			form:           models.CastRankingForm{PollID: goframework.NumberUUID(1), Ranking: []int{2, 0, 1}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        rankedPoll,
+			shouldCallCast: true,
+			castResp:       true,
+			expect: &models.PollRanking{
+				PollID:    goframework.NumberUUID(1),
+				UserID:    goframework.NumberUUID(100),
+				CreatedAt: updateTime,
+				Ranking:   []int{2, 0, 1},
+			},
+		},
+		{
+			name:           "Success/PartialRanking",
+			tokenRaw:       "token",
+			form:           models.CastRankingForm{PollID: goframework.NumberUUID(1), Ranking: []int{1}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        rankedPoll,
+			shouldCallCast: true,
+			castResp:       true,
+			expect: &models.PollRanking{
+				PollID:    goframework.NumberUUID(1),
+				UserID:    goframework.NumberUUID(100),
+				CreatedAt: updateTime,
+				Ranking:   []int{1},
+			},
+		},
+		{
+			name:           "Error/AlreadyCast",
+			tokenRaw:       "token",
+			form:           models.CastRankingForm{PollID: goframework.NumberUUID(1), Ranking: []int{2, 0, 1}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        rankedPoll,
+			shouldCallCast: true,
+			castResp:       false,
+			expectErr:      services.ErrBallotAlreadyCast,
+		},
+		{
+			name:           "Error/CastFailure",
+			tokenRaw:       "token",
+			form:           models.CastRankingForm{PollID: goframework.NumberUUID(1), Ranking: []int{2, 0, 1}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        rankedPoll,
+			shouldCallCast: true,
+			castErr:        fooErr,
+			expectErr:      services.ErrCastRanking,
+		},
+		{
+			name:           "Error/EmptyRanking",
+			tokenRaw:       "token",
+			form:           models.CastRankingForm{PollID: goframework.NumberUUID(1)},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        rankedPoll,
+			expectErr:      services.ErrInvalidRanking,
+		},
+		{
+			name:           "Error/DuplicateOptions",
+			tokenRaw:       "token",
+			form:           models.CastRankingForm{PollID: goframework.NumberUUID(1), Ranking: []int{2, 0, 2}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        rankedPoll,
+			expectErr:      services.ErrInvalidRanking,
+		},
+		{
+			name:           "Error/OptionOutOfRange",
+			tokenRaw:       "token",
+			form:           models.CastRankingForm{PollID: goframework.NumberUUID(1), Ranking: []int{2, 3}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        rankedPoll,
+			expectErr:      services.ErrInvalidRanking,
+		},
+		{
+			name:           "Error/NotOpen",
+			tokenRaw:       "token",
+			form:           models.CastRankingForm{PollID: goframework.NumberUUID(1), Ranking: []int{2, 0, 1}},
+			now:            baseTime.Add(-time.Hour),
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp:        rankedPoll,
+			expectErr:      services.ErrPollNotOpen,
+		},
+		{
+			name:           "Error/NotRanked",
+			tokenRaw:       "token",
+			form:           models.CastRankingForm{PollID: goframework.NumberUUID(1), Ranking: []int{1, 0}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getResp: &dao.PollModel{
+				Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CreatedBy: goframework.NumberUUID(200),
+				PollModelCore: dao.PollModelCore{
+					Question: "Which feature next?",
+					Options:  []string{"Dark mode", "Bookmarks"},
+					OpensAt:  baseTime,
+					ClosesAt: lo.ToPtr(updateTime.Add(time.Hour)),
+				},
+			},
+			expectErr: services.ErrPollNotRanked,
+		},
+		{
+			name:           "Error/PollNotFound",
+			tokenRaw:       "token",
+			form:           models.CastRankingForm{PollID: goframework.NumberUUID(1), Ranking: []int{2, 0, 1}},
+			now:            updateTime,
+			authClientResp: userToken,
+			shouldCallGet:  true,
+			getErr:         bunovel.ErrNotFound,
+			expectErr:      bunovel.ErrNotFound,
+		},
+		{
+			name:           "Error/NotAuthenticated",
+			tokenRaw:       "token",
+			form:           models.CastRankingForm{PollID: goframework.NumberUUID(1), Ranking: []int{2, 0, 1}},
+			now:            updateTime,
+			authClientResp: &apiclients.UserTokenStatus{},
+			expectErr:      goframework.ErrInvalidCredentials,
+		},
+		{
+			name:          "Error/AuthClientFailure",
+			tokenRaw:      "token",
+			form:          models.CastRankingForm{PollID: goframework.NumberUUID(1), Ranking: []int{2, 0, 1}},
+			now:           updateTime,
+			authClientErr: fooErr,
+			expectErr:     fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewPollsRepository(t)
+			authClient := apiclientsmocks.NewAuthClient(t)
+
+			authClient.On("IntrospectToken", context.Background(), d.tokenRaw).Return(d.authClientResp, d.authClientErr)
+
+			if d.shouldCallGet {
+				repository.On("Get", context.Background(), d.form.PollID).Return(d.getResp, d.getErr)
+			}
+
+			if d.shouldCallCast {
+				repository.
+					On("CastRanking", context.Background(), d.form.PollID, d.authClientResp.Token.Payload.ID, d.form.Ranking, d.now).
+					Return(d.castResp, d.castErr)
+			}
+
+			service := services.NewCastRankingService(repository, authClient)
+			res, err := service.Cast(context.Background(), d.tokenRaw, d.form, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+			authClient.AssertExpectations(t)
+		})
+	}
+}
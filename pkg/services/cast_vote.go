@@ -2,7 +2,11 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	goerrors "errors"
+	"github.com/a-novel/bunovel"
 	apiclients "github.com/a-novel/go-apis/clients"
 	goframework "github.com/a-novel/go-framework"
 	"github.com/a-novel/votes-service/pkg/adapters"
@@ -10,30 +14,56 @@ import (
 	"github.com/a-novel/votes-service/pkg/models"
 	"github.com/google/uuid"
 	"github.com/samber/lo"
+	"strconv"
 	"time"
 )
 
 type CastVoteService interface {
-	Cast(ctx context.Context, tokenRaw string, form models.VoteForm, id uuid.UUID, now time.Time) (*models.VotesSummary, error)
+	// Cast records the vote of the user. When an idempotency key is set, and the same key was already used by the user,
+	// the original summary is returned and the vote is not cast again.
+	Cast(ctx context.Context, tokenRaw string, form models.VoteForm, idempotencyKey string, id uuid.UUID, now time.Time) (*models.VotesSummary, error)
 }
 
-func NewCastVoteService(repository dao.VotesRepository, authClient apiclients.AuthClient, targetsClients map[string]models.CheckVoteClient) CastVoteService {
+// CastVoteConfig configures the casting of votes.
+type CastVoteConfig struct {
+	// IdempotencyTTL is how long the responses are replayed to requests with the same idempotency key.
+	IdempotencyTTL time.Duration
+	// UserRateLimit limits the votes of a user, across all targets.
+	UserRateLimit models.RateLimit
+	// TargetRateLimit limits the votes of a user on a single target.
+	TargetRateLimit models.RateLimit
+}
+
+func NewCastVoteService(
+	repository dao.VotesRepository,
+	authClient apiclients.AuthClient,
+	targets map[string]*models.Target,
+	rateLimiter models.RateLimiter,
+	config CastVoteConfig,
+) CastVoteService {
 	return &castVoteServiceImpl{
-		repository:     repository,
-		authClient:     authClient,
-		targetsClients: targetsClients,
+		repository:  repository,
+		authClient:  authClient,
+		targets:     targets,
+		rateLimiter: rateLimiter,
+		config:      config,
 	}
 }
 
 type castVoteServiceImpl struct {
-	repository dao.VotesRepository
-	authClient apiclients.AuthClient
+	repository  dao.VotesRepository
+	authClient  apiclients.AuthClient
+	rateLimiter models.RateLimiter
 
-	targetsClients map[string]models.CheckVoteClient
+	targets map[string]*models.Target
+	config  CastVoteConfig
 }
 
-func (s *castVoteServiceImpl) Cast(ctx context.Context, tokenRaw string, form models.VoteForm, id uuid.UUID, now time.Time) (*models.VotesSummary, error) {
-	var res *dao.VotesSummaryModel
+func (s *castVoteServiceImpl) Cast(ctx context.Context, tokenRaw string, form models.VoteForm, idempotencyKey string, id uuid.UUID, now time.Time) (*models.VotesSummary, error) {
+	var (
+		res    *dao.VotesSummaryModel
+		result *models.VotesSummary
+	)
 
 	token, err := s.authClient.IntrospectToken(ctx, tokenRaw)
 	if err != nil {
@@ -43,19 +73,103 @@ func (s *castVoteServiceImpl) Cast(ctx context.Context, tokenRaw string, form mo
 		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
 	}
 
-	if err := goframework.CheckRestricted(lo.FromPtr(form.Vote), "", models.VoteValueUp, models.VoteValueDown); err != nil {
-		return nil, goerrors.Join(goframework.ErrInvalidEntity, err)
+	if len(idempotencyKey) > MaxIdempotencyKeyLength {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidIdempotency)
+	}
+
+	requestHash := HashVoteForm(form)
+
+	// Most retries come after the original request completed, and are answered without opening a transaction.
+	if idempotencyKey != "" {
+		stored, err := s.repository.GetIdempotencyKey(ctx, token.Token.Payload.ID, idempotencyKey, now)
+		if err == nil {
+			return replayIdempotentCast(stored, requestHash)
+		}
+		if !goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil, goerrors.Join(ErrGetIdempotency, err)
+		}
 	}
 
-	targetClient := s.targetsClients[form.Target]
-	if targetClient == nil {
+	target := s.targets[form.Target]
+	if target == nil {
 		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidTarget)
 	}
+	if !target.Open {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrTargetClosed)
+	}
+
+	vote, err := castValue(target, form)
+	if err != nil {
+		return nil, err
+	}
+
+	// An empty vote removes the current one.
+	if err := goframework.CheckRestricted(lo.FromPtr(vote), append([]models.VoteValue{""}, target.Values...)...); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, err)
+	}
+
+	if form.ExpectedVote != nil {
+		if err := goframework.CheckRestricted(*form.ExpectedVote, append([]models.VoteValue{""}, target.Values...)...); err != nil {
+			return nil, goerrors.Join(goframework.ErrInvalidEntity, err)
+		}
+	}
 
-	// Prevent insertion if client call fails.
+	if err := target.Handler.Authorize(ctx, token.Token.Payload.ID, form.TargetID); err != nil {
+		return nil, goerrors.Join(ErrCheckVoteTarget, err)
+	}
+
+	// Replayed and unauthorized requests are not limited, as they don't cast anything.
+	if err := s.checkRateLimits(ctx, token.Token.Payload.ID, form, now); err != nil {
+		return nil, err
+	}
+
+	// Removing a vote does not need a weight.
+	weight := models.DefaultVoteWeight
+	if target.WeightResolver != nil && vote != nil {
+		weight, err = target.WeightResolver.Resolve(ctx, token.Token.Payload.ID, form.TargetID)
+		if err != nil {
+			return nil, goerrors.Join(ErrResolveWeight, err)
+		}
+	}
+
+	// The target is notified asynchronously, through the outbox.
 	err = s.repository.RunInTx(ctx, func(ctx context.Context, txRepository dao.VotesRepository) error {
-		_, err = txRepository.Cast(ctx, token.Token.Payload.ID, form.TargetID, form.Target, form.Vote, id, now)
+		if idempotencyKey != "" {
+			claimed, err := txRepository.ClaimIdempotencyKey(
+				ctx, token.Token.Payload.ID, idempotencyKey, requestHash, now, now.Add(s.config.IdempotencyTTL),
+			)
+			if err != nil {
+				return goerrors.Join(ErrClaimIdempotency, err)
+			}
+
+			// A concurrent request with the same key completed first.
+			if !claimed {
+				stored, err := txRepository.GetIdempotencyKey(ctx, token.Token.Payload.ID, idempotencyKey, now)
+				if err != nil {
+					return goerrors.Join(ErrGetIdempotency, err)
+				}
+
+				result, err = replayIdempotentCast(stored, requestHash)
+				return err
+			}
+		}
+
+		if err := checkLock(ctx, txRepository, form, now); err != nil {
+			return err
+		}
+
+		_, err = txRepository.Cast(
+			ctx, token.Token.Payload.ID, form.TargetID, form.Target, vote, form.ExpectedVote, form.Score, weight, id, now,
+		)
 		if err != nil {
+			var conflict *models.VoteConflictError
+			if goerrors.As(err, &conflict) {
+				return goerrors.Join(ErrVoteConflict, err)
+			}
+			if goerrors.Is(err, dao.ErrVoteInvalidated) {
+				return goerrors.Join(goframework.ErrInvalidEntity, ErrVoteInvalidated, err)
+			}
+
 			return goerrors.Join(ErrCastVote, err)
 		}
 
@@ -64,8 +178,21 @@ func (s *castVoteServiceImpl) Cast(ctx context.Context, tokenRaw string, form mo
 			return goerrors.Join(ErrGetVotesSummary, err)
 		}
 
-		if err := targetClient(ctx, form.TargetID, token.Token.Payload.ID, res.UpVotes, res.DownVotes); err != nil {
-			return goerrors.Join(ErrSendVoteToTarget, err)
+		if err = txRepository.QueueSummaryUpdate(ctx, token.Token.Payload.ID, res, now); err != nil {
+			return goerrors.Join(ErrQueueSummaryUpdate, err)
+		}
+
+		result = withScores(adapters.VotesSummaryToModel(res))
+
+		if idempotencyKey != "" {
+			response, err := json.Marshal(result)
+			if err != nil {
+				return goerrors.Join(ErrSaveIdempotency, err)
+			}
+
+			if err = txRepository.SaveIdempotencyResponse(ctx, token.Token.Payload.ID, idempotencyKey, response); err != nil {
+				return goerrors.Join(ErrSaveIdempotency, err)
+			}
 		}
 
 		return nil
@@ -74,5 +201,101 @@ func (s *castVoteServiceImpl) Cast(ctx context.Context, tokenRaw string, form mo
 		return nil, err
 	}
 
-	return adapters.VotesSummaryToModel(res), nil
+	return result, nil
+}
+
+// castValue returns the value of the vote to cast. Rated targets are rated with a score, that sets the value of the
+// vote: omitting the score removes the current rating.
+func castValue(target *models.Target, form models.VoteForm) (*models.VoteValue, error) {
+	if target.Rating == nil {
+		if form.Score != nil {
+			return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidScore)
+		}
+
+		return form.Vote, nil
+	}
+
+	if lo.FromPtr(form.Vote) != "" {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidVoteValue)
+	}
+	if form.Score == nil {
+		return nil, nil
+	}
+	if err := goframework.CheckMinMax(*form.Score, target.Rating.Min, target.Rating.Max); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidScore, err)
+	}
+
+	return lo.ToPtr(models.VoteValueRating), nil
+}
+
+// checkLock rejects the votes on a target that was locked by a moderator. Votes can neither be cast nor removed
+// once the lock is effective. It must run in the transaction of the cast, so the lock cannot change before the vote
+// is written.
+func checkLock(ctx context.Context, txRepository dao.VotesRepository, form models.VoteForm, now time.Time) error {
+	lock, err := txRepository.GetTargetLock(ctx, form.TargetID, form.Target)
+	if err != nil {
+		if goerrors.Is(err, bunovel.ErrNotFound) {
+			return nil
+		}
+
+		return goerrors.Join(ErrGetTargetLock, err)
+	}
+
+	if !lock.ClosesAt.After(now) {
+		return ErrTargetLocked
+	}
+
+	return nil
+}
+
+func (s *castVoteServiceImpl) checkRateLimits(ctx context.Context, userID uuid.UUID, form models.VoteForm, now time.Time) error {
+	// Both limits are checked at once, so a vote rejected on a target does not count against the other targets.
+	retryAfter, err := s.rateLimiter.Allow(ctx, []models.RateLimitBucket{
+		{Key: "cast:" + userID.String(), Limit: s.config.UserRateLimit},
+		{Key: "cast:" + userID.String() + ":" + form.Target + ":" + form.TargetID.String(), Limit: s.config.TargetRateLimit},
+	}, now)
+	if err != nil {
+		return goerrors.Join(ErrCheckRateLimit, err)
+	}
+	if retryAfter > 0 {
+		return goerrors.Join(ErrRateLimited, &models.RateLimitError{RetryAfter: retryAfter})
+	}
+
+	return nil
+}
+
+// HashVoteForm identifies a request, so an idempotency key cannot be replayed for a different vote.
+func HashVoteForm(form models.VoteForm) string {
+	hash := sha256.New()
+	hash.Write([]byte(form.TargetID.String()))
+	hash.Write([]byte{0})
+	hash.Write([]byte(form.Target))
+	hash.Write([]byte{0})
+	hash.Write([]byte(lo.FromPtr(form.Vote)))
+	hash.Write([]byte{0})
+	// Only the votes on rated targets carry a score, so the hash of the other votes is unchanged.
+	if form.Score != nil {
+		hash.Write([]byte("#"))
+		hash.Write([]byte(strconv.Itoa(*form.Score)))
+	}
+	// Distinguish an unconditional cast from a cast that expects no vote.
+	if form.ExpectedVote != nil {
+		hash.Write([]byte("="))
+		hash.Write([]byte(*form.ExpectedVote))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func replayIdempotentCast(stored *dao.IdempotencyKeyModel, requestHash string) (*models.VotesSummary, error) {
+	if stored.RequestHash != requestHash {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrIdempotencyReused)
+	}
+
+	result := new(models.VotesSummary)
+	if err := json.Unmarshal(stored.Response, result); err != nil {
+		return nil, goerrors.Join(ErrGetIdempotency, err)
+	}
+
+	return result, nil
 }
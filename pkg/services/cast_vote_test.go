@@ -2,6 +2,8 @@ package services_test
 
 import (
 	"context"
+	"encoding/json"
+	"github.com/a-novel/bunovel"
 	apiclients "github.com/a-novel/go-apis/clients"
 	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
 	goframework "github.com/a-novel/go-framework"
@@ -13,6 +15,7 @@ import (
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"strings"
 	"testing"
 	"time"
 )
@@ -21,30 +24,683 @@ func TestCastVoteService(t *testing.T) {
 	data := []struct {
 		name string
 
-		tokenRaw string
-		form     models.VoteForm
-		id       uuid.UUID
-		now      time.Time
+		tokenRaw       string
+		form           models.VoteForm
+		idempotencyKey string
+		id             uuid.UUID
+		now            time.Time
 
 		authClientResp *apiclients.UserTokenStatus
 		authClientErr  error
 
-		clientName string
-		clientErr  error
+		clientName   string
+		clientErr    error
+		targetValues []models.VoteValue
+		targetRating *models.RatingScale
+		targetClosed bool
 
-		shouldCallDAO bool
+		weightResolver *fakeWeightResolver
+		// weight is the weight the vote is cast with. It defaults to models.DefaultVoteWeight.
+		weight int
 
-		castErr error
+		shouldGetIdempotency bool
+		idempotencyStored    *dao.IdempotencyKeyModel
+		getIdempotencyErr    error
+
+		shouldGetLock bool
+		lock          *dao.TargetLockModel
+		lockErr       error
+
+		shouldCallTx   bool
+		shouldClaim    bool
+		claimed        bool
+		claimErr       error
+		shouldGetAgain bool
+		shouldCallCast bool
+		castErr        error
 
 		shouldCallGetSummary bool
 		summary              *dao.VotesSummaryModel
 		summaryErr           error
 
+		shouldQueue bool
+		queueErr    error
+
+		shouldSaveIdempotency bool
+		saveIdempotencyErr    error
+
+		rateLimited  map[string]time.Duration
+		rateLimitErr error
+
+		expectRetryAfter time.Duration
+
 		expect    *models.VotesSummary
 		expectErr error
 	}{
 		{
-			name:     "Success/UpVote",
+			name:     "Success/UpVote",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldGetLock:        true,
+			shouldCallTx:         true,
+			shouldCallCast:       true,
+			shouldCallGetSummary: true,
+			summary: &dao.VotesSummaryModel{
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				UpVotes:   128,
+				DownVotes: 64,
+			},
+			shouldQueue: true,
+			expect: &models.VotesSummary{
+				UpVotes:   128,
+				DownVotes: 64,
+				Scores:    services.ComputeVotesScores(128, 64),
+			},
+		},
+		{
+			name:     "Success/DownVote",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueDown),
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldGetLock:        true,
+			shouldCallTx:         true,
+			shouldCallCast:       true,
+			shouldCallGetSummary: true,
+			summary: &dao.VotesSummaryModel{
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				UpVotes:   128,
+				DownVotes: 64,
+			},
+			shouldQueue: true,
+			expect: &models.VotesSummary{
+				UpVotes:   128,
+				DownVotes: 64,
+				Scores:    services.ComputeVotesScores(128, 64),
+			},
+		},
+		{
+			name:     "Success/NoVote",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldGetLock:        true,
+			shouldCallTx:         true,
+			shouldCallCast:       true,
+			shouldCallGetSummary: true,
+			summary: &dao.VotesSummaryModel{
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				UpVotes:   128,
+				DownVotes: 64,
+			},
+			shouldQueue: true,
+			expect: &models.VotesSummary{
+				UpVotes:   128,
+				DownVotes: 64,
+				Scores:    services.ComputeVotesScores(128, 64),
+			},
+		},
+		{
+			name:     "Success/LastVoteRemoved",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldGetLock:        true,
+			shouldCallTx:         true,
+			shouldCallCast:       true,
+			shouldCallGetSummary: true,
+			summary: &dao.VotesSummaryModel{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			shouldQueue: true,
+			expect: &models.VotesSummary{
+				Scores: &models.VotesScores{},
+			},
+		},
+		{
+			name:     "Success/Weighted",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			weightResolver:       &fakeWeightResolver{weight: 3},
+			weight:               3,
+			shouldGetLock:        true,
+			shouldCallTx:         true,
+			shouldCallCast:       true,
+			shouldCallGetSummary: true,
+			summary: &dao.VotesSummaryModel{
+				TargetID:          goframework.NumberUUID(1),
+				Target:            "target",
+				UpVotes:           128,
+				DownVotes:         64,
+				WeightedUpVotes:   200,
+				WeightedDownVotes: 70,
+			},
+			shouldQueue: true,
+			expect: &models.VotesSummary{
+				UpVotes:           128,
+				DownVotes:         64,
+				WeightedUpVotes:   200,
+				WeightedDownVotes: 70,
+				Scores:            services.ComputeVotesScores(128, 64),
+			},
+		},
+		// Removing a vote does not resolve its weight.
+		{
+			name:     "Success/WeightedRemoval",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			weightResolver:       &fakeWeightResolver{err: fooErr},
+			shouldGetLock:        true,
+			shouldCallTx:         true,
+			shouldCallCast:       true,
+			shouldCallGetSummary: true,
+			summary: &dao.VotesSummaryModel{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			shouldQueue: true,
+			expect: &models.VotesSummary{
+				Scores: &models.VotesScores{},
+			},
+		},
+		{
+			name:     "Error/ResolveWeightFailure",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			weightResolver: &fakeWeightResolver{err: fooErr},
+			expectErr:      services.ErrResolveWeight,
+		},
+		{
+			name:     "Success/IdempotencyKey",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			idempotencyKey: "key",
+			id:             goframework.NumberUUID(10),
+			now:            baseTime,
+			clientName:     "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldGetIdempotency: true,
+			getIdempotencyErr:    bunovel.ErrNotFound,
+			shouldGetLock:        true,
+			shouldCallTx:         true,
+			shouldClaim:          true,
+			claimed:              true,
+			shouldCallCast:       true,
+			shouldCallGetSummary: true,
+			summary: &dao.VotesSummaryModel{
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				UpVotes:   128,
+				DownVotes: 64,
+			},
+			shouldQueue:           true,
+			shouldSaveIdempotency: true,
+			expect: &models.VotesSummary{
+				UpVotes:   128,
+				DownVotes: 64,
+				Scores:    services.ComputeVotesScores(128, 64),
+			},
+		},
+		// The target is closed, but the original request was accepted.
+		{
+			name:     "Success/IdempotencyKeyReplay",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			idempotencyKey: "key",
+			id:             goframework.NumberUUID(10),
+			now:            baseTime,
+			clientName:     "target",
+			targetClosed:   true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldGetIdempotency: true,
+			idempotencyStored: &dao.IdempotencyKeyModel{
+				UserID: goframework.NumberUUID(100),
+				Key:    "key",
+				RequestHash: services.HashVoteForm(models.VoteForm{
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+					Vote:     lo.ToPtr(models.VoteValueUp),
+				}),
+				Response: json.RawMessage(`{"upVotes":128,"downVotes":64}`),
+			},
+			expect: &models.VotesSummary{
+				UpVotes:   128,
+				DownVotes: 64,
+			},
+		},
+		{
+			name:     "Success/IdempotencyKeyConcurrentReplay",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			idempotencyKey: "key",
+			id:             goframework.NumberUUID(10),
+			now:            baseTime,
+			clientName:     "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldGetIdempotency: true,
+			getIdempotencyErr:    bunovel.ErrNotFound,
+			shouldCallTx:         true,
+			shouldClaim:          true,
+			shouldGetAgain:       true,
+			idempotencyStored: &dao.IdempotencyKeyModel{
+				UserID: goframework.NumberUUID(100),
+				Key:    "key",
+				RequestHash: services.HashVoteForm(models.VoteForm{
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+					Vote:     lo.ToPtr(models.VoteValueUp),
+				}),
+				Response: json.RawMessage(`{"upVotes":128,"downVotes":64}`),
+			},
+			expect: &models.VotesSummary{
+				UpVotes:   128,
+				DownVotes: 64,
+			},
+		},
+		{
+			name:     "Error/IdempotencyKeyReused",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueDown),
+			},
+			idempotencyKey: "key",
+			id:             goframework.NumberUUID(10),
+			now:            baseTime,
+			clientName:     "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldGetIdempotency: true,
+			idempotencyStored: &dao.IdempotencyKeyModel{
+				UserID: goframework.NumberUUID(100),
+				Key:    "key",
+				RequestHash: services.HashVoteForm(models.VoteForm{
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+					Vote:     lo.ToPtr(models.VoteValueUp),
+				}),
+				Response: json.RawMessage(`{"upVotes":128,"downVotes":64}`),
+			},
+			expectErr: services.ErrIdempotencyReused,
+		},
+		{
+			name:     "Error/IdempotencyKeyTooLong",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			idempotencyKey: strings.Repeat("a", services.MaxIdempotencyKeyLength+1),
+			id:             goframework.NumberUUID(10),
+			now:            baseTime,
+			clientName:     "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name:     "Error/GetIdempotencyKeyFailure",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			idempotencyKey: "key",
+			id:             goframework.NumberUUID(10),
+			now:            baseTime,
+			clientName:     "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldGetIdempotency: true,
+			getIdempotencyErr:    fooErr,
+			expectErr:            fooErr,
+		},
+		{
+			name:     "Error/ClaimIdempotencyKeyFailure",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			idempotencyKey: "key",
+			id:             goframework.NumberUUID(10),
+			now:            baseTime,
+			clientName:     "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldGetIdempotency: true,
+			getIdempotencyErr:    bunovel.ErrNotFound,
+			shouldCallTx:         true,
+			shouldClaim:          true,
+			claimErr:             fooErr,
+			expectErr:            fooErr,
+		},
+		{
+			name:     "Error/SaveIdempotencyResponseFailure",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			idempotencyKey: "key",
+			id:             goframework.NumberUUID(10),
+			now:            baseTime,
+			clientName:     "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldGetIdempotency: true,
+			getIdempotencyErr:    bunovel.ErrNotFound,
+			shouldGetLock:        true,
+			shouldCallTx:         true,
+			shouldClaim:          true,
+			claimed:              true,
+			shouldCallCast:       true,
+			shouldCallGetSummary: true,
+			summary: &dao.VotesSummaryModel{
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				UpVotes:   128,
+				DownVotes: 64,
+			},
+			shouldQueue:           true,
+			shouldSaveIdempotency: true,
+			saveIdempotencyErr:    fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:     "Error/VoteConflict",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID:     goframework.NumberUUID(1),
+				Target:       "target",
+				Vote:         lo.ToPtr(models.VoteValueUp),
+				ExpectedVote: lo.ToPtr(models.VoteValue("")),
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldGetLock:  true,
+			shouldCallTx:   true,
+			shouldCallCast: true,
+			castErr:        &models.VoteConflictError{CurrentVote: lo.ToPtr(models.VoteValueDown)},
+			expectErr:      services.ErrVoteConflict,
+		},
+		{
+			name:     "Error/RetractInvalidated",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldGetLock:  true,
+			shouldCallTx:   true,
+			shouldCallCast: true,
+			castErr:        dao.ErrVoteInvalidated,
+			expectErr:      goframework.ErrInvalidEntity,
+		},
+		{
+			name:     "Error/BadExpectedVote",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID:     goframework.NumberUUID(1),
+				Target:       "target",
+				Vote:         lo.ToPtr(models.VoteValueUp),
+				ExpectedVote: lo.ToPtr(models.VoteValue("invalid")),
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name:     "Error/UserRateLimited",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			rateLimited: map[string]time.Duration{
+				"cast:" + goframework.NumberUUID(100).String(): time.Second,
+			},
+			expectRetryAfter: time.Second,
+			expectErr:        services.ErrRateLimited,
+		},
+		{
+			name:     "Error/TargetRateLimited",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			rateLimited: map[string]time.Duration{
+				"cast:" + goframework.NumberUUID(100).String() + ":target:" + goframework.NumberUUID(1).String(): 5 * time.Second,
+			},
+			expectRetryAfter: 5 * time.Second,
+			expectErr:        services.ErrRateLimited,
+		},
+		{
+			name:     "Error/RateLimiterFailure",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			rateLimitErr: fooErr,
+			expectErr:    fooErr,
+		},
+		{
+			name:     "Error/AuthorizeFailure",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			clientErr:  fooErr,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			expectErr: fooErr,
+		},
+		{
+			name:     "Error/GetSummaryFailure",
 			tokenRaw: "token",
 			form: models.VoteForm{
 				TargetID: goframework.NumberUUID(1),
@@ -60,26 +716,20 @@ func TestCastVoteService(t *testing.T) {
 					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
 				},
 			},
-			shouldCallDAO:        true,
+			shouldGetLock:        true,
+			shouldCallTx:         true,
+			shouldCallCast:       true,
 			shouldCallGetSummary: true,
-			summary: &dao.VotesSummaryModel{
-				TargetID:  goframework.NumberUUID(1),
-				Target:    "target",
-				UpVotes:   128,
-				DownVotes: 64,
-			},
-			expect: &models.VotesSummary{
-				UpVotes:   128,
-				DownVotes: 64,
-			},
+			summaryErr:           fooErr,
+			expectErr:            fooErr,
 		},
 		{
-			name:     "Success/DownVote",
+			name:     "Error/QueueSummaryUpdateFailure",
 			tokenRaw: "token",
 			form: models.VoteForm{
 				TargetID: goframework.NumberUUID(1),
 				Target:   "target",
-				Vote:     lo.ToPtr(models.VoteValueDown),
+				Vote:     lo.ToPtr(models.VoteValueUp),
 			},
 			id:         goframework.NumberUUID(10),
 			now:        baseTime,
@@ -90,7 +740,9 @@ func TestCastVoteService(t *testing.T) {
 					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
 				},
 			},
-			shouldCallDAO:        true,
+			shouldGetLock:        true,
+			shouldCallTx:         true,
+			shouldCallCast:       true,
 			shouldCallGetSummary: true,
 			summary: &dao.VotesSummaryModel{
 				TargetID:  goframework.NumberUUID(1),
@@ -98,17 +750,87 @@ func TestCastVoteService(t *testing.T) {
 				UpVotes:   128,
 				DownVotes: 64,
 			},
-			expect: &models.VotesSummary{
-				UpVotes:   128,
-				DownVotes: 64,
+			shouldQueue: true,
+			queueErr:    fooErr,
+			expectErr:   fooErr,
+		},
+		{
+			name:     "Error/CastVoteFailure",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
 			},
+			shouldGetLock:  true,
+			shouldCallTx:   true,
+			shouldCallCast: true,
+			castErr:        fooErr,
+			expectErr:      fooErr,
 		},
 		{
-			name:     "Success/NoVote",
+			name:     "Error/BadTarget",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "fake-target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name:     "Error/NotAuthenticated",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "fake-target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			id:             goframework.NumberUUID(10),
+			now:            baseTime,
+			clientName:     "target",
+			authClientResp: &apiclients.UserTokenStatus{},
+			expectErr:      goframework.ErrInvalidCredentials,
+		},
+		{
+			name:     "Error/AuthClientFailure",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "fake-target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
+			},
+			id:            goframework.NumberUUID(10),
+			now:           baseTime,
+			clientName:    "target",
+			authClientErr: fooErr,
+			expectErr:     fooErr,
+		},
+		{
+			name:     "Success/ScheduledLock",
 			tokenRaw: "token",
 			form: models.VoteForm{
 				TargetID: goframework.NumberUUID(1),
 				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueUp),
 			},
 			id:         goframework.NumberUUID(10),
 			now:        baseTime,
@@ -119,7 +841,14 @@ func TestCastVoteService(t *testing.T) {
 					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
 				},
 			},
-			shouldCallDAO:        true,
+			shouldGetLock: true,
+			lock: &dao.TargetLockModel{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				ClosesAt: baseTime.Add(time.Second),
+			},
+			shouldCallTx:         true,
+			shouldCallCast:       true,
 			shouldCallGetSummary: true,
 			summary: &dao.VotesSummaryModel{
 				TargetID:  goframework.NumberUUID(1),
@@ -127,13 +856,15 @@ func TestCastVoteService(t *testing.T) {
 				UpVotes:   128,
 				DownVotes: 64,
 			},
+			shouldQueue: true,
 			expect: &models.VotesSummary{
 				UpVotes:   128,
 				DownVotes: 64,
+				Scores:    services.ComputeVotesScores(128, 64),
 			},
 		},
 		{
-			name:     "Error/TargetCallFailure",
+			name:     "Error/TargetLocked",
 			tokenRaw: "token",
 			form: models.VoteForm{
 				TargetID: goframework.NumberUUID(1),
@@ -143,30 +874,27 @@ func TestCastVoteService(t *testing.T) {
 			id:         goframework.NumberUUID(10),
 			now:        baseTime,
 			clientName: "target",
-			clientErr:  fooErr,
 			authClientResp: &apiclients.UserTokenStatus{
 				OK: true,
 				Token: &apiclients.UserToken{
 					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
 				},
 			},
-			shouldCallDAO:        true,
-			shouldCallGetSummary: true,
-			summary: &dao.VotesSummaryModel{
-				TargetID:  goframework.NumberUUID(1),
-				Target:    "target",
-				UpVotes:   128,
-				DownVotes: 64,
+			shouldGetLock: true,
+			shouldCallTx:  true,
+			lock: &dao.TargetLockModel{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				ClosesAt: baseTime,
 			},
-			expectErr: fooErr,
+			expectErr: services.ErrTargetLocked,
 		},
 		{
-			name:     "Error/GetSummaryFailure",
+			name:     "Error/TargetLockedRemoval",
 			tokenRaw: "token",
 			form: models.VoteForm{
 				TargetID: goframework.NumberUUID(1),
 				Target:   "target",
-				Vote:     lo.ToPtr(models.VoteValueUp),
 			},
 			id:         goframework.NumberUUID(10),
 			now:        baseTime,
@@ -177,13 +905,17 @@ func TestCastVoteService(t *testing.T) {
 					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
 				},
 			},
-			shouldCallDAO:        true,
-			shouldCallGetSummary: true,
-			summaryErr:           fooErr,
-			expectErr:            fooErr,
+			shouldGetLock: true,
+			shouldCallTx:  true,
+			lock: &dao.TargetLockModel{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				ClosesAt: baseTime.Add(-time.Hour),
+			},
+			expectErr: services.ErrTargetLocked,
 		},
 		{
-			name:     "Error/CastVoteFailure",
+			name:     "Error/GetTargetLockFailure",
 			tokenRaw: "token",
 			form: models.VoteForm{
 				TargetID: goframework.NumberUUID(1),
@@ -199,21 +931,43 @@ func TestCastVoteService(t *testing.T) {
 					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
 				},
 			},
-			shouldCallDAO: true,
-			castErr:       fooErr,
-			expectErr:     fooErr,
+			shouldGetLock: true,
+			shouldCallTx:  true,
+			lockErr:       fooErr,
+			expectErr:     services.ErrGetTargetLock,
 		},
 		{
-			name:     "Error/BadTarget",
+			name:     "Error/TargetClosed",
 			tokenRaw: "token",
 			form: models.VoteForm{
 				TargetID: goframework.NumberUUID(1),
-				Target:   "fake-target",
+				Target:   "target",
 				Vote:     lo.ToPtr(models.VoteValueUp),
 			},
-			id:         goframework.NumberUUID(10),
-			now:        baseTime,
-			clientName: "target",
+			id:           goframework.NumberUUID(10),
+			now:          baseTime,
+			clientName:   "target",
+			targetClosed: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			expectErr: services.ErrTargetClosed,
+		},
+		{
+			name:     "Error/VoteNotAllowedOnTarget",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Vote:     lo.ToPtr(models.VoteValueDown),
+			},
+			id:           goframework.NumberUUID(10),
+			now:          baseTime,
+			clientName:   "target",
+			targetValues: []models.VoteValue{models.VoteValueUp},
 			authClientResp: &apiclients.UserTokenStatus{
 				OK: true,
 				Token: &apiclients.UserToken{
@@ -223,32 +977,138 @@ func TestCastVoteService(t *testing.T) {
 			expectErr: goframework.ErrInvalidEntity,
 		},
 		{
-			name:     "Error/NotAuthenticated",
+			name:     "Success/Rating",
 			tokenRaw: "token",
 			form: models.VoteForm{
 				TargetID: goframework.NumberUUID(1),
-				Target:   "fake-target",
+				Target:   "target",
+				Score:    lo.ToPtr(4),
+			},
+			id:           goframework.NumberUUID(10),
+			now:          baseTime,
+			clientName:   "target",
+			targetRating: &models.RatingScale{Min: 1, Max: 5},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldGetLock:        true,
+			shouldCallTx:         true,
+			shouldCallCast:       true,
+			shouldCallGetSummary: true,
+			summary: &dao.VotesSummaryModel{
+				TargetID:    goframework.NumberUUID(1),
+				Target:      "target",
+				Counts:      map[models.VoteValue]int{models.VoteValueRating: 4},
+				Ratings:     map[int]int{2: 1, 4: 2, 5: 1},
+				RatingCount: 4,
+				RatingSum:   15,
+			},
+			shouldQueue: true,
+			expect: &models.VotesSummary{
+				Counts: map[models.VoteValue]int{models.VoteValueRating: 4},
+				Rating: &models.RatingSummary{
+					Count:     4,
+					Average:   3.75,
+					Histogram: map[int]int{2: 1, 4: 2, 5: 1},
+				},
+				Scores: services.ComputeVotesScores(0, 0),
+			},
+		},
+		{
+			name:     "Success/RatingRemoved",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			id:           goframework.NumberUUID(10),
+			now:          baseTime,
+			clientName:   "target",
+			targetRating: &models.RatingScale{Min: 1, Max: 5},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldGetLock:        true,
+			shouldCallTx:         true,
+			shouldCallCast:       true,
+			shouldCallGetSummary: true,
+			summary: &dao.VotesSummaryModel{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Counts:   map[models.VoteValue]int{},
+				Ratings:  map[int]int{},
+			},
+			shouldQueue: true,
+			expect: &models.VotesSummary{
+				Counts: map[models.VoteValue]int{},
+				Scores: services.ComputeVotesScores(0, 0),
+			},
+		},
+		{
+			name:     "Error/ScoreOutOfScale",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				Score:    lo.ToPtr(6),
+			},
+			id:           goframework.NumberUUID(10),
+			now:          baseTime,
+			clientName:   "target",
+			targetRating: &models.RatingScale{Min: 1, Max: 5},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			expectErr: services.ErrInvalidScore,
+		},
+		{
+			name:     "Error/VoteOnRatedTarget",
+			tokenRaw: "token",
+			form: models.VoteForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
 				Vote:     lo.ToPtr(models.VoteValueUp),
 			},
-			id:             goframework.NumberUUID(10),
-			now:            baseTime,
-			clientName:     "target",
-			authClientResp: &apiclients.UserTokenStatus{},
-			expectErr:      goframework.ErrInvalidCredentials,
+			id:           goframework.NumberUUID(10),
+			now:          baseTime,
+			clientName:   "target",
+			targetRating: &models.RatingScale{Min: 1, Max: 5},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			expectErr: services.ErrInvalidVoteValue,
 		},
 		{
-			name:     "Error/AuthClientFailure",
+			name:     "Error/ScoreOnVotedTarget",
 			tokenRaw: "token",
 			form: models.VoteForm{
 				TargetID: goframework.NumberUUID(1),
-				Target:   "fake-target",
+				Target:   "target",
 				Vote:     lo.ToPtr(models.VoteValueUp),
+				Score:    lo.ToPtr(4),
 			},
-			id:            goframework.NumberUUID(10),
-			now:           baseTime,
-			clientName:    "target",
-			authClientErr: fooErr,
-			expectErr:     fooErr,
+			id:         goframework.NumberUUID(10),
+			now:        baseTime,
+			clientName: "target",
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			expectErr: services.ErrInvalidScore,
 		},
 		{
 			name:     "Error/BadVote",
@@ -278,11 +1138,14 @@ func TestCastVoteService(t *testing.T) {
 
 			authClient.On("IntrospectToken", context.Background(), d.tokenRaw).Return(d.authClientResp, d.authClientErr)
 
-			if d.shouldCallDAO {
+			if d.shouldGetIdempotency {
 				repository.
-					On("Cast", context.Background(), d.authClientResp.Token.Payload.ID, d.form.TargetID, d.form.Target, d.form.Vote, d.id, d.now).
-					Return(nil, d.castErr)
+					On("GetIdempotencyKey", context.Background(), d.authClientResp.Token.Payload.ID, d.idempotencyKey, d.now).
+					Return(lo.Ternary(d.getIdempotencyErr == nil, d.idempotencyStored, nil), d.getIdempotencyErr).
+					Once()
+			}
 
+			if d.shouldCallTx {
 				// Execute the actual method, but call the mocks inside of it.
 				txCall := repository.On("RunInTx", context.Background(), mock.Anything)
 				txCall.Run(func(args mock.Arguments) {
@@ -291,24 +1154,99 @@ func TestCastVoteService(t *testing.T) {
 				})
 			}
 
+			if d.shouldClaim {
+				repository.
+					On("ClaimIdempotencyKey", context.Background(), d.authClientResp.Token.Payload.ID, d.idempotencyKey, services.HashVoteForm(d.form), d.now, d.now.Add(time.Hour)).
+					Return(d.claimed, d.claimErr)
+			}
+
+			if d.shouldGetAgain {
+				repository.
+					On("GetIdempotencyKey", context.Background(), d.authClientResp.Token.Payload.ID, d.idempotencyKey, d.now).
+					Return(d.idempotencyStored, nil).
+					Once()
+			}
+
+			if d.shouldGetLock {
+				repository.
+					On("GetTargetLock", context.Background(), d.form.TargetID, d.form.Target).
+					Return(d.lock, lo.Ternary(d.lock == nil && d.lockErr == nil, bunovel.ErrNotFound, d.lockErr))
+			}
+
+			if d.shouldCallCast {
+				repository.
+					On("Cast", context.Background(), d.authClientResp.Token.Payload.ID, d.form.TargetID, d.form.Target, lo.Ternary(d.form.Score != nil, lo.ToPtr(models.VoteValueRating), d.form.Vote), d.form.ExpectedVote, d.form.Score, lo.Ternary(d.weight == 0, models.DefaultVoteWeight, d.weight), d.id, d.now).
+					Return(nil, d.castErr)
+			}
+
 			if d.shouldCallGetSummary {
 				repository.
 					On("GetSummary", context.Background(), d.form.TargetID, d.form.Target).
 					Return(d.summary, d.summaryErr)
 			}
 
-			targets := map[string]models.CheckVoteClient{
-				d.clientName: func(ctx context.Context, id, userID uuid.UUID, upVotes, downVotes int) error {
-					return d.clientErr
+			if d.shouldQueue {
+				repository.
+					On("QueueSummaryUpdate", context.Background(), d.authClientResp.Token.Payload.ID, d.summary, d.now).
+					Return(d.queueErr)
+			}
+
+			if d.shouldSaveIdempotency {
+				response, err := json.Marshal(&models.VotesSummary{
+					UpVotes:   d.summary.UpVotes,
+					DownVotes: d.summary.DownVotes,
+					Scores:    services.ComputeVotesScores(d.summary.UpVotes, d.summary.DownVotes),
+				})
+				require.NoError(t, err)
+
+				repository.
+					On("SaveIdempotencyResponse", context.Background(), d.authClientResp.Token.Payload.ID, d.idempotencyKey, json.RawMessage(response)).
+					Return(d.saveIdempotencyErr)
+			}
+
+			targetHandler := &fakeTargetHandler{authorizeErr: d.clientErr}
+			targets := map[string]*models.Target{
+				d.clientName: {
+					Name:    d.clientName,
+					Values:  lo.Ternary(d.targetValues == nil, []models.VoteValue{models.VoteValueUp, models.VoteValueDown}, d.targetValues),
+					Open:    !d.targetClosed,
+					Handler: targetHandler,
 				},
 			}
+			if d.weightResolver != nil {
+				targets[d.clientName].WeightResolver = d.weightResolver
+			}
+			if d.targetRating != nil {
+				targets[d.clientName].Values = []models.VoteValue{models.VoteValueRating}
+				targets[d.clientName].Rating = d.targetRating
+			}
 
-			service := services.NewCastVoteService(repository, authClient, targets)
-			res, err := service.Cast(context.Background(), d.tokenRaw, d.form, d.id, d.now)
+			rateLimiter := &fakeRateLimiter{limited: d.rateLimited, err: d.rateLimitErr}
+
+			service := services.NewCastVoteService(repository, authClient, targets, rateLimiter, services.CastVoteConfig{
+				IdempotencyTTL:  time.Hour,
+				UserRateLimit:   models.RateLimit{Burst: 30, Interval: time.Second},
+				TargetRateLimit: models.RateLimit{Burst: 5, Interval: 10 * time.Second},
+			})
+			res, err := service.Cast(context.Background(), d.tokenRaw, d.form, d.idempotencyKey, d.id, d.now)
 
 			require.ErrorIs(t, err, d.expectErr)
 			require.Equal(t, d.expect, res)
 
+			if d.expectRetryAfter > 0 {
+				var rateLimited *models.RateLimitError
+				require.ErrorAs(t, err, &rateLimited)
+				require.Equal(t, d.expectRetryAfter, rateLimited.RetryAfter)
+			}
+
+			// The target is notified through the outbox.
+			require.Empty(t, targetHandler.received)
+
+			// Unauthorized votes don't consume the tokens of the user.
+			if d.clientErr != nil {
+				require.Empty(t, rateLimiter.checked)
+			}
+
 			repository.AssertExpectations(t)
 			authClient.AssertExpectations(t)
 		})
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	apiclients "github.com/a-novel/go-apis/clients"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"strings"
+	"time"
+)
+
+type CreatePollService interface {
+	Create(ctx context.Context, tokenRaw string, form models.CreatePollForm, id uuid.UUID, now time.Time) (*models.Poll, error)
+}
+
+func NewCreatePollService(
+	repository dao.PollsRepository,
+	authClient apiclients.AuthClient,
+	permissionsClient apiclients.PermissionsClient,
+	pollScope apiclients.Scope,
+) CreatePollService {
+	return &createPollServiceImpl{
+		repository:        repository,
+		authClient:        authClient,
+		permissionsClient: permissionsClient,
+		pollScope:         pollScope,
+	}
+}
+
+type createPollServiceImpl struct {
+	repository        dao.PollsRepository
+	authClient        apiclients.AuthClient
+	permissionsClient apiclients.PermissionsClient
+
+	pollScope apiclients.Scope
+}
+
+func (s *createPollServiceImpl) Create(ctx context.Context, tokenRaw string, form models.CreatePollForm, id uuid.UUID, now time.Time) (*models.Poll, error) {
+	userID, err := checkUserScope(ctx, s.authClient, s.permissionsClient, tokenRaw, s.pollScope)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(form.Question) == "" || len(form.Question) > MaxPollQuestionLength {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidQuestion)
+	}
+
+	if err := goframework.CheckMinMax(len(form.Options), MinPollOptions, MaxPollOptions); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidOptions, err)
+	}
+	for _, option := range form.Options {
+		if strings.TrimSpace(option) == "" || len(option) > MaxPollOptionLength {
+			return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidOptions)
+		}
+	}
+	// Users could not tell identical options apart.
+	if len(lo.Uniq(form.Options)) != len(form.Options) {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidOptions)
+	}
+
+	// A ranked ballot already expresses a preference over several options.
+	if form.Ranked && form.MultiSelect {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPollKind)
+	}
+
+	opensAt := lo.FromPtrOr(form.OpensAt, now)
+	if form.ClosesAt != nil && (!form.ClosesAt.After(opensAt) || !form.ClosesAt.After(now)) {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPollDates)
+	}
+	// Otherwise, the results would never be revealed.
+	if form.HideResults && form.ClosesAt == nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidPollDates)
+	}
+
+	poll, err := s.repository.Create(ctx, &dao.PollModelCore{
+		Question:    form.Question,
+		Options:     form.Options,
+		MultiSelect: form.MultiSelect,
+		OpensAt:     opensAt,
+		ClosesAt:    form.ClosesAt,
+		HideResults: form.HideResults,
+		Ranked:      form.Ranked,
+	}, userID, id, now)
+	if err != nil {
+		return nil, goerrors.Join(ErrCreatePoll, err)
+	}
+
+	return adapters.PollToModel(poll), nil
+}
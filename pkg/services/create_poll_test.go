@@ -0,0 +1,382 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreatePollService(t *testing.T) {
+	pollScope := apiclients.Scope("can_moderate_votes")
+
+	moderatorToken := &apiclients.UserTokenStatus{
+		OK: true,
+		Token: &apiclients.UserToken{
+			Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		form     models.CreatePollForm
+
+		authClientResp *apiclients.UserTokenStatus
+		authClientErr  error
+
+		shouldCallPermissions bool
+		permissionsErr        error
+
+		shouldCallDAO     bool
+		shouldCallDAOWith *dao.PollModelCore
+		daoResp           *dao.PollModel
+		daoErr            error
+
+		expect    *models.Poll
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question: "Which feature next?",
+				Options:  []string{"Dark mode", "Bookmarks"},
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: &dao.PollModelCore{
+				Question: "Which feature next?",
+				Options:  []string{"Dark mode", "Bookmarks"},
+				OpensAt:  baseTime,
+			},
+			daoResp: &dao.PollModel{
+				Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CreatedBy: goframework.NumberUUID(100),
+				PollModelCore: dao.PollModelCore{
+					Question: "Which feature next?",
+					Options:  []string{"Dark mode", "Bookmarks"},
+					OpensAt:  baseTime,
+				},
+			},
+			expect: &models.Poll{
+				ID:        goframework.NumberUUID(1),
+				CreatedAt: baseTime,
+				CreatedBy: goframework.NumberUUID(100),
+				Question:  "Which feature next?",
+				Options:   []string{"Dark mode", "Bookmarks"},
+				OpensAt:   baseTime,
+			},
+		},
+		{
+			name:     "Success/Scheduled",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question:    "Which feature next?",
+				Options:     []string{"Dark mode", "Bookmarks", "Offline reading"},
+				MultiSelect: true,
+				OpensAt:     lo.ToPtr(updateTime),
+				ClosesAt:    lo.ToPtr(updateTime.Add(24 * time.Hour)),
+				HideResults: true,
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: &dao.PollModelCore{
+				Question:    "Which feature next?",
+				Options:     []string{"Dark mode", "Bookmarks", "Offline reading"},
+				MultiSelect: true,
+				OpensAt:     updateTime,
+				ClosesAt:    lo.ToPtr(updateTime.Add(24 * time.Hour)),
+				HideResults: true,
+			},
+			daoResp: &dao.PollModel{
+				Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CreatedBy: goframework.NumberUUID(100),
+				PollModelCore: dao.PollModelCore{
+					Question:    "Which feature next?",
+					Options:     []string{"Dark mode", "Bookmarks", "Offline reading"},
+					MultiSelect: true,
+					OpensAt:     updateTime,
+					ClosesAt:    lo.ToPtr(updateTime.Add(24 * time.Hour)),
+					HideResults: true,
+				},
+			},
+			expect: &models.Poll{
+				ID:          goframework.NumberUUID(1),
+				CreatedAt:   baseTime,
+				CreatedBy:   goframework.NumberUUID(100),
+				Question:    "Which feature next?",
+				Options:     []string{"Dark mode", "Bookmarks", "Offline reading"},
+				MultiSelect: true,
+				OpensAt:     updateTime,
+				ClosesAt:    lo.ToPtr(updateTime.Add(24 * time.Hour)),
+				HideResults: true,
+			},
+		},
+		{
+			name:     "Success/Ranked",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question: "Which story should be featured?",
+				Options:  []string{"Story A", "Story B"},
+				Ranked:   true,
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: &dao.PollModelCore{
+				Question: "Which story should be featured?",
+				Options:  []string{"Story A", "Story B"},
+				OpensAt:  baseTime,
+				Ranked:   true,
+			},
+			daoResp: &dao.PollModel{
+				Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CreatedBy: goframework.NumberUUID(100),
+				PollModelCore: dao.PollModelCore{
+					Question: "Which story should be featured?",
+					Options:  []string{"Story A", "Story B"},
+					OpensAt:  baseTime,
+					Ranked:   true,
+				},
+			},
+			expect: &models.Poll{
+				ID:        goframework.NumberUUID(1),
+				CreatedAt: baseTime,
+				CreatedBy: goframework.NumberUUID(100),
+				Question:  "Which story should be featured?",
+				Options:   []string{"Story A", "Story B"},
+				OpensAt:   baseTime,
+				Ranked:    true,
+			},
+		},
+		{
+			name:     "Error/DAOFailure",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question: "Which feature next?",
+				Options:  []string{"Dark mode", "Bookmarks"},
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: &dao.PollModelCore{
+				Question: "Which feature next?",
+				Options:  []string{"Dark mode", "Bookmarks"},
+				OpensAt:  baseTime,
+			},
+			daoErr:    fooErr,
+			expectErr: services.ErrCreatePoll,
+		},
+		{
+			name:     "Error/MissingQuestion",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question: "  ",
+				Options:  []string{"Dark mode", "Bookmarks"},
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidQuestion,
+		},
+		{
+			name:     "Error/QuestionTooLong",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question: strings.Repeat("a", services.MaxPollQuestionLength+1),
+				Options:  []string{"Dark mode", "Bookmarks"},
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidQuestion,
+		},
+		{
+			name:     "Error/NotEnoughOptions",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question: "Which feature next?",
+				Options:  []string{"Dark mode"},
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidOptions,
+		},
+		{
+			name:     "Error/TooManyOptions",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question: "Which feature next?",
+				Options: lo.Times(services.MaxPollOptions+1, func(index int) string {
+					return strings.Repeat("a", index+1)
+				}),
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidOptions,
+		},
+		{
+			name:     "Error/EmptyOption",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question: "Which feature next?",
+				Options:  []string{"Dark mode", ""},
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidOptions,
+		},
+		{
+			name:     "Error/OptionTooLong",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question: "Which feature next?",
+				Options:  []string{"Dark mode", strings.Repeat("a", services.MaxPollOptionLength+1)},
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidOptions,
+		},
+		{
+			name:     "Error/DuplicateOptions",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question: "Which feature next?",
+				Options:  []string{"Dark mode", "Dark mode"},
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidOptions,
+		},
+		{
+			name:     "Error/RankedMultiSelect",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question:    "Which story should be featured?",
+				Options:     []string{"Story A", "Story B"},
+				MultiSelect: true,
+				Ranked:      true,
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidPollKind,
+		},
+		{
+			name:     "Error/ClosesBeforeOpening",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question: "Which feature next?",
+				Options:  []string{"Dark mode", "Bookmarks"},
+				OpensAt:  lo.ToPtr(updateTime),
+				ClosesAt: lo.ToPtr(updateTime),
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidPollDates,
+		},
+		{
+			name:     "Error/AlreadyClosed",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question: "Which feature next?",
+				Options:  []string{"Dark mode", "Bookmarks"},
+				OpensAt:  lo.ToPtr(baseTime.Add(-2 * time.Hour)),
+				ClosesAt: lo.ToPtr(baseTime.Add(-time.Hour)),
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidPollDates,
+		},
+		{
+			name:     "Error/HiddenResultsWithoutClose",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question:    "Which feature next?",
+				Options:     []string{"Dark mode", "Bookmarks"},
+				HideResults: true,
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidPollDates,
+		},
+		{
+			name:     "Error/MissingPermission",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question: "Which feature next?",
+				Options:  []string{"Dark mode", "Bookmarks"},
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			permissionsErr:        fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:     "Error/NotAuthenticated",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question: "Which feature next?",
+				Options:  []string{"Dark mode", "Bookmarks"},
+			},
+			authClientResp: &apiclients.UserTokenStatus{},
+			expectErr:      goframework.ErrInvalidCredentials,
+		},
+		{
+			name:     "Error/AuthClientFailure",
+			tokenRaw: "token",
+			form: models.CreatePollForm{
+				Question: "Which feature next?",
+				Options:  []string{"Dark mode", "Bookmarks"},
+			},
+			authClientErr: fooErr,
+			expectErr:     fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewPollsRepository(t)
+			authClient := apiclientsmocks.NewAuthClient(t)
+			permissionsClient := apiclientsmocks.NewPermissionsClient(t)
+
+			authClient.On("IntrospectToken", context.Background(), d.tokenRaw).Return(d.authClientResp, d.authClientErr)
+
+			if d.shouldCallPermissions {
+				permissionsClient.
+					On("HasUserScope", context.Background(), apiclients.HasUserScopeQuery{
+						UserID: d.authClientResp.Token.Payload.ID,
+						Scope:  pollScope,
+					}).
+					Return(d.permissionsErr)
+			}
+
+			if d.shouldCallDAO {
+				repository.
+					On("Create", context.Background(), d.shouldCallDAOWith, d.authClientResp.Token.Payload.ID, goframework.NumberUUID(1), baseTime).
+					Return(d.daoResp, d.daoErr)
+			}
+
+			service := services.NewCreatePollService(repository, authClient, permissionsClient, pollScope)
+			res, err := service.Create(context.Background(), d.tokenRaw, d.form, goframework.NumberUUID(1), baseTime)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+			authClient.AssertExpectations(t)
+			permissionsClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"encoding/base64"
+	goerrors "errors"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"strings"
+	"time"
+)
+
+// EncodeVotesCursor returns an opaque cursor, pointing to the given vote in a list ordered by activity date.
+func EncodeVotesCursor(vote *dao.VoteModel) string {
+	activityAt := lo.Ternary(vote.UpdatedAt == nil, vote.CreatedAt, lo.FromPtr(vote.UpdatedAt))
+	raw := activityAt.UTC().Format(time.RFC3339Nano) + "|" + vote.ID.String()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeVotesCursor reads a cursor returned by EncodeVotesCursor.
+func DecodeVotesCursor(cursor string) (*dao.VotesCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, goerrors.Join(ErrInvalidCursor, err)
+	}
+
+	activityAtRaw, idRaw, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	activityAt, err := time.Parse(time.RFC3339Nano, activityAtRaw)
+	if err != nil {
+		return nil, goerrors.Join(ErrInvalidCursor, err)
+	}
+
+	id, err := uuid.Parse(idRaw)
+	if err != nil {
+		return nil, goerrors.Join(ErrInvalidCursor, err)
+	}
+
+	return &dao.VotesCursor{ActivityAt: activityAt, ID: id}, nil
+}
+
+// newVotesPage builds a page from the result of a query for limit+1 votes: the extra vote, if any, means there is a
+// next page.
+func newVotesPage(votes []*dao.VoteModel, limit, total int) *models.VotesPage {
+	page := &models.VotesPage{Total: total}
+	if len(votes) > limit {
+		votes = votes[:limit]
+		page.NextCursor = EncodeVotesCursor(votes[len(votes)-1])
+	}
+
+	page.Votes = lo.Map(votes, func(item *dao.VoteModel, _ int) *models.Vote {
+		return adapters.VoteToModel(item)
+	})
+
+	return page
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	goerrors "errors"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"sort"
+	"time"
+)
+
+type DetectVoteFlagsService interface {
+	// Detect scans the recent votes for suspicious patterns, and flags them for review. It returns the number of new
+	// flags.
+	Detect(ctx context.Context, now time.Time) (int, error)
+}
+
+// VoteFlagsConfig configures the detection of suspicious voting patterns.
+type VoteFlagsConfig struct {
+	// Lookback is the period of activity scanned by each detection.
+	Lookback time.Duration
+
+	// CoVotingWindow is the maximum delay between two votes for them to count as cast together.
+	CoVotingWindow time.Duration
+	// MinCoVotedTargets is the number of targets two users must have voted on together to be linked.
+	MinCoVotedTargets int
+	// MinCoVotingGroup is the minimum size of a group of linked users to be flagged.
+	MinCoVotingGroup int
+
+	// NewVoterAge is how long users are considered new after their first vote.
+	NewVoterAge time.Duration
+	// MinNewVoters is the number of new voters casting the same vote on a target for it to be flagged.
+	MinNewVoters int
+
+	// MinFlips is the number of votes a user must change or retract on a target to be flagged.
+	MinFlips int
+}
+
+func NewDetectVoteFlagsService(repository dao.VoteFlagsRepository, config VoteFlagsConfig) DetectVoteFlagsService {
+	return &detectVoteFlagsServiceImpl{
+		repository: repository,
+		config:     config,
+	}
+}
+
+type detectVoteFlagsServiceImpl struct {
+	repository dao.VoteFlagsRepository
+	config     VoteFlagsConfig
+}
+
+func (s *detectVoteFlagsServiceImpl) Detect(ctx context.Context, now time.Time) (int, error) {
+	since := now.Add(-s.config.Lookback)
+
+	pairs, err := s.repository.ListCoVotingPairs(ctx, since, s.config.CoVotingWindow, s.config.MinCoVotedTargets)
+	if err != nil {
+		return 0, goerrors.Join(ErrDetectVoteFlags, err)
+	}
+
+	swarms, err := s.repository.ListNewVoterSwarms(ctx, since, now.Add(-s.config.NewVoterAge), s.config.MinNewVoters)
+	if err != nil {
+		return 0, goerrors.Join(ErrDetectVoteFlags, err)
+	}
+
+	bursts, err := s.repository.ListFlipBursts(ctx, since, s.config.MinFlips)
+	if err != nil {
+		return 0, goerrors.Join(ErrDetectVoteFlags, err)
+	}
+
+	flags := coVotingFlags(pairs, s.config.MinCoVotingGroup, now)
+
+	for _, swarm := range swarms {
+		flags = append(flags, newVoteFlag(
+			models.VoteFlagKindNewVoters, swarm.Target, swarm.TargetID.String()+":"+string(swarm.Vote),
+			[]uuid.UUID{swarm.TargetID}, swarm.UserIDs, len(swarm.UserIDs), now,
+		))
+	}
+
+	for _, burst := range bursts {
+		flags = append(flags, newVoteFlag(
+			models.VoteFlagKindFlipBurst, burst.Target, burst.UserID.String(),
+			burst.TargetIDs, []uuid.UUID{burst.UserID}, burst.Changes, now,
+		))
+	}
+
+	saved, err := s.repository.Save(ctx, flags)
+	if err != nil {
+		return 0, goerrors.Join(ErrSaveVoteFlags, err)
+	}
+
+	return saved, nil
+}
+
+// coVotingFlags merges the pairs of users who voted together into groups, so a ring of accounts is reported once
+// rather than as every pair it contains.
+func coVotingFlags(pairs []*dao.CoVotingPairModel, minGroup int, now time.Time) []*dao.VoteFlagModel {
+	parents := make(map[string]map[uuid.UUID]uuid.UUID)
+
+	var find func(parent map[uuid.UUID]uuid.UUID, user uuid.UUID) uuid.UUID
+	find = func(parent map[uuid.UUID]uuid.UUID, user uuid.UUID) uuid.UUID {
+		if parent[user] == user {
+			return user
+		}
+
+		root := find(parent, parent[user])
+		parent[user] = root
+		return root
+	}
+
+	for _, pair := range pairs {
+		parent, ok := parents[pair.Target]
+		if !ok {
+			parent = make(map[uuid.UUID]uuid.UUID)
+			parents[pair.Target] = parent
+		}
+
+		for _, user := range []uuid.UUID{pair.UserA, pair.UserB} {
+			if _, ok := parent[user]; !ok {
+				parent[user] = user
+			}
+		}
+
+		parent[find(parent, pair.UserA)] = find(parent, pair.UserB)
+	}
+
+	type group struct {
+		target    string
+		users     []uuid.UUID
+		targetIDs []uuid.UUID
+	}
+
+	groups := make(map[string]map[uuid.UUID]*group)
+	for _, pair := range pairs {
+		if groups[pair.Target] == nil {
+			groups[pair.Target] = make(map[uuid.UUID]*group)
+		}
+
+		root := find(parents[pair.Target], pair.UserA)
+		current, ok := groups[pair.Target][root]
+		if !ok {
+			current = &group{target: pair.Target}
+			groups[pair.Target][root] = current
+		}
+
+		current.users = append(current.users, pair.UserA, pair.UserB)
+		current.targetIDs = append(current.targetIDs, pair.TargetIDs...)
+	}
+
+	flags := make([]*dao.VoteFlagModel, 0)
+	for _, targetGroups := range groups {
+		for _, current := range targetGroups {
+			users := lo.Uniq(current.users)
+			if len(users) < minGroup {
+				continue
+			}
+
+			// A ring is identified by its smallest member, which only changes when a smaller one joins.
+			users = sortUUIDs(users)
+			targetIDs := lo.Uniq(current.targetIDs)
+			flags = append(flags, newVoteFlag(
+				models.VoteFlagKindCoVoting, current.target, users[0].String(), targetIDs, users, len(targetIDs), now,
+			))
+		}
+	}
+
+	// Map iteration is random: keep the output stable.
+	sort.Slice(flags, func(i, j int) bool {
+		if flags[i].Target != flags[j].Target {
+			return flags[i].Target < flags[j].Target
+		}
+
+		return bytes.Compare(flags[i].UserIDs[0][:], flags[j].UserIDs[0][:]) < 0
+	})
+
+	return flags
+}
+
+// newVoteFlag reports a finding. The key identifies the finding within its kind and target: the same finding is
+// reported by every run of the job, often with more evidence, and must keep the same fingerprint.
+func newVoteFlag(
+	kind models.VoteFlagKind, target, key string, targetIDs, userIDs []uuid.UUID, occurrences int, now time.Time,
+) *dao.VoteFlagModel {
+	hash := sha256.New()
+	hash.Write([]byte(kind))
+	hash.Write([]byte{0})
+	hash.Write([]byte(target))
+	hash.Write([]byte{0})
+	hash.Write([]byte(key))
+
+	return &dao.VoteFlagModel{
+		CreatedAt:   now,
+		Kind:        kind,
+		Target:      target,
+		TargetIDs:   sortUUIDs(targetIDs),
+		UserIDs:     sortUUIDs(userIDs),
+		Occurrences: occurrences,
+		Fingerprint: hex.EncodeToString(hash.Sum(nil)),
+	}
+}
+
+func sortUUIDs(ids []uuid.UUID) []uuid.UUID {
+	sorted := append([]uuid.UUID{}, ids...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i][:], sorted[j][:]) < 0
+	})
+
+	return sorted
+}
@@ -0,0 +1,339 @@
+package services_test
+
+import (
+	"context"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDetectVoteFlagsService(t *testing.T) {
+	config := services.VoteFlagsConfig{
+		Lookback:          24 * time.Hour,
+		CoVotingWindow:    5 * time.Minute,
+		MinCoVotedTargets: 3,
+		MinCoVotingGroup:  3,
+		NewVoterAge:       72 * time.Hour,
+		MinNewVoters:      5,
+		MinFlips:          20,
+	}
+
+	data := []struct {
+		name string
+
+		pairs    []*dao.CoVotingPairModel
+		pairsErr error
+
+		shouldCallSwarms bool
+		swarms           []*dao.NewVoterSwarmModel
+		swarmsErr        error
+
+		shouldCallBursts bool
+		bursts           []*dao.FlipBurstModel
+		burstsErr        error
+
+		shouldSave bool
+		saveResp   int
+		saveErr    error
+
+		expectSaved []*dao.VoteFlagModel
+		expect      int
+		expectErr   error
+	}{
+		{
+			name: "Success",
+			pairs: []*dao.CoVotingPairModel{
+				{
+					Target:    "target",
+					UserA:     goframework.NumberUUID(1),
+					UserB:     goframework.NumberUUID(2),
+					TargetIDs: []uuid.UUID{goframework.NumberUUID(10), goframework.NumberUUID(11), goframework.NumberUUID(12)},
+				},
+				{
+					Target:    "target",
+					UserA:     goframework.NumberUUID(2),
+					UserB:     goframework.NumberUUID(3),
+					TargetIDs: []uuid.UUID{goframework.NumberUUID(11), goframework.NumberUUID(12), goframework.NumberUUID(13)},
+				},
+				// Too small to be flagged.
+				{
+					Target:    "target",
+					UserA:     goframework.NumberUUID(4),
+					UserB:     goframework.NumberUUID(5),
+					TargetIDs: []uuid.UUID{goframework.NumberUUID(20), goframework.NumberUUID(21), goframework.NumberUUID(22)},
+				},
+				// Groups are formed within a target.
+				{
+					Target:    "other-target",
+					UserA:     goframework.NumberUUID(1),
+					UserB:     goframework.NumberUUID(3),
+					TargetIDs: []uuid.UUID{goframework.NumberUUID(10), goframework.NumberUUID(11), goframework.NumberUUID(12)},
+				},
+			},
+			shouldCallSwarms: true,
+			swarms: []*dao.NewVoterSwarmModel{
+				{
+					Target:   "target",
+					TargetID: goframework.NumberUUID(30),
+					Vote:     models.VoteValueUp,
+					UserIDs: []uuid.UUID{
+						goframework.NumberUUID(6),
+						goframework.NumberUUID(7),
+						goframework.NumberUUID(8),
+						goframework.NumberUUID(9),
+						goframework.NumberUUID(10),
+					},
+				},
+			},
+			shouldCallBursts: true,
+			bursts: []*dao.FlipBurstModel{
+				{
+					UserID:    goframework.NumberUUID(11),
+					Target:    "target",
+					TargetIDs: []uuid.UUID{goframework.NumberUUID(41), goframework.NumberUUID(40)},
+					Changes:   25,
+				},
+			},
+			shouldSave: true,
+			saveResp:   2,
+			expectSaved: []*dao.VoteFlagModel{
+				{
+					CreatedAt: baseTime,
+					Kind:      models.VoteFlagKindCoVoting,
+					Target:    "target",
+					TargetIDs: []uuid.UUID{
+						goframework.NumberUUID(10),
+						goframework.NumberUUID(11),
+						goframework.NumberUUID(12),
+						goframework.NumberUUID(13),
+					},
+					UserIDs:     []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2), goframework.NumberUUID(3)},
+					Occurrences: 4,
+				},
+				{
+					CreatedAt: baseTime,
+					Kind:      models.VoteFlagKindNewVoters,
+					Target:    "target",
+					TargetIDs: []uuid.UUID{goframework.NumberUUID(30)},
+					UserIDs: []uuid.UUID{
+						goframework.NumberUUID(6),
+						goframework.NumberUUID(7),
+						goframework.NumberUUID(8),
+						goframework.NumberUUID(9),
+						goframework.NumberUUID(10),
+					},
+					Occurrences: 5,
+				},
+				{
+					CreatedAt:   baseTime,
+					Kind:        models.VoteFlagKindFlipBurst,
+					Target:      "target",
+					TargetIDs:   []uuid.UUID{goframework.NumberUUID(40), goframework.NumberUUID(41)},
+					UserIDs:     []uuid.UUID{goframework.NumberUUID(11)},
+					Occurrences: 25,
+				},
+			},
+			expect: 2,
+		},
+		{
+			name:             "Success/NothingFound",
+			pairs:            []*dao.CoVotingPairModel{},
+			shouldCallSwarms: true,
+			swarms:           []*dao.NewVoterSwarmModel{},
+			shouldCallBursts: true,
+			bursts:           []*dao.FlipBurstModel{},
+			shouldSave:       true,
+			expectSaved:      []*dao.VoteFlagModel{},
+		},
+		{
+			name:             "Error/SaveFailure",
+			pairs:            []*dao.CoVotingPairModel{},
+			shouldCallSwarms: true,
+			swarms:           []*dao.NewVoterSwarmModel{},
+			shouldCallBursts: true,
+			bursts:           []*dao.FlipBurstModel{},
+			shouldSave:       true,
+			saveErr:          fooErr,
+			expectSaved:      []*dao.VoteFlagModel{},
+			expectErr:        fooErr,
+		},
+		{
+			name:             "Error/ListFlipBurstsFailure",
+			pairs:            []*dao.CoVotingPairModel{},
+			shouldCallSwarms: true,
+			swarms:           []*dao.NewVoterSwarmModel{},
+			shouldCallBursts: true,
+			burstsErr:        fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:             "Error/ListNewVoterSwarmsFailure",
+			pairs:            []*dao.CoVotingPairModel{},
+			shouldCallSwarms: true,
+			swarmsErr:        fooErr,
+			expectErr:        fooErr,
+		},
+		{
+			name:      "Error/ListCoVotingPairsFailure",
+			pairsErr:  fooErr,
+			expectErr: fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewVoteFlagsRepository(t)
+
+			since := baseTime.Add(-config.Lookback)
+
+			repository.
+				On("ListCoVotingPairs", context.Background(), since, config.CoVotingWindow, config.MinCoVotedTargets).
+				Return(d.pairs, d.pairsErr)
+
+			if d.shouldCallSwarms {
+				repository.
+					On("ListNewVoterSwarms", context.Background(), since, baseTime.Add(-config.NewVoterAge), config.MinNewVoters).
+					Return(d.swarms, d.swarmsErr)
+			}
+
+			if d.shouldCallBursts {
+				repository.
+					On("ListFlipBursts", context.Background(), since, config.MinFlips).
+					Return(d.bursts, d.burstsErr)
+			}
+
+			var saved []*dao.VoteFlagModel
+			if d.shouldSave {
+				repository.
+					On("Save", context.Background(), mock.Anything).
+					Run(func(args mock.Arguments) {
+						saved = args.Get(1).([]*dao.VoteFlagModel)
+					}).
+					Return(d.saveResp, d.saveErr)
+			}
+
+			service := services.NewDetectVoteFlagsService(repository, config)
+			res, err := service.Detect(context.Background(), baseTime)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			if d.shouldSave {
+				// Fingerprints are opaque, but must tell the findings apart.
+				fingerprints := lo.Map(saved, func(item *dao.VoteFlagModel, _ int) string {
+					require.NotEmpty(t, item.Fingerprint)
+					return item.Fingerprint
+				})
+				require.Len(t, lo.Uniq(fingerprints), len(saved))
+
+				require.Equal(t, d.expectSaved, lo.Map(saved, func(item *dao.VoteFlagModel, _ int) *dao.VoteFlagModel {
+					item.Fingerprint = ""
+					return item
+				}))
+			}
+
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+// The same finding must keep its fingerprint as it grows between two runs of the job, so it updates its pending flag
+// rather than creating a new one.
+func TestDetectVoteFlagsService_StableFingerprints(t *testing.T) {
+	config := services.VoteFlagsConfig{
+		Lookback:          24 * time.Hour,
+		CoVotingWindow:    5 * time.Minute,
+		MinCoVotedTargets: 3,
+		MinCoVotingGroup:  3,
+		NewVoterAge:       72 * time.Hour,
+		MinNewVoters:      2,
+		MinFlips:          20,
+	}
+
+	runs := []struct {
+		pairs  []*dao.CoVotingPairModel
+		swarms []*dao.NewVoterSwarmModel
+		bursts []*dao.FlipBurstModel
+	}{
+		{
+			pairs: []*dao.CoVotingPairModel{
+				{Target: "target", UserA: goframework.NumberUUID(1), UserB: goframework.NumberUUID(2), TargetIDs: []uuid.UUID{goframework.NumberUUID(10)}},
+				{Target: "target", UserA: goframework.NumberUUID(2), UserB: goframework.NumberUUID(3), TargetIDs: []uuid.UUID{goframework.NumberUUID(10)}},
+			},
+			swarms: []*dao.NewVoterSwarmModel{
+				{
+					Target:   "target",
+					TargetID: goframework.NumberUUID(30),
+					Vote:     models.VoteValueUp,
+					UserIDs:  []uuid.UUID{goframework.NumberUUID(6), goframework.NumberUUID(7)},
+				},
+			},
+			bursts: []*dao.FlipBurstModel{
+				{UserID: goframework.NumberUUID(11), Target: "target", TargetIDs: []uuid.UUID{goframework.NumberUUID(40)}, Changes: 20},
+			},
+		},
+		{
+			pairs: []*dao.CoVotingPairModel{
+				{Target: "target", UserA: goframework.NumberUUID(1), UserB: goframework.NumberUUID(2), TargetIDs: []uuid.UUID{goframework.NumberUUID(10)}},
+				{Target: "target", UserA: goframework.NumberUUID(2), UserB: goframework.NumberUUID(3), TargetIDs: []uuid.UUID{goframework.NumberUUID(11)}},
+				{Target: "target", UserA: goframework.NumberUUID(3), UserB: goframework.NumberUUID(4), TargetIDs: []uuid.UUID{goframework.NumberUUID(12)}},
+			},
+			swarms: []*dao.NewVoterSwarmModel{
+				{
+					Target:   "target",
+					TargetID: goframework.NumberUUID(30),
+					Vote:     models.VoteValueUp,
+					UserIDs:  []uuid.UUID{goframework.NumberUUID(6), goframework.NumberUUID(7), goframework.NumberUUID(8)},
+				},
+			},
+			bursts: []*dao.FlipBurstModel{
+				{
+					UserID:    goframework.NumberUUID(11),
+					Target:    "target",
+					TargetIDs: []uuid.UUID{goframework.NumberUUID(40), goframework.NumberUUID(41)},
+					Changes:   25,
+				},
+			},
+		},
+	}
+
+	fingerprints := make([][]string, len(runs))
+	for i, run := range runs {
+		repository := daomocks.NewVoteFlagsRepository(t)
+		since := baseTime.Add(-config.Lookback)
+
+		repository.
+			On("ListCoVotingPairs", context.Background(), since, config.CoVotingWindow, config.MinCoVotedTargets).
+			Return(run.pairs, nil)
+		repository.
+			On("ListNewVoterSwarms", context.Background(), since, baseTime.Add(-config.NewVoterAge), config.MinNewVoters).
+			Return(run.swarms, nil)
+		repository.
+			On("ListFlipBursts", context.Background(), since, config.MinFlips).
+			Return(run.bursts, nil)
+		repository.
+			On("Save", context.Background(), mock.Anything).
+			Run(func(args mock.Arguments) {
+				fingerprints[i] = lo.Map(args.Get(1).([]*dao.VoteFlagModel), func(item *dao.VoteFlagModel, _ int) string {
+					return item.Fingerprint
+				})
+			}).
+			Return(0, nil)
+
+		_, err := services.NewDetectVoteFlagsService(repository, config).Detect(context.Background(), baseTime)
+		require.NoError(t, err)
+
+		repository.AssertExpectations(t)
+	}
+
+	require.Len(t, fingerprints[0], 3)
+	require.Equal(t, fingerprints[0], fingerprints[1])
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"time"
+)
+
+type DispatchOutboxService interface {
+	// Dispatch delivers a batch of pending messages, and returns the number of messages successfully delivered.
+	Dispatch(ctx context.Context, now time.Time) (int, error)
+}
+
+// OutboxConfig configures the delivery of the outbox messages.
+type OutboxConfig struct {
+	// BatchSize is the maximum number of messages delivered by a single Dispatch call.
+	BatchSize int
+	// Lease hides claimed messages from other dispatchers, while they are being delivered.
+	Lease time.Duration
+	// MaxAttempts is the number of failed deliveries after which a message is dead-lettered.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry. It doubles after each failure, up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+func NewDispatchOutboxService(repository dao.OutboxRepository, targets map[string]*models.Target, config OutboxConfig) DispatchOutboxService {
+	return &dispatchOutboxServiceImpl{
+		repository: repository,
+		targets:    targets,
+		config:     config,
+	}
+}
+
+type dispatchOutboxServiceImpl struct {
+	repository dao.OutboxRepository
+	targets    map[string]*models.Target
+	config     OutboxConfig
+}
+
+func (s *dispatchOutboxServiceImpl) Dispatch(ctx context.Context, now time.Time) (int, error) {
+	messages, err := s.repository.Claim(ctx, now, now.Add(s.config.Lease), s.config.BatchSize)
+	if err != nil {
+		return 0, goerrors.Join(ErrClaimOutbox, err)
+	}
+
+	var (
+		delivered int
+		errs      []error
+	)
+
+	// A failing message must not prevent the delivery of the others.
+	for _, message := range messages {
+		if err := s.deliver(ctx, message); err != nil {
+			if err := s.fail(ctx, message, err, now); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		if err := s.repository.Acknowledge(ctx, message.ID, message.Version); err != nil {
+			errs = append(errs, goerrors.Join(ErrUpdateOutbox, err))
+			continue
+		}
+
+		delivered++
+	}
+
+	return delivered, goerrors.Join(errs...)
+}
+
+func (s *dispatchOutboxServiceImpl) deliver(ctx context.Context, message *dao.OutboxMessageModel) error {
+	target := s.targets[message.Target]
+	if target == nil {
+		return ErrInvalidTarget
+	}
+
+	update := &models.TargetUpdate{
+		Target:   message.Target,
+		TargetID: message.TargetID,
+		UserID:   message.UserID,
+		Summary: withScores(&models.VotesSummary{
+			UpVotes:           message.UpVotes,
+			DownVotes:         message.DownVotes,
+			WeightedUpVotes:   message.WeightedUpVotes,
+			WeightedDownVotes: message.WeightedDownVotes,
+			Counts:            message.Counts,
+			Rating:            adapters.RatingsToModel(message.Ratings),
+		}),
+		Weighted: target.WeightResolver != nil,
+	}
+	if err := target.Handler.Publish(ctx, update); err != nil {
+		return goerrors.Join(ErrSendVoteToTarget, err)
+	}
+
+	return nil
+}
+
+func (s *dispatchOutboxServiceImpl) fail(ctx context.Context, message *dao.OutboxMessageModel, cause error, now time.Time) error {
+	var err error
+
+	attempts := message.Attempts + 1
+	if attempts >= s.config.MaxAttempts {
+		err = s.repository.DeadLetter(ctx, message.ID, message.Version, cause.Error(), now)
+	} else {
+		err = s.repository.Retry(ctx, message.ID, message.Version, cause.Error(), now.Add(s.backoff(attempts)))
+	}
+
+	if err != nil {
+		return goerrors.Join(ErrUpdateOutbox, err)
+	}
+
+	return nil
+}
+
+// backoff returns the delay before the next delivery attempt, given the number of failed attempts so far.
+func (s *dispatchOutboxServiceImpl) backoff(attempts int) time.Duration {
+	delay := s.config.MinBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= s.config.MaxBackoff {
+			return s.config.MaxBackoff
+		}
+	}
+
+	return delay
+}
@@ -0,0 +1,239 @@
+package services_test
+
+import (
+	"context"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDispatchOutboxService(t *testing.T) {
+	config := services.OutboxConfig{
+		BatchSize:   10,
+		Lease:       time.Minute,
+		MaxAttempts: 3,
+		MinBackoff:  time.Second,
+		MaxBackoff:  3 * time.Second,
+	}
+
+	data := []struct {
+		name string
+
+		now time.Time
+
+		messages []*dao.OutboxMessageModel
+		claimErr error
+
+		failures map[uuid.UUID]error
+
+		expectAcknowledged []int64
+		acknowledgeErr     error
+		expectRetried      map[int64]time.Time
+		expectDeadLettered []int64
+
+		expect         int
+		expectReceived map[uuid.UUID]*models.TargetUpdate
+		expectErr      error
+	}{
+		{
+			name: "Success",
+			now:  baseTime,
+			messages: []*dao.OutboxMessageModel{
+				{ID: 1, Version: 2, Target: "target", TargetID: goframework.NumberUUID(1), UserID: goframework.NumberUUID(100), UpVotes: 10, DownVotes: 5},
+				{ID: 2, Target: "target", TargetID: goframework.NumberUUID(2), UserID: goframework.NumberUUID(100), UpVotes: 3},
+			},
+			expectAcknowledged: []int64{1, 2},
+			expect:             2,
+			expectReceived: map[uuid.UUID]*models.TargetUpdate{
+				goframework.NumberUUID(1): {
+					Target:   "target",
+					TargetID: goframework.NumberUUID(1),
+					UserID:   goframework.NumberUUID(100),
+					Summary:  &models.VotesSummary{UpVotes: 10, DownVotes: 5, Scores: services.ComputeVotesScores(10, 5)},
+				},
+				goframework.NumberUUID(2): {
+					Target:   "target",
+					TargetID: goframework.NumberUUID(2),
+					UserID:   goframework.NumberUUID(100),
+					Summary:  &models.VotesSummary{UpVotes: 3, DownVotes: 0, Scores: services.ComputeVotesScores(3, 0)},
+				},
+			},
+		},
+		{
+			name: "Success/Weighted",
+			now:  baseTime,
+			messages: []*dao.OutboxMessageModel{
+				{
+					ID:                1,
+					Target:            "weighted-target",
+					TargetID:          goframework.NumberUUID(1),
+					UserID:            goframework.NumberUUID(100),
+					UpVotes:           10,
+					DownVotes:         5,
+					WeightedUpVotes:   25,
+					WeightedDownVotes: 6,
+				},
+			},
+			expectAcknowledged: []int64{1},
+			expect:             1,
+			expectReceived: map[uuid.UUID]*models.TargetUpdate{
+				goframework.NumberUUID(1): {
+					Target:   "weighted-target",
+					TargetID: goframework.NumberUUID(1),
+					UserID:   goframework.NumberUUID(100),
+					Summary: &models.VotesSummary{
+						UpVotes:           10,
+						DownVotes:         5,
+						WeightedUpVotes:   25,
+						WeightedDownVotes: 6,
+						Scores:            services.ComputeVotesScores(10, 5),
+					},
+					Weighted: true,
+				},
+			},
+		},
+		{
+			name: "Success/Rated",
+			now:  baseTime,
+			messages: []*dao.OutboxMessageModel{
+				{
+					ID:       1,
+					Target:   "target",
+					TargetID: goframework.NumberUUID(1),
+					UserID:   goframework.NumberUUID(100),
+					Counts:   map[models.VoteValue]int{models.VoteValueRating: 3},
+					Ratings:  map[int]int{3: 1, 5: 2},
+				},
+			},
+			expectAcknowledged: []int64{1},
+			expect:             1,
+			expectReceived: map[uuid.UUID]*models.TargetUpdate{
+				goframework.NumberUUID(1): {
+					Target:   "target",
+					TargetID: goframework.NumberUUID(1),
+					UserID:   goframework.NumberUUID(100),
+					Summary: &models.VotesSummary{
+						Counts: map[models.VoteValue]int{models.VoteValueRating: 3},
+						Rating: &models.RatingSummary{
+							Count:     3,
+							Average:   13.0 / 3,
+							Histogram: map[int]int{3: 1, 5: 2},
+						},
+						Scores: services.ComputeVotesScores(0, 0),
+					},
+				},
+			},
+		},
+		{
+			name: "Success/Empty",
+			now:  baseTime,
+		},
+		{
+			name: "Success/Retry",
+			now:  baseTime,
+			messages: []*dao.OutboxMessageModel{
+				{ID: 1, Target: "target", TargetID: goframework.NumberUUID(1), UpVotes: 10, DownVotes: 5},
+				{ID: 2, Target: "target", TargetID: goframework.NumberUUID(2), UpVotes: 3, Attempts: 1},
+				{ID: 3, Target: "target", TargetID: goframework.NumberUUID(3), UpVotes: 4},
+			},
+			failures: map[uuid.UUID]error{
+				goframework.NumberUUID(1): fooErr,
+				goframework.NumberUUID(2): fooErr,
+			},
+			expectAcknowledged: []int64{3},
+			expectRetried: map[int64]time.Time{
+				1: baseTime.Add(time.Second),
+				2: baseTime.Add(2 * time.Second),
+			},
+			expect: 1,
+			expectReceived: map[uuid.UUID]*models.TargetUpdate{
+				goframework.NumberUUID(3): {
+					Target:   "target",
+					TargetID: goframework.NumberUUID(3),
+					Summary:  &models.VotesSummary{UpVotes: 4, DownVotes: 0, Scores: services.ComputeVotesScores(4, 0)},
+				},
+			},
+		},
+		{
+			name: "Success/DeadLetter",
+			now:  baseTime,
+			messages: []*dao.OutboxMessageModel{
+				{ID: 1, Target: "target", TargetID: goframework.NumberUUID(1), UpVotes: 10, Attempts: 2},
+				{ID: 2, Target: "fake-target", TargetID: goframework.NumberUUID(2), UpVotes: 3},
+			},
+			failures: map[uuid.UUID]error{
+				goframework.NumberUUID(1): fooErr,
+			},
+			expectRetried: map[int64]time.Time{
+				2: baseTime.Add(time.Second),
+			},
+			expectDeadLettered: []int64{1},
+		},
+		{
+			name:      "Error/ClaimFailure",
+			now:       baseTime,
+			claimErr:  fooErr,
+			expectErr: fooErr,
+		},
+		{
+			name: "Error/AcknowledgeFailure",
+			now:  baseTime,
+			messages: []*dao.OutboxMessageModel{
+				{ID: 1, Target: "target", TargetID: goframework.NumberUUID(1), UpVotes: 10, DownVotes: 5},
+			},
+			expectAcknowledged: []int64{1},
+			acknowledgeErr:     fooErr,
+			expectReceived: map[uuid.UUID]*models.TargetUpdate{
+				goframework.NumberUUID(1): {
+					Target:   "target",
+					TargetID: goframework.NumberUUID(1),
+					Summary:  &models.VotesSummary{UpVotes: 10, DownVotes: 5, Scores: services.ComputeVotesScores(10, 5)},
+				},
+			},
+			expectErr: fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewOutboxRepository(t)
+			targetHandler := &fakeTargetHandler{failures: d.failures}
+
+			repository.
+				On("Claim", context.Background(), d.now, d.now.Add(config.Lease), config.BatchSize).
+				Return(d.messages, d.claimErr)
+
+			for _, id := range d.expectAcknowledged {
+				repository.On("Acknowledge", context.Background(), id, mock.Anything).Return(d.acknowledgeErr)
+			}
+			for id, nextAttemptAt := range d.expectRetried {
+				repository.On("Retry", context.Background(), id, mock.Anything, mock.Anything, nextAttemptAt).Return(nil)
+			}
+			for _, id := range d.expectDeadLettered {
+				repository.On("DeadLetter", context.Background(), id, mock.Anything, mock.Anything, d.now).Return(nil)
+			}
+
+			targets := map[string]*models.Target{
+				"target":          {Name: "target", Open: true, Handler: targetHandler},
+				"weighted-target": {Name: "weighted-target", Open: true, Handler: targetHandler, WeightResolver: &fakeWeightResolver{}},
+			}
+
+			service := services.NewDispatchOutboxService(repository, targets, config)
+			res, err := service.Dispatch(context.Background(), d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			require.Equal(t, d.expectReceived, targetHandler.received)
+
+			repository.AssertExpectations(t)
+		})
+	}
+}
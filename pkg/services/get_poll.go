@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+)
+
+type GetPollService interface {
+	Get(ctx context.Context, id uuid.UUID) (*models.Poll, error)
+}
+
+func NewGetPollService(repository dao.PollsRepository) GetPollService {
+	return &getPollServiceImpl{
+		repository: repository,
+	}
+}
+
+type getPollServiceImpl struct {
+	repository dao.PollsRepository
+}
+
+func (s *getPollServiceImpl) Get(ctx context.Context, id uuid.UUID) (*models.Poll, error) {
+	poll, err := s.repository.Get(ctx, id)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetPoll, err)
+	}
+
+	return adapters.PollToModel(poll), nil
+}
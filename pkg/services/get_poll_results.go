@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type GetPollResultsService interface {
+	// Get returns the results of a poll. Polls can hide their results until they close.
+	Get(ctx context.Context, pollID uuid.UUID, now time.Time) (*models.PollResults, error)
+}
+
+func NewGetPollResultsService(repository dao.PollsRepository) GetPollResultsService {
+	return &getPollResultsServiceImpl{
+		repository: repository,
+	}
+}
+
+type getPollResultsServiceImpl struct {
+	repository dao.PollsRepository
+}
+
+func (s *getPollResultsServiceImpl) Get(ctx context.Context, pollID uuid.UUID, now time.Time) (*models.PollResults, error) {
+	pollModel, err := s.repository.Get(ctx, pollID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetPoll, err)
+	}
+
+	poll := adapters.PollToModel(pollModel)
+	// Ranked polls are tallied with instant runoff instead.
+	if poll.Ranked {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrPollRanked)
+	}
+	if poll.HideResults && !poll.IsClosed(now) {
+		return nil, ErrPollResultsHidden
+	}
+
+	ballots, err := s.repository.CountBallots(ctx, pollID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetPollResults, err)
+	}
+
+	counts, err := s.repository.CountChoices(ctx, pollID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetPollResults, err)
+	}
+
+	votes := make([]int, len(poll.Options))
+	for _, count := range counts {
+		// Ballots are checked when they are cast, so this only guards against corrupted data.
+		if count.Choice >= 0 && count.Choice < len(votes) {
+			votes[count.Choice] = count.Count
+		}
+	}
+
+	return &models.PollResults{
+		PollID:  pollID,
+		Ballots: ballots,
+		Votes:   votes,
+		Final:   poll.IsClosed(now),
+	}, nil
+}
@@ -0,0 +1,177 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestGetPollResultsService(t *testing.T) {
+	openPoll := &dao.PollModel{
+		Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		CreatedBy: goframework.NumberUUID(100),
+		PollModelCore: dao.PollModelCore{
+			Question: "Which feature next?",
+			Options:  []string{"Dark mode", "Bookmarks", "Offline reading"},
+			OpensAt:  baseTime,
+		},
+	}
+
+	hiddenPoll := &dao.PollModel{
+		Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		CreatedBy: goframework.NumberUUID(100),
+		PollModelCore: dao.PollModelCore{
+			Question:    "Which feature next?",
+			Options:     []string{"Dark mode", "Bookmarks", "Offline reading"},
+			OpensAt:     baseTime,
+			ClosesAt:    lo.ToPtr(updateTime),
+			HideResults: true,
+		},
+	}
+
+	data := []struct {
+		name string
+
+		now time.Time
+
+		getResp *dao.PollModel
+		getErr  error
+
+		shouldCallCountBallots bool
+		countBallotsResp       int
+		countBallotsErr        error
+
+		shouldCallCountChoices bool
+		countChoicesResp       []*dao.PollChoiceCountModel
+		countChoicesErr        error
+
+		expect    *models.PollResults
+		expectErr error
+	}{
+		{
+			name:                   "Success",
+			now:                    updateTime,
+			getResp:                openPoll,
+			shouldCallCountBallots: true,
+			countBallotsResp:       5,
+			shouldCallCountChoices: true,
+			countChoicesResp: []*dao.PollChoiceCountModel{
+				{Choice: 0, Count: 3},
+				{Choice: 2, Count: 2},
+			},
+			expect: &models.PollResults{
+				PollID:  goframework.NumberUUID(1),
+				Ballots: 5,
+				Votes:   []int{3, 0, 2},
+			},
+		},
+		{
+			name:                   "Success/NoBallots",
+			now:                    updateTime,
+			getResp:                openPoll,
+			shouldCallCountBallots: true,
+			shouldCallCountChoices: true,
+			countChoicesResp:       []*dao.PollChoiceCountModel{},
+			expect: &models.PollResults{
+				PollID: goframework.NumberUUID(1),
+				Votes:  []int{0, 0, 0},
+			},
+		},
+		{
+			name:                   "Success/HiddenResultsAfterClose",
+			now:                    updateTime,
+			getResp:                hiddenPoll,
+			shouldCallCountBallots: true,
+			countBallotsResp:       2,
+			shouldCallCountChoices: true,
+			countChoicesResp: []*dao.PollChoiceCountModel{
+				{Choice: 1, Count: 2},
+			},
+			expect: &models.PollResults{
+				PollID:  goframework.NumberUUID(1),
+				Ballots: 2,
+				Votes:   []int{0, 2, 0},
+				Final:   true,
+			},
+		},
+		{
+			name:      "Error/HiddenResults",
+			now:       baseTime.Add(30 * time.Minute),
+			getResp:   hiddenPoll,
+			expectErr: services.ErrPollResultsHidden,
+		},
+		{
+			name: "Error/RankedPoll",
+			now:  updateTime,
+			getResp: &dao.PollModel{
+				Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CreatedBy: goframework.NumberUUID(100),
+				PollModelCore: dao.PollModelCore{
+					Question: "Which story should be featured?",
+					Options:  []string{"Story A", "Story B"},
+					OpensAt:  baseTime,
+					Ranked:   true,
+				},
+			},
+			expectErr: services.ErrPollRanked,
+		},
+		{
+			name:                   "Error/CountChoicesFailure",
+			now:                    updateTime,
+			getResp:                openPoll,
+			shouldCallCountBallots: true,
+			countBallotsResp:       5,
+			shouldCallCountChoices: true,
+			countChoicesErr:        fooErr,
+			expectErr:              services.ErrGetPollResults,
+		},
+		{
+			name:                   "Error/CountBallotsFailure",
+			now:                    updateTime,
+			getResp:                openPoll,
+			shouldCallCountBallots: true,
+			countBallotsErr:        fooErr,
+			expectErr:              services.ErrGetPollResults,
+		},
+		{
+			name:      "Error/NotFound",
+			now:       updateTime,
+			getErr:    bunovel.ErrNotFound,
+			expectErr: bunovel.ErrNotFound,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewPollsRepository(t)
+
+			repository.On("Get", context.Background(), goframework.NumberUUID(1)).Return(d.getResp, d.getErr)
+
+			if d.shouldCallCountBallots {
+				repository.On("CountBallots", context.Background(), goframework.NumberUUID(1)).
+					Return(d.countBallotsResp, d.countBallotsErr)
+			}
+
+			if d.shouldCallCountChoices {
+				repository.On("CountChoices", context.Background(), goframework.NumberUUID(1)).
+					Return(d.countChoicesResp, d.countChoicesErr)
+			}
+
+			service := services.NewGetPollResultsService(repository)
+			res, err := service.Get(context.Background(), goframework.NumberUUID(1), d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetPollService(t *testing.T) {
+	data := []struct {
+		name string
+
+		id uuid.UUID
+
+		daoResp *dao.PollModel
+		daoErr  error
+
+		expect    *models.Poll
+		expectErr error
+	}{
+		{
+			name: "Success",
+			id:   goframework.NumberUUID(1),
+			daoResp: &dao.PollModel{
+				Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CreatedBy: goframework.NumberUUID(100),
+				PollModelCore: dao.PollModelCore{
+					Question: "Which feature next?",
+					Options:  []string{"Dark mode", "Bookmarks"},
+					OpensAt:  baseTime,
+				},
+			},
+			expect: &models.Poll{
+				ID:        goframework.NumberUUID(1),
+				CreatedAt: baseTime,
+				CreatedBy: goframework.NumberUUID(100),
+				Question:  "Which feature next?",
+				Options:   []string{"Dark mode", "Bookmarks"},
+				OpensAt:   baseTime,
+			},
+		},
+		{
+			name:      "Error/NotFound",
+			id:        goframework.NumberUUID(1),
+			daoErr:    bunovel.ErrNotFound,
+			expectErr: bunovel.ErrNotFound,
+		},
+		{
+			name:      "Error/DAOFailure",
+			id:        goframework.NumberUUID(1),
+			daoErr:    fooErr,
+			expectErr: services.ErrGetPoll,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewPollsRepository(t)
+
+			repository.On("Get", context.Background(), d.id).Return(d.daoResp, d.daoErr)
+
+			service := services.NewGetPollService(repository)
+			res, err := service.Get(context.Background(), d.id)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"time"
+)
+
+type GetRunoffResultsService interface {
+	// Get tallies the ranked ballots of a poll, and returns the counts of each round of the runoff. Polls can hide
+	// their results until they close.
+	Get(ctx context.Context, pollID uuid.UUID, now time.Time) (*models.RunoffResults, error)
+}
+
+func NewGetRunoffResultsService(repository dao.PollsRepository) GetRunoffResultsService {
+	return &getRunoffResultsServiceImpl{
+		repository: repository,
+	}
+}
+
+type getRunoffResultsServiceImpl struct {
+	repository dao.PollsRepository
+}
+
+func (s *getRunoffResultsServiceImpl) Get(ctx context.Context, pollID uuid.UUID, now time.Time) (*models.RunoffResults, error) {
+	pollModel, err := s.repository.Get(ctx, pollID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetPoll, err)
+	}
+
+	poll := adapters.PollToModel(pollModel)
+	if !poll.Ranked {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrPollNotRanked)
+	}
+	if poll.HideResults && !poll.IsClosed(now) {
+		return nil, ErrPollResultsHidden
+	}
+
+	rankings, err := s.repository.ListRankings(ctx, pollID)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetRunoffResults, err)
+	}
+
+	rounds, winner := TallyInstantRunoff(len(poll.Options), lo.Map(rankings, func(item *dao.PollRankingModel, _ int) []int {
+		return item.Ranking
+	}))
+
+	return &models.RunoffResults{
+		PollID:  pollID,
+		Ballots: len(rankings),
+		Rounds:  rounds,
+		Winner:  winner,
+		Final:   poll.IsClosed(now),
+	}, nil
+}
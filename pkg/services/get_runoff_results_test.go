@@ -0,0 +1,162 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestGetRunoffResultsService(t *testing.T) {
+	rankedPoll := &dao.PollModel{
+		Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		CreatedBy: goframework.NumberUUID(100),
+		PollModelCore: dao.PollModelCore{
+			Question: "Which story should be featured?",
+			Options:  []string{"Story A", "Story B", "Story C"},
+			OpensAt:  baseTime,
+			Ranked:   true,
+		},
+	}
+
+	hiddenPoll := &dao.PollModel{
+		Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+		CreatedBy: goframework.NumberUUID(100),
+		PollModelCore: dao.PollModelCore{
+			Question:    "Which story should be featured?",
+			Options:     []string{"Story A", "Story B", "Story C"},
+			OpensAt:     baseTime,
+			ClosesAt:    lo.ToPtr(updateTime),
+			HideResults: true,
+			Ranked:      true,
+		},
+	}
+
+	rankings := []*dao.PollRankingModel{
+		{PollID: goframework.NumberUUID(1), UserID: goframework.NumberUUID(10), CreatedAt: baseTime, Ranking: []int{0}},
+		{PollID: goframework.NumberUUID(1), UserID: goframework.NumberUUID(11), CreatedAt: baseTime, Ranking: []int{1}},
+		{PollID: goframework.NumberUUID(1), UserID: goframework.NumberUUID(12), CreatedAt: baseTime, Ranking: []int{2, 1}},
+	}
+
+	data := []struct {
+		name string
+
+		now time.Time
+
+		getResp *dao.PollModel
+		getErr  error
+
+		shouldCallList bool
+		listResp       []*dao.PollRankingModel
+		listErr        error
+
+		expect    *models.RunoffResults
+		expectErr error
+	}{
+		{
+			name:           "Success",
+			now:            updateTime,
+			getResp:        rankedPoll,
+			shouldCallList: true,
+			listResp:       rankings,
+			expect: &models.RunoffResults{
+				PollID:  goframework.NumberUUID(1),
+				Ballots: 3,
+				Rounds: []*models.RunoffRound{
+					{Votes: []int{1, 1, 1}, Eliminated: lo.ToPtr(2)},
+					{Votes: []int{1, 2, 0}},
+				},
+				Winner: lo.ToPtr(1),
+			},
+		},
+		{
+			name:           "Success/NoBallots",
+			now:            updateTime,
+			getResp:        rankedPoll,
+			shouldCallList: true,
+			listResp:       []*dao.PollRankingModel{},
+			expect: &models.RunoffResults{
+				PollID: goframework.NumberUUID(1),
+				Rounds: []*models.RunoffRound{},
+			},
+		},
+		{
+			name:           "Success/HiddenResultsAfterClose",
+			now:            updateTime,
+			getResp:        hiddenPoll,
+			shouldCallList: true,
+			listResp:       rankings,
+			expect: &models.RunoffResults{
+				PollID:  goframework.NumberUUID(1),
+				Ballots: 3,
+				Rounds: []*models.RunoffRound{
+					{Votes: []int{1, 1, 1}, Eliminated: lo.ToPtr(2)},
+					{Votes: []int{1, 2, 0}},
+				},
+				Winner: lo.ToPtr(1),
+				Final:  true,
+			},
+		},
+		{
+			name:      "Error/HiddenResults",
+			now:       baseTime.Add(30 * time.Minute),
+			getResp:   hiddenPoll,
+			expectErr: services.ErrPollResultsHidden,
+		},
+		{
+			name: "Error/NotRanked",
+			now:  updateTime,
+			getResp: &dao.PollModel{
+				Metadata:  bunovel.NewMetadata(goframework.NumberUUID(1), baseTime, nil),
+				CreatedBy: goframework.NumberUUID(100),
+				PollModelCore: dao.PollModelCore{
+					Question: "Which feature next?",
+					Options:  []string{"Dark mode", "Bookmarks"},
+					OpensAt:  baseTime,
+				},
+			},
+			expectErr: services.ErrPollNotRanked,
+		},
+		{
+			name:           "Error/ListFailure",
+			now:            updateTime,
+			getResp:        rankedPoll,
+			shouldCallList: true,
+			listErr:        fooErr,
+			expectErr:      services.ErrGetRunoffResults,
+		},
+		{
+			name:      "Error/NotFound",
+			now:       updateTime,
+			getErr:    bunovel.ErrNotFound,
+			expectErr: bunovel.ErrNotFound,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewPollsRepository(t)
+
+			repository.On("Get", context.Background(), goframework.NumberUUID(1)).Return(d.getResp, d.getErr)
+
+			if d.shouldCallList {
+				repository.On("ListRankings", context.Background(), goframework.NumberUUID(1)).Return(d.listResp, d.listErr)
+			}
+
+			service := services.NewGetRunoffResultsService(repository)
+			res, err := service.Get(context.Background(), goframework.NumberUUID(1), d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+		})
+	}
+}
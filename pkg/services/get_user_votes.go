@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	apiclients "github.com/a-novel/go-apis/clients"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type GetUserVotesService interface {
+	Get(ctx context.Context, tokenRaw string, target string, targetIDs []uuid.UUID) (map[uuid.UUID]*models.Vote, error)
+}
+
+func NewGetUserVotesService(repository dao.VotesRepository, authClient apiclients.AuthClient) GetUserVotesService {
+	return &getUserVotesServiceImpl{
+		repository: repository,
+		authClient: authClient,
+	}
+}
+
+type getUserVotesServiceImpl struct {
+	repository dao.VotesRepository
+	authClient apiclients.AuthClient
+}
+
+func (s *getUserVotesServiceImpl) Get(ctx context.Context, tokenRaw string, target string, targetIDs []uuid.UUID) (map[uuid.UUID]*models.Vote, error) {
+	token, err := s.authClient.IntrospectToken(ctx, tokenRaw)
+	if err != nil {
+		return nil, goerrors.Join(ErrIntrospectToken, err)
+	}
+	if !token.OK {
+		return nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
+	}
+
+	targetIDs = lo.Uniq(targetIDs)
+
+	if err := goframework.CheckMinMax(len(targetIDs), 0, MaxBatchTargets); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrTooManyTargets, err)
+	}
+
+	votes, err := s.repository.GetUserVotes(ctx, token.Token.Payload.ID, target, targetIDs)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetUserVotes, err)
+	}
+
+	// Targets the user did not vote for are kept in the output, with a nil value.
+	output := make(map[uuid.UUID]*models.Vote, len(targetIDs))
+	for _, targetID := range targetIDs {
+		output[targetID] = nil
+	}
+	for _, vote := range votes {
+		output[vote.TargetID] = adapters.VoteToModel(vote)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,143 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetUserVotesService(t *testing.T) {
+	data := []struct {
+		name string
+
+		tokenRaw  string
+		target    string
+		targetIDs []uuid.UUID
+
+		authClientResp *apiclients.UserTokenStatus
+		authClientErr  error
+
+		shouldCallDAO     bool
+		shouldCallDAOWith []uuid.UUID
+		daoResp           []*dao.VoteModel
+		daoErr            error
+
+		expect    map[uuid.UUID]*models.Vote
+		expectErr error
+	}{
+		{
+			name:      "Success",
+			tokenRaw:  "token",
+			target:    "target",
+			targetIDs: []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2), goframework.NumberUUID(1)},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallDAO:     true,
+			shouldCallDAOWith: []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2)},
+			daoResp: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, &updateTime),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(100),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+			},
+			expect: map[uuid.UUID]*models.Vote{
+				goframework.NumberUUID(1): {
+					ID:        goframework.NumberUUID(10),
+					UpdatedAt: updateTime,
+					Vote:      models.VoteValueUp,
+					UserID:    goframework.NumberUUID(100),
+					TargetID:  goframework.NumberUUID(1),
+					Target:    "target",
+				},
+				goframework.NumberUUID(2): nil,
+			},
+		},
+		{
+			name:      "Error/TooManyTargets",
+			tokenRaw:  "token",
+			target:    "target",
+			targetIDs: lo.Times(services.MaxBatchTargets+1, func(index int) uuid.UUID { return uuid.New() }),
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name:      "Error/DAOFailure",
+			tokenRaw:  "token",
+			target:    "target",
+			targetIDs: []uuid.UUID{goframework.NumberUUID(1)},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallDAO:     true,
+			shouldCallDAOWith: []uuid.UUID{goframework.NumberUUID(1)},
+			daoErr:            fooErr,
+			expectErr:         fooErr,
+		},
+		{
+			name:           "Error/NotAuthenticated",
+			tokenRaw:       "token",
+			target:         "target",
+			targetIDs:      []uuid.UUID{goframework.NumberUUID(1)},
+			authClientResp: &apiclients.UserTokenStatus{},
+			expectErr:      goframework.ErrInvalidCredentials,
+		},
+		{
+			name:          "Error/AuthClientFailure",
+			tokenRaw:      "token",
+			target:        "target",
+			targetIDs:     []uuid.UUID{goframework.NumberUUID(1)},
+			authClientErr: fooErr,
+			expectErr:     fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewVotesRepository(t)
+			authClient := apiclientsmocks.NewAuthClient(t)
+
+			authClient.On("IntrospectToken", context.Background(), d.tokenRaw).Return(d.authClientResp, d.authClientErr)
+
+			if d.shouldCallDAO {
+				repository.
+					On("GetUserVotes", context.Background(), d.authClientResp.Token.Payload.ID, d.target, d.shouldCallDAOWith).
+					Return(d.daoResp, d.daoErr)
+			}
+
+			service := services.NewGetUserVotesService(repository, authClient)
+
+			resp, err := service.Get(context.Background(), d.tokenRaw, d.target, d.targetIDs)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, resp)
+
+			repository.AssertExpectations(t)
+			authClient.AssertExpectations(t)
+		})
+	}
+}
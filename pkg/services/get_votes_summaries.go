@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type GetVotesSummariesService interface {
+	Get(ctx context.Context, target string, targetIDs []uuid.UUID) (map[uuid.UUID]*models.VotesSummary, error)
+}
+
+func NewGetVotesSummariesService(repository dao.VotesRepository) GetVotesSummariesService {
+	return &getVotesSummariesServiceImpl{
+		repository: repository,
+	}
+}
+
+type getVotesSummariesServiceImpl struct {
+	repository dao.VotesRepository
+}
+
+func (s *getVotesSummariesServiceImpl) Get(ctx context.Context, target string, targetIDs []uuid.UUID) (map[uuid.UUID]*models.VotesSummary, error) {
+	targetIDs = lo.Uniq(targetIDs)
+
+	if err := goframework.CheckMinMax(len(targetIDs), 0, MaxBatchTargets); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrTooManyTargets, err)
+	}
+
+	summaries, err := s.repository.GetSummaries(ctx, target, targetIDs)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetVotesSummaries, err)
+	}
+
+	// Targets without any vote have no summary yet: they are returned with zero counts, so every requested target
+	// is present in the output.
+	output := make(map[uuid.UUID]*models.VotesSummary, len(targetIDs))
+	for _, targetID := range targetIDs {
+		output[targetID] = withScores(&models.VotesSummary{})
+	}
+	for _, summary := range summaries {
+		output[summary.TargetID] = withScores(adapters.VotesSummaryToModel(summary))
+	}
+
+	return output, nil
+}
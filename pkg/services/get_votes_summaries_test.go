@@ -0,0 +1,95 @@
+package services_test
+
+import (
+	"context"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetVotesSummariesService(t *testing.T) {
+	data := []struct {
+		name string
+
+		target    string
+		targetIDs []uuid.UUID
+
+		shouldCallDAO     bool
+		shouldCallDAOWith []uuid.UUID
+		daoResp           []*dao.VotesSummaryModel
+		daoErr            error
+
+		expect    map[uuid.UUID]*models.VotesSummary
+		expectErr error
+	}{
+		{
+			name:              "Success",
+			target:            "target",
+			targetIDs:         []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2), goframework.NumberUUID(1)},
+			shouldCallDAO:     true,
+			shouldCallDAOWith: []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2)},
+			daoResp: []*dao.VotesSummaryModel{
+				{
+					TargetID:  goframework.NumberUUID(1),
+					Target:    "target",
+					UpVotes:   100,
+					DownVotes: 50,
+				},
+			},
+			expect: map[uuid.UUID]*models.VotesSummary{
+				goframework.NumberUUID(1): {UpVotes: 100, DownVotes: 50, Scores: services.ComputeVotesScores(100, 50)},
+				goframework.NumberUUID(2): {Scores: &models.VotesScores{}},
+			},
+		},
+		{
+			name:              "Success/NoTargets",
+			target:            "target",
+			targetIDs:         []uuid.UUID{},
+			shouldCallDAO:     true,
+			shouldCallDAOWith: []uuid.UUID{},
+			daoResp:           []*dao.VotesSummaryModel{},
+			expect:            map[uuid.UUID]*models.VotesSummary{},
+		},
+		{
+			name:   "Error/TooManyTargets",
+			target: "target",
+			targetIDs: lo.Times(services.MaxBatchTargets+1, func(index int) uuid.UUID {
+				return uuid.New()
+			}),
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name:              "Error/DAOFailure",
+			target:            "target",
+			targetIDs:         []uuid.UUID{goframework.NumberUUID(1)},
+			shouldCallDAO:     true,
+			shouldCallDAOWith: []uuid.UUID{goframework.NumberUUID(1)},
+			daoErr:            fooErr,
+			expectErr:         fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewVotesRepository(t)
+
+			if d.shouldCallDAO {
+				repository.On("GetSummaries", context.Background(), d.target, d.shouldCallDAOWith).Return(d.daoResp, d.daoErr)
+			}
+
+			service := services.NewGetVotesSummariesService(repository)
+			resp, err := service.Get(context.Background(), d.target, d.targetIDs)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, resp)
+
+			repository.AssertExpectations(t)
+		})
+	}
+}
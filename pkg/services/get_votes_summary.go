@@ -29,5 +29,5 @@ func (s *getVotesSummaryServiceImpl) Get(ctx context.Context, targetID uuid.UUID
 		return nil, goerrors.Join(ErrGetVotesSummary, err)
 	}
 
-	return adapters.VotesSummaryToModel(summary), nil
+	return withScores(adapters.VotesSummaryToModel(summary)), nil
 }
@@ -36,6 +36,19 @@ func TestGetVotesSummaryService(t *testing.T) {
 			expect: &models.VotesSummary{
 				UpVotes:   100,
 				DownVotes: 50,
+				Scores:    services.ComputeVotesScores(100, 50),
+			},
+		},
+		{
+			name:     "Success/NoVotes",
+			targetID: goframework.NumberUUID(1),
+			target:   "target",
+			daoResp: &dao.VotesSummaryModel{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			expect: &models.VotesSummary{
+				Scores: &models.VotesScores{},
 			},
 		},
 		{
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/samber/lo"
+)
+
+// TallyInstantRunoff elects one of the options from the given rankings. Each round counts every ballot for its
+// preferred option still running. An option wins once it holds a strict majority of the ballots that are not
+// exhausted; otherwise, the option with the fewest votes is eliminated, and a new round starts.
+//
+// Ties for the elimination are broken by looking back at the previous rounds: the tied option with the fewest votes
+// in the latest round where they differ is eliminated. Options still tied after the first round are broken by
+// position, the last listed option being eliminated. The tally is thus deterministic, and does not depend on the
+// order of the rankings.
+//
+// Positions out of the range of the options are ignored. The winner is nil when no ballot ranks any option.
+func TallyInstantRunoff(options int, rankings [][]int) ([]*models.RunoffRound, *int) {
+	rounds := make([]*models.RunoffRound, 0)
+	eliminated := make([]bool, options)
+
+	for {
+		round := &models.RunoffRound{Votes: make([]int, options)}
+		for _, ranking := range rankings {
+			preferred, ok := lo.Find(ranking, func(option int) bool {
+				return option >= 0 && option < options && !eliminated[option]
+			})
+			if ok {
+				round.Votes[preferred]++
+			} else {
+				round.Exhausted++
+			}
+		}
+
+		continuing := len(rankings) - round.Exhausted
+		if continuing == 0 {
+			return rounds, nil
+		}
+
+		rounds = append(rounds, round)
+
+		// The last option running always holds every continuing ballot, so the tally ends.
+		for option, votes := range round.Votes {
+			if votes*2 > continuing {
+				return rounds, lo.ToPtr(option)
+			}
+		}
+
+		loser := runoffLoser(rounds, eliminated)
+		round.Eliminated = lo.ToPtr(loser)
+		eliminated[loser] = true
+	}
+}
+
+// runoffLoser returns the option to eliminate at the end of the last round.
+func runoffLoser(rounds []*models.RunoffRound, eliminated []bool) int {
+	candidates := make([]int, 0, len(eliminated))
+	for option, out := range eliminated {
+		if !out {
+			candidates = append(candidates, option)
+		}
+	}
+
+	for i := len(rounds) - 1; i >= 0 && len(candidates) > 1; i-- {
+		votes := rounds[i].Votes
+		fewest := lo.Min(lo.Map(candidates, func(option int, _ int) int { return votes[option] }))
+		candidates = lo.Filter(candidates, func(option int, _ int) bool { return votes[option] == fewest })
+	}
+
+	return candidates[len(candidates)-1]
+}
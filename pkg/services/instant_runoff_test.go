@@ -0,0 +1,118 @@
+package services_test
+
+import (
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTallyInstantRunoff(t *testing.T) {
+	// repeat returns count copies of the ranking.
+	repeat := func(count int, ranking ...int) [][]int {
+		return lo.Times(count, func(_ int) []int { return ranking })
+	}
+
+	data := []struct {
+		name string
+
+		options  int
+		rankings [][]int
+
+		expectRounds []*models.RunoffRound
+		expectWinner *int
+	}{
+		{
+			name:         "Success/NoBallots",
+			options:      3,
+			expectRounds: []*models.RunoffRound{},
+		},
+		{
+			name:    "Success/FirstRoundMajority",
+			options: 3,
+			rankings: [][]int{
+				{0},
+				{0, 1},
+				{1},
+				{2, 0},
+				{0},
+			},
+			expectRounds: []*models.RunoffRound{
+				{Votes: []int{3, 1, 1}},
+			},
+			expectWinner: lo.ToPtr(0),
+		},
+		{
+			name:     "Success/Transfer",
+			options:  3,
+			rankings: lo.Flatten([][][]int{repeat(4, 0), repeat(3, 1), repeat(2, 2, 1)}),
+			expectRounds: []*models.RunoffRound{
+				{Votes: []int{4, 3, 2}, Eliminated: lo.ToPtr(2)},
+				{Votes: []int{4, 5, 0}},
+			},
+			expectWinner: lo.ToPtr(1),
+		},
+		{
+			name:     "Success/ExhaustedBallots",
+			options:  3,
+			rankings: lo.Flatten([][][]int{repeat(3, 0), repeat(2, 1), repeat(2, 2)}),
+			expectRounds: []*models.RunoffRound{
+				{Votes: []int{3, 2, 2}, Eliminated: lo.ToPtr(2)},
+				{Votes: []int{3, 2, 0}, Exhausted: 2},
+			},
+			expectWinner: lo.ToPtr(0),
+		},
+		{
+			name:     "Success/TieBrokenByPreviousRounds",
+			options:  4,
+			rankings: lo.Flatten([][][]int{repeat(6, 0), repeat(3, 1), repeat(4, 2), repeat(1, 3, 1)}),
+			expectRounds: []*models.RunoffRound{
+				{Votes: []int{6, 3, 4, 1}, Eliminated: lo.ToPtr(3)},
+				// Options 1 and 2 are tied, but option 1 had fewer votes in the first round.
+				{Votes: []int{6, 4, 4, 0}, Eliminated: lo.ToPtr(1)},
+				{Votes: []int{6, 0, 4, 0}, Exhausted: 4},
+			},
+			expectWinner: lo.ToPtr(0),
+		},
+		{
+			name:     "Success/TieBrokenByPosition",
+			options:  3,
+			rankings: [][]int{{0}, {1}, {2}},
+			expectRounds: []*models.RunoffRound{
+				{Votes: []int{1, 1, 1}, Eliminated: lo.ToPtr(2)},
+				{Votes: []int{1, 1, 0}, Exhausted: 1, Eliminated: lo.ToPtr(1)},
+				{Votes: []int{1, 0, 0}, Exhausted: 2},
+			},
+			expectWinner: lo.ToPtr(0),
+		},
+		{
+			name:     "Success/IgnoresInvalidPositions",
+			options:  2,
+			rankings: [][]int{{5, 1}, {0}, {-1, 1}},
+			expectRounds: []*models.RunoffRound{
+				{Votes: []int{1, 2}},
+			},
+			expectWinner: lo.ToPtr(1),
+		},
+		{
+			name:         "Success/EmptyRankings",
+			options:      2,
+			rankings:     [][]int{{}, {}},
+			expectRounds: []*models.RunoffRound{},
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			rounds, winner := services.TallyInstantRunoff(d.options, d.rankings)
+			require.Equal(t, d.expectRounds, rounds)
+			require.Equal(t, d.expectWinner, winner)
+
+			// The tally does not depend on the order of the ballots.
+			rounds, winner = services.TallyInstantRunoff(d.options, lo.Reverse(append([][]int{}, d.rankings...)))
+			require.Equal(t, d.expectRounds, rounds)
+			require.Equal(t, d.expectWinner, winner)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	apiclients "github.com/a-novel/go-apis/clients"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"time"
+)
+
+type InvalidateVotesService interface {
+	// Invalidate excludes the selected votes from the summaries, and returns the number of votes invalidated. The
+	// owners of the affected targets receive the corrected counters.
+	Invalidate(ctx context.Context, tokenRaw string, form models.ModerateVotesForm, now time.Time) (int, error)
+}
+
+func NewInvalidateVotesService(
+	repository dao.VotesRepository,
+	authClient apiclients.AuthClient,
+	permissionsClient apiclients.PermissionsClient,
+	targets map[string]*models.Target,
+	moderationScope apiclients.Scope,
+) InvalidateVotesService {
+	return &invalidateVotesServiceImpl{
+		repository:        repository,
+		authClient:        authClient,
+		permissionsClient: permissionsClient,
+		targets:           targets,
+		moderationScope:   moderationScope,
+	}
+}
+
+type invalidateVotesServiceImpl struct {
+	repository        dao.VotesRepository
+	authClient        apiclients.AuthClient
+	permissionsClient apiclients.PermissionsClient
+
+	targets         map[string]*models.Target
+	moderationScope apiclients.Scope
+}
+
+func (s *invalidateVotesServiceImpl) Invalidate(ctx context.Context, tokenRaw string, form models.ModerateVotesForm, now time.Time) (int, error) {
+	moderatorID, err := checkUserScope(ctx, s.authClient, s.permissionsClient, tokenRaw, s.moderationScope)
+	if err != nil {
+		return 0, err
+	}
+
+	filter, err := moderatedVotesFilter(s.targets, form)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := goframework.CheckMinMax(len(form.Reason), 1, MaxInvalidationReasonLength); err != nil {
+		return 0, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidReason, err)
+	}
+
+	var invalidated int
+
+	err = s.repository.RunInTx(ctx, func(ctx context.Context, txRepository dao.VotesRepository) error {
+		votes, err := txRepository.Invalidate(ctx, filter, moderatorID, form.Reason, now)
+		if err != nil {
+			return goerrors.Join(ErrInvalidateVotes, err)
+		}
+
+		invalidated = len(votes)
+
+		return republishSummaries(ctx, txRepository, votes, moderatorID, now)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return invalidated, nil
+}
@@ -0,0 +1,370 @@
+package services_test
+
+import (
+	"context"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+// getSummariesCall describes an expected call to VotesRepository.GetSummaries.
+type getSummariesCall struct {
+	target    string
+	targetIDs []uuid.UUID
+	resp      []*dao.VotesSummaryModel
+	err       error
+}
+
+func TestInvalidateVotesService(t *testing.T) {
+	moderationScope := apiclients.Scope("can_moderate_votes")
+
+	targets := map[string]*models.Target{
+		"target":       {Name: "target", Open: true},
+		"other-target": {Name: "other-target", Open: true},
+	}
+
+	moderatorToken := &apiclients.UserTokenStatus{
+		OK: true,
+		Token: &apiclients.UserToken{
+			Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		form     models.ModerateVotesForm
+
+		authClientResp *apiclients.UserTokenStatus
+		authClientErr  error
+
+		shouldCallPermissions bool
+		permissionsErr        error
+
+		shouldCallDAO     bool
+		shouldCallDAOWith dao.ModeratedVotesFilter
+		daoResp           []*dao.VoteModel
+		daoErr            error
+
+		getSummaries []getSummariesCall
+		queue        []*dao.VotesSummaryModel
+		queueErr     error
+
+		expect    int
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+				Reason: "vote ring",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: dao.ModeratedVotesFilter{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			daoResp: []*dao.VoteModel{
+				{Vote: models.VoteValueUp, UserID: goframework.NumberUUID(10), TargetID: goframework.NumberUUID(1), Target: "target"},
+				{Vote: models.VoteValueDown, UserID: goframework.NumberUUID(10), TargetID: goframework.NumberUUID(2), Target: "target"},
+				{Vote: models.VoteValueUp, UserID: goframework.NumberUUID(10), TargetID: goframework.NumberUUID(3), Target: "other-target"},
+			},
+			getSummaries: []getSummariesCall{
+				{
+					target:    "target",
+					targetIDs: []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2)},
+					resp: []*dao.VotesSummaryModel{
+						{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 4, DownVotes: 1},
+						{TargetID: goframework.NumberUUID(2), Target: "target", UpVotes: 0, DownVotes: 2},
+					},
+				},
+				{
+					target:    "other-target",
+					targetIDs: []uuid.UUID{goframework.NumberUUID(3)},
+					resp: []*dao.VotesSummaryModel{
+						{TargetID: goframework.NumberUUID(3), Target: "other-target", UpVotes: 7},
+					},
+				},
+			},
+			queue: []*dao.VotesSummaryModel{
+				{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 4, DownVotes: 1},
+				{TargetID: goframework.NumberUUID(2), Target: "target", UpVotes: 0, DownVotes: 2},
+				{TargetID: goframework.NumberUUID(3), Target: "other-target", UpVotes: 7},
+			},
+			expect: 3,
+		},
+		{
+			name:     "Success/Target",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				TargetID: lo.ToPtr(goframework.NumberUUID(1)),
+				Target:   "target",
+				Reason:   "brigading",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: dao.ModeratedVotesFilter{
+				TargetID: lo.ToPtr(goframework.NumberUUID(1)),
+				Target:   "target",
+			},
+			daoResp: []*dao.VoteModel{
+				{Vote: models.VoteValueUp, UserID: goframework.NumberUUID(10), TargetID: goframework.NumberUUID(1), Target: "target"},
+				{Vote: models.VoteValueUp, UserID: goframework.NumberUUID(11), TargetID: goframework.NumberUUID(1), Target: "target"},
+			},
+			getSummaries: []getSummariesCall{
+				{
+					target:    "target",
+					targetIDs: []uuid.UUID{goframework.NumberUUID(1)},
+					resp: []*dao.VotesSummaryModel{
+						{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 3},
+					},
+				},
+			},
+			queue: []*dao.VotesSummaryModel{
+				{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 3},
+			},
+			expect: 2,
+		},
+		{
+			name:     "Success/NothingToInvalidate",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+				Reason: "vote ring",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: dao.ModeratedVotesFilter{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			daoResp: []*dao.VoteModel{},
+		},
+		{
+			name:     "Error/QueueSummaryUpdateFailure",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+				Reason: "vote ring",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: dao.ModeratedVotesFilter{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			daoResp: []*dao.VoteModel{
+				{Vote: models.VoteValueUp, UserID: goframework.NumberUUID(10), TargetID: goframework.NumberUUID(1), Target: "target"},
+			},
+			getSummaries: []getSummariesCall{
+				{
+					target:    "target",
+					targetIDs: []uuid.UUID{goframework.NumberUUID(1)},
+					resp: []*dao.VotesSummaryModel{
+						{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 4},
+					},
+				},
+			},
+			queue: []*dao.VotesSummaryModel{
+				{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 4},
+			},
+			queueErr:  fooErr,
+			expectErr: services.ErrQueueSummaryUpdate,
+		},
+		{
+			name:     "Error/GetSummariesFailure",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+				Reason: "vote ring",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: dao.ModeratedVotesFilter{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			daoResp: []*dao.VoteModel{
+				{Vote: models.VoteValueUp, UserID: goframework.NumberUUID(10), TargetID: goframework.NumberUUID(1), Target: "target"},
+			},
+			getSummaries: []getSummariesCall{
+				{
+					target:    "target",
+					targetIDs: []uuid.UUID{goframework.NumberUUID(1)},
+					err:       fooErr,
+				},
+			},
+			expectErr: services.ErrGetVotesSummaries,
+		},
+		{
+			name:     "Error/DAOFailure",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+				Reason: "vote ring",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: dao.ModeratedVotesFilter{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			daoErr:    fooErr,
+			expectErr: services.ErrInvalidateVotes,
+		},
+		{
+			name:     "Error/NoReason",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidReason,
+		},
+		{
+			name:     "Error/ReasonTooLong",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+				Reason: strings.Repeat("a", services.MaxInvalidationReasonLength+1),
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             goframework.ErrInvalidEntity,
+		},
+		{
+			name:     "Error/NoFilter",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				Target: "target",
+				Reason: "vote ring",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrMissingFilter,
+		},
+		{
+			name:     "Error/TargetIDWithoutTarget",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				TargetID: lo.ToPtr(goframework.NumberUUID(1)),
+				Reason:   "vote ring",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidTarget,
+		},
+		{
+			name:     "Error/UnknownTarget",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				TargetID: lo.ToPtr(goframework.NumberUUID(1)),
+				Target:   "foo",
+				Reason:   "vote ring",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidTarget,
+		},
+		{
+			name:     "Error/MissingPermission",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+				Reason: "vote ring",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			permissionsErr:        fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:     "Error/NotAuthenticated",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+				Reason: "vote ring",
+			},
+			authClientResp: &apiclients.UserTokenStatus{},
+			expectErr:      goframework.ErrInvalidCredentials,
+		},
+		{
+			name:     "Error/AuthClientFailure",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+				Reason: "vote ring",
+			},
+			authClientErr: fooErr,
+			expectErr:     fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewVotesRepository(t)
+			authClient := apiclientsmocks.NewAuthClient(t)
+			permissionsClient := apiclientsmocks.NewPermissionsClient(t)
+
+			authClient.On("IntrospectToken", context.Background(), d.tokenRaw).Return(d.authClientResp, d.authClientErr)
+
+			if d.shouldCallPermissions {
+				permissionsClient.
+					On("HasUserScope", context.Background(), apiclients.HasUserScopeQuery{
+						UserID: d.authClientResp.Token.Payload.ID,
+						Scope:  moderationScope,
+					}).
+					Return(d.permissionsErr)
+			}
+
+			if d.shouldCallDAO {
+				txCall := repository.On("RunInTx", context.Background(), mock.Anything)
+				txCall.Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context, dao.VotesRepository) error)
+					txCall.ReturnArguments = []interface{}{fn(context.Background(), repository)}
+				})
+
+				repository.
+					On("Invalidate", context.Background(), d.shouldCallDAOWith, d.authClientResp.Token.Payload.ID, d.form.Reason, updateTime).
+					Return(d.daoResp, d.daoErr)
+			}
+
+			for _, call := range d.getSummaries {
+				repository.
+					On("GetSummaries", context.Background(), call.target, call.targetIDs).
+					Return(call.resp, call.err)
+			}
+
+			for _, summary := range d.queue {
+				repository.
+					On("QueueSummaryUpdate", context.Background(), d.authClientResp.Token.Payload.ID, summary, updateTime).
+					Return(d.queueErr)
+			}
+
+			service := services.NewInvalidateVotesService(repository, authClient, permissionsClient, targets, moderationScope)
+			res, err := service.Invalidate(context.Background(), d.tokenRaw, d.form, updateTime)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+			authClient.AssertExpectations(t)
+			permissionsClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/samber/lo"
+	"time"
+)
+
+type ListHotTargetsService interface {
+	List(ctx context.Context, query *models.ListHotTargetsQuery, now time.Time) ([]*models.RankedTarget, error)
+}
+
+// HotRankingConfig configures the time decay of the hot ranking.
+type HotRankingConfig struct {
+	// Gravity is the exponent applied to the age of each vote. The higher it is, the faster old votes fade.
+	Gravity float64
+	// Window is the maximum age of the votes taken into account.
+	Window time.Duration
+}
+
+func NewListHotTargetsService(
+	repository dao.VotesRepository, targets map[string]*models.Target, config HotRankingConfig,
+) ListHotTargetsService {
+	return &listHotTargetsServiceImpl{
+		repository: repository,
+		targets:    targets,
+		config:     config,
+	}
+}
+
+type listHotTargetsServiceImpl struct {
+	repository dao.VotesRepository
+	targets    map[string]*models.Target
+	config     HotRankingConfig
+}
+
+func (s *listHotTargetsServiceImpl) List(ctx context.Context, query *models.ListHotTargetsQuery, now time.Time) ([]*models.RankedTarget, error) {
+	if err := goframework.CheckMinMax(query.Limit, 1, MaxSearchLimit); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSearchLimit, err)
+	}
+
+	target := s.targets[query.Target]
+	if target == nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidTarget)
+	}
+	// Ratings are not up or down votes, rated targets are ranked by their average instead.
+	if target.Rating != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrRatedTarget)
+	}
+
+	targets, err := s.repository.ListHotTargets(
+		ctx, query.Target, s.config.Gravity, now.Add(-s.config.Window), now, query.Limit, query.Offset,
+	)
+	if err != nil {
+		return nil, goerrors.Join(ErrListHotTargets, err)
+	}
+
+	return lo.Map(targets, func(item *dao.TargetScoreModel, _ int) *models.RankedTarget {
+		return adapters.TargetScoreToModel(item)
+	}), nil
+}
@@ -0,0 +1,136 @@
+package services_test
+
+import (
+	"context"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestListHotTargetsService(t *testing.T) {
+	config := services.HotRankingConfig{
+		Gravity: 1.8,
+		Window:  24 * time.Hour,
+	}
+
+	targets := map[string]*models.Target{
+		"target": {
+			Name:   "target",
+			Values: []models.VoteValue{models.VoteValueUp, models.VoteValueDown},
+			Open:   true,
+		},
+		"story": {
+			Name:   "story",
+			Values: []models.VoteValue{models.VoteValueRating},
+			Rating: &models.RatingScale{Min: 1, Max: 5},
+			Open:   true,
+		},
+	}
+
+	data := []struct {
+		name string
+
+		query *models.ListHotTargetsQuery
+		now   time.Time
+
+		shouldCallDAO bool
+		daoResp       []*dao.TargetScoreModel
+		daoErr        error
+
+		expect    []*models.RankedTarget
+		expectErr error
+	}{
+		{
+			name: "Success",
+			query: &models.ListHotTargetsQuery{
+				Target: "target",
+				Limit:  10,
+				Offset: 5,
+			},
+			now:           updateTime,
+			shouldCallDAO: true,
+			daoResp: []*dao.TargetScoreModel{
+				{TargetID: goframework.NumberUUID(1), Score: 1.5},
+				{TargetID: goframework.NumberUUID(2), Score: 0.5},
+			},
+			expect: []*models.RankedTarget{
+				{TargetID: goframework.NumberUUID(1), Score: 1.5},
+				{TargetID: goframework.NumberUUID(2), Score: 0.5},
+			},
+		},
+		{
+			name: "Error/DAOFailure",
+			query: &models.ListHotTargetsQuery{
+				Target: "target",
+				Limit:  10,
+			},
+			now:           updateTime,
+			shouldCallDAO: true,
+			daoErr:        fooErr,
+			expectErr:     fooErr,
+		},
+		{
+			name: "Error/RatedTarget",
+			query: &models.ListHotTargetsQuery{
+				Target: "story",
+				Limit:  10,
+			},
+			now:       updateTime,
+			expectErr: services.ErrRatedTarget,
+		},
+		{
+			name: "Error/BadTarget",
+			query: &models.ListHotTargetsQuery{
+				Target: "fake-target",
+				Limit:  10,
+			},
+			now:       updateTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name: "Error/NoLimit",
+			query: &models.ListHotTargetsQuery{
+				Target: "target",
+			},
+			now:       updateTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name: "Error/LimitTooHigh",
+			query: &models.ListHotTargetsQuery{
+				Target: "target",
+				Limit:  services.MaxSearchLimit + 1,
+			},
+			now:       updateTime,
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewVotesRepository(t)
+
+			if d.shouldCallDAO {
+				repository.
+					On(
+						"ListHotTargets", context.Background(), d.query.Target, config.Gravity,
+						d.now.Add(-config.Window), d.now, d.query.Limit, d.query.Offset,
+					).
+					Return(d.daoResp, d.daoErr)
+			}
+
+			service := services.NewListHotTargetsService(repository, targets, config)
+			res, err := service.List(context.Background(), d.query, d.now)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+		})
+	}
+}
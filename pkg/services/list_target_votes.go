@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	apiclients "github.com/a-novel/go-apis/clients"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/samber/lo"
+)
+
+type ListTargetVotesService interface {
+	List(ctx context.Context, tokenRaw string, query *models.ListTargetVotesQuery) (*models.VotesPage, error)
+}
+
+func NewListTargetVotesService(
+	repository dao.VotesRepository,
+	authClient apiclients.AuthClient,
+	permissionsClient apiclients.PermissionsClient,
+	targets map[string]*models.Target,
+	moderationScope apiclients.Scope,
+) ListTargetVotesService {
+	return &listTargetVotesServiceImpl{
+		repository:        repository,
+		authClient:        authClient,
+		permissionsClient: permissionsClient,
+		targets:           targets,
+		moderationScope:   moderationScope,
+	}
+}
+
+type listTargetVotesServiceImpl struct {
+	repository        dao.VotesRepository
+	authClient        apiclients.AuthClient
+	permissionsClient apiclients.PermissionsClient
+	targets           map[string]*models.Target
+	moderationScope   apiclients.Scope
+}
+
+func (s *listTargetVotesServiceImpl) List(ctx context.Context, tokenRaw string, query *models.ListTargetVotesQuery) (*models.VotesPage, error) {
+	target := s.targets[query.Target]
+	if target == nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidTarget)
+	}
+
+	userID, err := introspectUser(ctx, s.authClient, tokenRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	// Moderators can see the voters of every target, while some targets also let their authors see their own voters.
+	// Only moderators can see the votes they discarded.
+	if err := checkScopes(ctx, s.permissionsClient, userID, s.moderationScope); err != nil {
+		if !target.AuthorsSeeVoters || query.Invalidated {
+			return nil, err
+		}
+
+		owner, ownerErr := target.Handler.IsOwner(ctx, userID, query.TargetID.Value())
+		if ownerErr != nil {
+			return nil, goerrors.Join(ErrCheckOwnership, ownerErr)
+		}
+		if !owner {
+			return nil, err
+		}
+	}
+
+	if err := goframework.CheckMinMax(query.Limit, 1, MaxSearchLimit); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSearchLimit, err)
+	}
+	if err := goframework.CheckRestricted(query.Vote, append([]models.VoteValue{""}, target.Values...)...); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, err)
+	}
+
+	var cursor *dao.VotesCursor
+	if query.Cursor != "" {
+		if cursor, err = DecodeVotesCursor(query.Cursor); err != nil {
+			return nil, goerrors.Join(goframework.ErrInvalidEntity, err)
+		}
+	}
+
+	filter := dao.TargetVotesFilter{Vote: lo.EmptyableToPtr(query.Vote), IncludeInvalidated: query.Invalidated}
+
+	// Request an extra vote, to know whether there is a next page.
+	votes, err := s.repository.ListTargetVotes(ctx, query.TargetID.Value(), query.Target, filter, cursor, query.Limit+1)
+	if err != nil {
+		return nil, goerrors.Join(ErrListTargetVotes, err)
+	}
+
+	// The summary already holds the number of valid votes for each value. Invalidated votes must be counted.
+	if filter.IncludeInvalidated {
+		total, err := s.repository.CountTargetVotes(ctx, query.TargetID.Value(), query.Target, filter)
+		if err != nil {
+			return nil, goerrors.Join(ErrCountTargetVotes, err)
+		}
+
+		return newVotesPage(votes, query.Limit, total), nil
+	}
+
+	summary, err := s.repository.GetSummary(ctx, query.TargetID.Value(), query.Target)
+	if err != nil {
+		return nil, goerrors.Join(ErrGetVotesSummary, err)
+	}
+
+	total := summary.Counts[query.Vote]
+	if query.Vote == "" {
+		total = lo.Sum(lo.Values(summary.Counts))
+	}
+
+	return newVotesPage(votes, query.Limit, total), nil
+}
@@ -0,0 +1,598 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	"github.com/a-novel/go-apis"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestListTargetVotesService(t *testing.T) {
+	moderationScope := apiclients.Scope("can_moderate_votes")
+
+	targets := map[string]*models.Target{
+		"target": {
+			Name:             "target",
+			Values:           []models.VoteValue{models.VoteValueUp, models.VoteValueDown},
+			Open:             true,
+			AuthorsSeeVoters: true,
+			Handler: &fakeTargetHandler{
+				owners: map[uuid.UUID]uuid.UUID{goframework.NumberUUID(1): goframework.NumberUUID(100)},
+			},
+		},
+		"unreachable-target": {
+			Name:             "unreachable-target",
+			Values:           []models.VoteValue{models.VoteValueUp},
+			Open:             true,
+			AuthorsSeeVoters: true,
+			Handler:          &fakeTargetHandler{ownerErr: fooErr},
+		},
+		// The author of the target is ignored, as the target does not let authors see their voters.
+		"private-target": {
+			Name:   "private-target",
+			Values: []models.VoteValue{models.VoteValueUp},
+			Open:   true,
+			Handler: &fakeTargetHandler{
+				owners: map[uuid.UUID]uuid.UUID{goframework.NumberUUID(1): goframework.NumberUUID(100)},
+			},
+		},
+		"reactions": {Name: "reactions", Values: []models.VoteValue{"helpful", "funny"}, Open: true},
+	}
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		query    *models.ListTargetVotesQuery
+
+		shouldCallAuth bool
+		authClientResp *apiclients.UserTokenStatus
+		authClientErr  error
+
+		shouldCallModeration bool
+		moderationErr        error
+
+		shouldCallDAO       bool
+		shouldCallDAOFilter dao.TargetVotesFilter
+		shouldCallDAOCursor *dao.VotesCursor
+		daoResp             []*dao.VoteModel
+		daoErr              error
+
+		shouldCallSummary bool
+		summary           *dao.VotesSummaryModel
+		summaryErr        error
+
+		shouldCallCount bool
+		count           int
+		countErr        error
+
+		expect    *models.VotesPage
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:   "target",
+				Limit:    1,
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallModeration: true,
+			shouldCallDAO:        true,
+			daoResp: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(20), baseTime, &updateTime),
+					Vote:     models.VoteValueDown,
+					UserID:   goframework.NumberUUID(10),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, nil),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(11),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+			},
+			shouldCallSummary: true,
+			summary: &dao.VotesSummaryModel{
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				UpVotes:   3,
+				DownVotes: 2,
+				Counts:    map[models.VoteValue]int{models.VoteValueUp: 3, models.VoteValueDown: 2},
+			},
+			expect: &models.VotesPage{
+				Votes: []*models.Vote{
+					{
+						ID:        goframework.NumberUUID(20),
+						UpdatedAt: updateTime,
+						Vote:      models.VoteValueDown,
+						UserID:    goframework.NumberUUID(10),
+						TargetID:  goframework.NumberUUID(1),
+						Target:    "target",
+					},
+				},
+				Total: 5,
+				NextCursor: services.EncodeVotesCursor(&dao.VoteModel{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(20), baseTime, &updateTime),
+				}),
+			},
+		},
+		{
+			name:     "Success/Author",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:   "target",
+				Vote:     models.VoteValueUp,
+				Limit:    10,
+				Cursor: services.EncodeVotesCursor(&dao.VoteModel{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(20), baseTime, &updateTime),
+				}),
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallModeration: true,
+			moderationErr:        fooErr,
+			shouldCallDAO:        true,
+			shouldCallDAOFilter:  dao.TargetVotesFilter{Vote: lo.ToPtr(models.VoteValueUp)},
+			shouldCallDAOCursor: &dao.VotesCursor{
+				ActivityAt: updateTime,
+				ID:         goframework.NumberUUID(20),
+			},
+			daoResp: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, nil),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(11),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+			},
+			shouldCallSummary: true,
+			summary: &dao.VotesSummaryModel{
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				UpVotes:   3,
+				DownVotes: 2,
+				Counts:    map[models.VoteValue]int{models.VoteValueUp: 3, models.VoteValueDown: 2},
+			},
+			expect: &models.VotesPage{
+				Votes: []*models.Vote{
+					{
+						ID:        goframework.NumberUUID(10),
+						UpdatedAt: baseTime,
+						Vote:      models.VoteValueUp,
+						UserID:    goframework.NumberUUID(11),
+						TargetID:  goframework.NumberUUID(1),
+						Target:    "target",
+					},
+				},
+				Total: 3,
+			},
+		},
+		// The summary does not count the invalidated votes.
+		{
+			name:     "Success/Invalidated",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID:    apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:      "target",
+				Limit:       10,
+				Invalidated: true,
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallModeration: true,
+			shouldCallDAO:        true,
+			shouldCallDAOFilter:  dao.TargetVotesFilter{IncludeInvalidated: true},
+			daoResp: []*dao.VoteModel{
+				{
+					Metadata:      bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, nil),
+					Vote:          models.VoteValueUp,
+					UserID:        goframework.NumberUUID(11),
+					TargetID:      goframework.NumberUUID(1),
+					Target:        "target",
+					InvalidatedAt: &updateTime,
+				},
+			},
+			shouldCallCount: true,
+			count:           6,
+			expect: &models.VotesPage{
+				Votes: []*models.Vote{
+					{
+						ID:            goframework.NumberUUID(10),
+						UpdatedAt:     baseTime,
+						Vote:          models.VoteValueUp,
+						UserID:        goframework.NumberUUID(11),
+						TargetID:      goframework.NumberUUID(1),
+						Target:        "target",
+						InvalidatedAt: &updateTime,
+					},
+				},
+				Total: 6,
+			},
+		},
+		{
+			name:     "Success/Reaction",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:   "reactions",
+				Vote:     "funny",
+				Limit:    10,
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallModeration: true,
+			shouldCallDAO:        true,
+			shouldCallDAOFilter:  dao.TargetVotesFilter{Vote: lo.ToPtr(models.VoteValue("funny"))},
+			daoResp: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, nil),
+					Vote:     "funny",
+					UserID:   goframework.NumberUUID(11),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "reactions",
+				},
+			},
+			shouldCallSummary: true,
+			summary: &dao.VotesSummaryModel{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "reactions",
+				Counts:   map[models.VoteValue]int{"funny": 4, "helpful": 1},
+			},
+			expect: &models.VotesPage{
+				Votes: []*models.Vote{
+					{
+						ID:        goframework.NumberUUID(10),
+						UpdatedAt: baseTime,
+						Vote:      "funny",
+						UserID:    goframework.NumberUUID(11),
+						TargetID:  goframework.NumberUUID(1),
+						Target:    "reactions",
+					},
+				},
+				Total: 4,
+			},
+		},
+		// Values from the vocabulary of other targets are rejected.
+		{
+			name:     "Error/VoteNotAllowedOnTarget",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:   "reactions",
+				Vote:     models.VoteValueUp,
+				Limit:    10,
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallModeration: true,
+			expectErr:            goframework.ErrInvalidEntity,
+		},
+		{
+			name:     "Error/SummaryFailure",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:   "target",
+				Limit:    10,
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallModeration: true,
+			shouldCallDAO:        true,
+			daoResp:              []*dao.VoteModel{},
+			shouldCallSummary:    true,
+			summaryErr:           fooErr,
+			expectErr:            fooErr,
+		},
+		{
+			name:     "Error/CountFailure",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID:    apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:      "target",
+				Limit:       10,
+				Invalidated: true,
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallModeration: true,
+			shouldCallDAO:        true,
+			shouldCallDAOFilter:  dao.TargetVotesFilter{IncludeInvalidated: true},
+			daoResp:              []*dao.VoteModel{},
+			shouldCallCount:      true,
+			countErr:             fooErr,
+			expectErr:            fooErr,
+		},
+		{
+			name:     "Error/DAOFailure",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:   "target",
+				Limit:    10,
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallModeration: true,
+			shouldCallDAO:        true,
+			daoErr:               fooErr,
+			expectErr:            fooErr,
+		},
+		{
+			name:     "Error/InvalidCursor",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:   "target",
+				Limit:    10,
+				Cursor:   "not a cursor",
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallModeration: true,
+			expectErr:            services.ErrInvalidCursor,
+		},
+		{
+			name:     "Error/InvalidVote",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:   "target",
+				Vote:     "sideways",
+				Limit:    10,
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallModeration: true,
+			expectErr:            goframework.ErrInvalidEntity,
+		},
+		{
+			name:     "Error/NoLimit",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:   "target",
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallModeration: true,
+			expectErr:            goframework.ErrInvalidEntity,
+		},
+		// The user is not the author of this target.
+		{
+			name:     "Error/MissingPermission",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(2).String()),
+				Target:   "target",
+				Limit:    10,
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallModeration: true,
+			moderationErr:        fooErr,
+			expectErr:            services.ErrCheckPermissions,
+		},
+		// Authors only see the valid votes on their targets.
+		{
+			name:     "Error/InvalidatedByAuthor",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID:    apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:      "target",
+				Limit:       10,
+				Invalidated: true,
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallModeration: true,
+			moderationErr:        fooErr,
+			expectErr:            services.ErrCheckPermissions,
+		},
+		{
+			name:     "Error/OwnershipFailure",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:   "unreachable-target",
+				Limit:    10,
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallModeration: true,
+			moderationErr:        fooErr,
+			expectErr:            services.ErrCheckOwnership,
+		},
+		// Only moderators can see the voters of targets that do not let their authors see them.
+		{
+			name:     "Error/MissingModerationPermission",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:   "private-target",
+				Limit:    10,
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallModeration: true,
+			moderationErr:        fooErr,
+			expectErr:            services.ErrCheckPermissions,
+		},
+		{
+			name:     "Error/InvalidTarget",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:   "fake-target",
+				Limit:    10,
+			},
+			expectErr: services.ErrInvalidTarget,
+		},
+		{
+			name:     "Error/NotAuthenticated",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:   "target",
+				Limit:    10,
+			},
+			shouldCallAuth: true,
+			authClientResp: &apiclients.UserTokenStatus{},
+			expectErr:      goframework.ErrInvalidCredentials,
+		},
+		{
+			name:     "Error/AuthClientFailure",
+			tokenRaw: "token",
+			query: &models.ListTargetVotesQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:   "target",
+				Limit:    10,
+			},
+			shouldCallAuth: true,
+			authClientErr:  fooErr,
+			expectErr:      fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewVotesRepository(t)
+			authClient := apiclientsmocks.NewAuthClient(t)
+			permissionsClient := apiclientsmocks.NewPermissionsClient(t)
+
+			if d.shouldCallAuth {
+				authClient.On("IntrospectToken", context.Background(), d.tokenRaw).Return(d.authClientResp, d.authClientErr)
+			}
+
+			if d.shouldCallModeration {
+				permissionsClient.
+					On("HasUserScope", context.Background(), apiclients.HasUserScopeQuery{
+						UserID: d.authClientResp.Token.Payload.ID,
+						Scope:  moderationScope,
+					}).
+					Return(d.moderationErr)
+			}
+
+			if d.shouldCallDAO {
+				repository.
+					On("ListTargetVotes", context.Background(), d.query.TargetID.Value(), d.query.Target, d.shouldCallDAOFilter, d.shouldCallDAOCursor, d.query.Limit+1).
+					Return(d.daoResp, d.daoErr)
+			}
+
+			if d.shouldCallSummary {
+				repository.
+					On("GetSummary", context.Background(), d.query.TargetID.Value(), d.query.Target).
+					Return(d.summary, d.summaryErr)
+			}
+
+			if d.shouldCallCount {
+				repository.
+					On("CountTargetVotes", context.Background(), d.query.TargetID.Value(), d.query.Target, d.shouldCallDAOFilter).
+					Return(d.count, d.countErr)
+			}
+
+			service := services.NewListTargetVotesService(repository, authClient, permissionsClient, targets, moderationScope)
+			res, err := service.List(context.Background(), d.tokenRaw, d.query)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+			authClient.AssertExpectations(t)
+			permissionsClient.AssertExpectations(t)
+		})
+	}
+}
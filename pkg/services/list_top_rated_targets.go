@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/samber/lo"
+)
+
+type ListTopRatedTargetsService interface {
+	List(ctx context.Context, query *models.ListTopRatedTargetsQuery) ([]*models.RankedTarget, error)
+}
+
+// TopRatedRankingConfig configures the ranking of the rated targets.
+type TopRatedRankingConfig struct {
+	// PriorWeight is the number of average ratings added to every target, when ranking by Bayesian average. The
+	// higher it is, the more ratings a target needs to stand out.
+	PriorWeight float64
+}
+
+func NewListTopRatedTargetsService(
+	repository dao.VotesRepository, targets map[string]*models.Target, config TopRatedRankingConfig,
+) ListTopRatedTargetsService {
+	return &listTopRatedTargetsServiceImpl{
+		repository: repository,
+		targets:    targets,
+		config:     config,
+	}
+}
+
+type listTopRatedTargetsServiceImpl struct {
+	repository dao.VotesRepository
+	targets    map[string]*models.Target
+	config     TopRatedRankingConfig
+}
+
+func (s *listTopRatedTargetsServiceImpl) List(ctx context.Context, query *models.ListTopRatedTargetsQuery) ([]*models.RankedTarget, error) {
+	if err := goframework.CheckMinMax(query.Limit, 1, MaxSearchLimit); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSearchLimit, err)
+	}
+
+	target := s.targets[query.Target]
+	if target == nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidTarget)
+	}
+	if target.Rating == nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrNotRatedTarget)
+	}
+
+	// Without prior, targets are ranked by their plain average.
+	priorWeight := lo.Ternary(query.Bayesian, s.config.PriorWeight, 0)
+
+	targets, err := s.repository.ListTopRatedTargets(ctx, query.Target, priorWeight, query.Limit, query.Offset)
+	if err != nil {
+		return nil, goerrors.Join(ErrListTopRated, err)
+	}
+
+	return lo.Map(targets, func(item *dao.TargetScoreModel, _ int) *models.RankedTarget {
+		return adapters.TargetScoreToModel(item)
+	}), nil
+}
@@ -0,0 +1,146 @@
+package services_test
+
+import (
+	"context"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestListTopRatedTargetsService(t *testing.T) {
+	config := services.TopRatedRankingConfig{
+		PriorWeight: 10,
+	}
+
+	targets := map[string]*models.Target{
+		"story": {
+			Name:   "story",
+			Values: []models.VoteValue{models.VoteValueRating},
+			Rating: &models.RatingScale{Min: 1, Max: 5},
+			Open:   true,
+		},
+		"target": {
+			Name:   "target",
+			Values: []models.VoteValue{models.VoteValueUp, models.VoteValueDown},
+			Open:   true,
+		},
+	}
+
+	data := []struct {
+		name string
+
+		query *models.ListTopRatedTargetsQuery
+
+		shouldCallDAO       bool
+		expectedPriorWeight float64
+		daoResp             []*dao.TargetScoreModel
+		daoErr              error
+
+		expect    []*models.RankedTarget
+		expectErr error
+	}{
+		{
+			name: "Success",
+			query: &models.ListTopRatedTargetsQuery{
+				Target: "story",
+				Limit:  10,
+				Offset: 5,
+			},
+			shouldCallDAO: true,
+			daoResp: []*dao.TargetScoreModel{
+				{TargetID: goframework.NumberUUID(1), Score: 5},
+				{TargetID: goframework.NumberUUID(2), Score: 4.5},
+			},
+			expect: []*models.RankedTarget{
+				{TargetID: goframework.NumberUUID(1), Score: 5},
+				{TargetID: goframework.NumberUUID(2), Score: 4.5},
+			},
+		},
+		{
+			name: "Success/Bayesian",
+			query: &models.ListTopRatedTargetsQuery{
+				Target:   "story",
+				Bayesian: true,
+				Limit:    10,
+			},
+			shouldCallDAO:       true,
+			expectedPriorWeight: 10,
+			daoResp: []*dao.TargetScoreModel{
+				{TargetID: goframework.NumberUUID(2), Score: 4.2},
+				{TargetID: goframework.NumberUUID(1), Score: 4},
+			},
+			expect: []*models.RankedTarget{
+				{TargetID: goframework.NumberUUID(2), Score: 4.2},
+				{TargetID: goframework.NumberUUID(1), Score: 4},
+			},
+		},
+		{
+			name: "Error/DAOFailure",
+			query: &models.ListTopRatedTargetsQuery{
+				Target: "story",
+				Limit:  10,
+			},
+			shouldCallDAO: true,
+			daoErr:        fooErr,
+			expectErr:     fooErr,
+		},
+		{
+			name: "Error/NotRatedTarget",
+			query: &models.ListTopRatedTargetsQuery{
+				Target: "target",
+				Limit:  10,
+			},
+			expectErr: services.ErrNotRatedTarget,
+		},
+		{
+			name: "Error/BadTarget",
+			query: &models.ListTopRatedTargetsQuery{
+				Target: "fake-target",
+				Limit:  10,
+			},
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name: "Error/NoLimit",
+			query: &models.ListTopRatedTargetsQuery{
+				Target: "story",
+			},
+			expectErr: goframework.ErrInvalidEntity,
+		},
+		{
+			name: "Error/LimitTooHigh",
+			query: &models.ListTopRatedTargetsQuery{
+				Target: "story",
+				Limit:  services.MaxSearchLimit + 1,
+			},
+			expectErr: goframework.ErrInvalidEntity,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewVotesRepository(t)
+
+			if d.shouldCallDAO {
+				repository.
+					On(
+						"ListTopRatedTargets", context.Background(), d.query.Target, d.expectedPriorWeight,
+						d.query.Limit, d.query.Offset,
+					).
+					Return(d.daoResp, d.daoErr)
+			}
+
+			service := services.NewListTopRatedTargetsService(repository, targets, config)
+			res, err := service.List(context.Background(), d.query)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+		})
+	}
+}
@@ -5,14 +5,13 @@ import (
 	goerrors "errors"
 	apiclients "github.com/a-novel/go-apis/clients"
 	goframework "github.com/a-novel/go-framework"
-	"github.com/a-novel/votes-service/pkg/adapters"
 	"github.com/a-novel/votes-service/pkg/dao"
 	"github.com/a-novel/votes-service/pkg/models"
 	"github.com/samber/lo"
 )
 
 type ListUserVotesService interface {
-	List(ctx context.Context, tokenRaw string, query *models.ListUserVotesQuery) ([]*models.Vote, error)
+	List(ctx context.Context, tokenRaw string, query *models.ListUserVotesQuery) (*models.VotesPage, error)
 }
 
 func NewListUserVotesService(repository dao.VotesRepository, authClient apiclients.AuthClient) ListUserVotesService {
@@ -27,7 +26,7 @@ type listUserVotesServiceImpl struct {
 	authClient apiclients.AuthClient
 }
 
-func (s *listUserVotesServiceImpl) List(ctx context.Context, tokenRaw string, query *models.ListUserVotesQuery) ([]*models.Vote, error) {
+func (s *listUserVotesServiceImpl) List(ctx context.Context, tokenRaw string, query *models.ListUserVotesQuery) (*models.VotesPage, error) {
 	token, err := s.authClient.IntrospectToken(ctx, tokenRaw)
 	if err != nil {
 		return nil, goerrors.Join(ErrIntrospectToken, err)
@@ -40,12 +39,38 @@ func (s *listUserVotesServiceImpl) List(ctx context.Context, tokenRaw string, qu
 		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSearchLimit, err)
 	}
 
-	votes, err := s.repository.ListUserVotes(ctx, token.Token.Payload.ID, query.Target, query.Limit, query.Offset)
+	// The votes may span several targets, so the value is only checked to be well-formed.
+	if query.Vote != "" && !query.Vote.IsValid() {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidVoteValue)
+	}
+	if !query.Since.IsZero() && !query.Until.IsZero() && query.Until.Before(query.Since) {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidDateRange)
+	}
+
+	filter := dao.UserVotesFilter{
+		Targets: lo.Uniq(query.Targets),
+		Vote:    lo.EmptyableToPtr(query.Vote),
+		Since:   lo.EmptyableToPtr(query.Since),
+		Until:   lo.EmptyableToPtr(query.Until),
+	}
+
+	var cursor *dao.VotesCursor
+	if query.Cursor != "" {
+		if cursor, err = DecodeVotesCursor(query.Cursor); err != nil {
+			return nil, goerrors.Join(goframework.ErrInvalidEntity, err)
+		}
+	}
+
+	// Request an extra vote, to know whether there is a next page.
+	votes, err := s.repository.ListUserVotes(ctx, token.Token.Payload.ID, filter, cursor, query.Limit+1)
 	if err != nil {
 		return nil, goerrors.Join(ErrListUserVotes, err)
 	}
 
-	return lo.Map(votes, func(item *dao.VoteModel, _ int) *models.Vote {
-		return adapters.VoteToModel(item)
-	}), nil
+	total, err := s.repository.CountUserVotes(ctx, token.Token.Payload.ID, filter)
+	if err != nil {
+		return nil, goerrors.Join(ErrCountUserVotes, err)
+	}
+
+	return newVotesPage(votes, query.Limit, total), nil
 }
@@ -10,6 +10,7 @@ import (
 	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
 	"github.com/a-novel/votes-service/pkg/models"
 	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -24,20 +25,25 @@ func TestListUserVotesService(t *testing.T) {
 		authClientResp *apiclients.UserTokenStatus
 		authClientErr  error
 
-		shouldCallDAO bool
-		daoResp       []*dao.VoteModel
-		daoErr        error
+		shouldCallDAO       bool
+		shouldCallDAOFilter dao.UserVotesFilter
+		shouldCallDAOCursor *dao.VotesCursor
+		daoResp             []*dao.VoteModel
+		daoErr              error
 
-		expect    []*models.Vote
+		shouldCallCount bool
+		countResp       int
+		countErr        error
+
+		expect    *models.VotesPage
 		expectErr error
 	}{
 		{
 			name:     "Success",
 			tokenRaw: "token",
 			query: &models.ListUserVotesQuery{
-				Target: "target",
-				Limit:  10,
-				Offset: 5,
+				Targets: []string{"target"},
+				Limit:   10,
 			},
 			authClientResp: &apiclients.UserTokenStatus{
 				OK: true,
@@ -45,7 +51,8 @@ func TestListUserVotesService(t *testing.T) {
 					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
 				},
 			},
-			shouldCallDAO: true,
+			shouldCallDAO:       true,
+			shouldCallDAOFilter: dao.UserVotesFilter{Targets: []string{"target"}},
 			daoResp: []*dao.VoteModel{
 				{
 					Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, nil),
@@ -62,22 +69,124 @@ func TestListUserVotesService(t *testing.T) {
 					Target:   "target",
 				},
 			},
-			expect: []*models.Vote{
+			shouldCallCount: true,
+			countResp:       2,
+			expect: &models.VotesPage{
+				Total: 2,
+				Votes: []*models.Vote{
+					{
+						ID:        goframework.NumberUUID(10),
+						UpdatedAt: baseTime,
+						Vote:      models.VoteValueUp,
+						UserID:    goframework.NumberUUID(100),
+						TargetID:  goframework.NumberUUID(1),
+						Target:    "target",
+					},
+					{
+						ID:        goframework.NumberUUID(20),
+						UpdatedAt: updateTime,
+						Vote:      models.VoteValueDown,
+						UserID:    goframework.NumberUUID(100),
+						TargetID:  goframework.NumberUUID(3),
+						Target:    "target",
+					},
+				},
+			},
+		},
+		{
+			name:     "Success/NextPage",
+			tokenRaw: "token",
+			query: &models.ListUserVotesQuery{
+				Targets: []string{"target"},
+				Limit:   1,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallDAO:       true,
+			shouldCallDAOFilter: dao.UserVotesFilter{Targets: []string{"target"}},
+			daoResp: []*dao.VoteModel{
 				{
-					ID:        goframework.NumberUUID(10),
-					UpdatedAt: baseTime,
-					Vote:      models.VoteValueUp,
-					UserID:    goframework.NumberUUID(100),
-					TargetID:  goframework.NumberUUID(1),
-					Target:    "target",
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(20), baseTime, &updateTime),
+					Vote:     models.VoteValueDown,
+					UserID:   goframework.NumberUUID(100),
+					TargetID: goframework.NumberUUID(3),
+					Target:   "target",
 				},
 				{
-					ID:        goframework.NumberUUID(20),
-					UpdatedAt: updateTime,
-					Vote:      models.VoteValueDown,
-					UserID:    goframework.NumberUUID(100),
-					TargetID:  goframework.NumberUUID(3),
-					Target:    "target",
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, nil),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(100),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+			},
+			shouldCallCount: true,
+			countResp:       2,
+			expect: &models.VotesPage{
+				Total: 2,
+				Votes: []*models.Vote{
+					{
+						ID:        goframework.NumberUUID(20),
+						UpdatedAt: updateTime,
+						Vote:      models.VoteValueDown,
+						UserID:    goframework.NumberUUID(100),
+						TargetID:  goframework.NumberUUID(3),
+						Target:    "target",
+					},
+				},
+				NextCursor: services.EncodeVotesCursor(&dao.VoteModel{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(20), baseTime, &updateTime),
+				}),
+			},
+		},
+		{
+			name:     "Success/WithCursor",
+			tokenRaw: "token",
+			query: &models.ListUserVotesQuery{
+				Targets: []string{"target"},
+				Limit:   10,
+				Cursor: services.EncodeVotesCursor(&dao.VoteModel{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(20), baseTime, &updateTime),
+				}),
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallDAO:       true,
+			shouldCallDAOFilter: dao.UserVotesFilter{Targets: []string{"target"}},
+			shouldCallDAOCursor: &dao.VotesCursor{
+				ActivityAt: updateTime,
+				ID:         goframework.NumberUUID(20),
+			},
+			daoResp: []*dao.VoteModel{
+				{
+					Metadata: bunovel.NewMetadata(goframework.NumberUUID(10), baseTime, nil),
+					Vote:     models.VoteValueUp,
+					UserID:   goframework.NumberUUID(100),
+					TargetID: goframework.NumberUUID(1),
+					Target:   "target",
+				},
+			},
+			shouldCallCount: true,
+			countResp:       2,
+			expect: &models.VotesPage{
+				Total: 2,
+				Votes: []*models.Vote{
+					{
+						ID:        goframework.NumberUUID(10),
+						UpdatedAt: baseTime,
+						Vote:      models.VoteValueUp,
+						UserID:    goframework.NumberUUID(100),
+						TargetID:  goframework.NumberUUID(1),
+						Target:    "target",
+					},
 				},
 			},
 		},
@@ -85,9 +194,8 @@ func TestListUserVotesService(t *testing.T) {
 			name:     "Success/NoResults",
 			tokenRaw: "token",
 			query: &models.ListUserVotesQuery{
-				Target: "target",
-				Limit:  10,
-				Offset: 5,
+				Targets: []string{"target"},
+				Limit:   10,
 			},
 			authClientResp: &apiclients.UserTokenStatus{
 				OK: true,
@@ -95,16 +203,39 @@ func TestListUserVotesService(t *testing.T) {
 					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
 				},
 			},
-			shouldCallDAO: true,
-			expect:        []*models.Vote{},
+			shouldCallDAO:       true,
+			shouldCallDAOFilter: dao.UserVotesFilter{Targets: []string{"target"}},
+			shouldCallCount:     true,
+			countResp:           0,
+			expect:              &models.VotesPage{Votes: []*models.Vote{}},
 		},
 		{
 			name:     "Error/DAOFailure",
 			tokenRaw: "token",
 			query: &models.ListUserVotesQuery{
-				Target: "target",
-				Limit:  10,
-				Offset: 5,
+				Targets: []string{"target"},
+				Limit:   10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallDAO:       true,
+			shouldCallDAOFilter: dao.UserVotesFilter{Targets: []string{"target"}},
+			daoErr:              fooErr,
+			expectErr:           fooErr,
+		},
+		{
+			name:     "Success/Filters",
+			tokenRaw: "token",
+			query: &models.ListUserVotesQuery{
+				Targets: []string{"target", "other-target", "target"},
+				Vote:    models.VoteValueDown,
+				Since:   baseTime,
+				Until:   updateTime,
+				Limit:   10,
 			},
 			authClientResp: &apiclients.UserTokenStatus{
 				OK: true,
@@ -113,16 +244,104 @@ func TestListUserVotesService(t *testing.T) {
 				},
 			},
 			shouldCallDAO: true,
-			daoErr:        fooErr,
-			expectErr:     fooErr,
+			shouldCallDAOFilter: dao.UserVotesFilter{
+				Targets: []string{"target", "other-target"},
+				Vote:    lo.ToPtr(models.VoteValueDown),
+				Since:   lo.ToPtr(baseTime),
+				Until:   lo.ToPtr(updateTime),
+			},
+			shouldCallCount: true,
+			expect:          &models.VotesPage{Votes: []*models.Vote{}},
+		},
+		{
+			name:     "Success/AllTargets",
+			tokenRaw: "token",
+			query: &models.ListUserVotesQuery{
+				Limit: 10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallDAO:       true,
+			shouldCallDAOFilter: dao.UserVotesFilter{Targets: []string{}},
+			shouldCallCount:     true,
+			expect:              &models.VotesPage{Votes: []*models.Vote{}},
+		},
+		{
+			name:     "Error/CountFailure",
+			tokenRaw: "token",
+			query: &models.ListUserVotesQuery{
+				Targets: []string{"target"},
+				Limit:   10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallDAO:       true,
+			shouldCallDAOFilter: dao.UserVotesFilter{Targets: []string{"target"}},
+			shouldCallCount:     true,
+			countErr:            fooErr,
+			expectErr:           fooErr,
+		},
+		{
+			name:     "Error/InvalidVote",
+			tokenRaw: "token",
+			query: &models.ListUserVotesQuery{
+				Vote:  "Sideways!",
+				Limit: 10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			expectErr: services.ErrInvalidVoteValue,
+		},
+		{
+			name:     "Error/InvalidDateRange",
+			tokenRaw: "token",
+			query: &models.ListUserVotesQuery{
+				Since: updateTime,
+				Until: baseTime,
+				Limit: 10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			expectErr: services.ErrInvalidDateRange,
+		},
+		{
+			name:     "Error/InvalidCursor",
+			tokenRaw: "token",
+			query: &models.ListUserVotesQuery{
+				Targets: []string{"target"},
+				Limit:   10,
+				Cursor:  "not a cursor",
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			expectErr: services.ErrInvalidCursor,
 		},
 		{
 			name:     "Error/LimitTooHigh",
 			tokenRaw: "token",
 			query: &models.ListUserVotesQuery{
-				Target: "target",
-				Limit:  services.MaxSearchLimit + 1,
-				Offset: 5,
+				Targets: []string{"target"},
+				Limit:   services.MaxSearchLimit + 1,
 			},
 			authClientResp: &apiclients.UserTokenStatus{
 				OK: true,
@@ -136,8 +355,7 @@ func TestListUserVotesService(t *testing.T) {
 			name:     "Error/NoLimit",
 			tokenRaw: "token",
 			query: &models.ListUserVotesQuery{
-				Target: "target",
-				Offset: 5,
+				Targets: []string{"target"},
 			},
 			authClientResp: &apiclients.UserTokenStatus{
 				OK: true,
@@ -151,9 +369,8 @@ func TestListUserVotesService(t *testing.T) {
 			name:     "Error/NotAuthenticated",
 			tokenRaw: "token",
 			query: &models.ListUserVotesQuery{
-				Target: "target",
-				Limit:  10,
-				Offset: 5,
+				Targets: []string{"target"},
+				Limit:   10,
 			},
 			authClientResp: &apiclients.UserTokenStatus{},
 			expectErr:      goframework.ErrInvalidCredentials,
@@ -162,9 +379,8 @@ func TestListUserVotesService(t *testing.T) {
 			name:     "Error/AuthClientFailure",
 			tokenRaw: "token",
 			query: &models.ListUserVotesQuery{
-				Target: "target",
-				Limit:  10,
-				Offset: 5,
+				Targets: []string{"target"},
+				Limit:   10,
 			},
 			authClientErr: fooErr,
 			expectErr:     fooErr,
@@ -180,10 +396,16 @@ func TestListUserVotesService(t *testing.T) {
 
 			if d.shouldCallDAO {
 				repository.
-					On("ListUserVotes", context.Background(), d.authClientResp.Token.Payload.ID, d.query.Target, d.query.Limit, d.query.Offset).
+					On("ListUserVotes", context.Background(), d.authClientResp.Token.Payload.ID, d.shouldCallDAOFilter, d.shouldCallDAOCursor, d.query.Limit+1).
 					Return(d.daoResp, d.daoErr)
 			}
 
+			if d.shouldCallCount {
+				repository.
+					On("CountUserVotes", context.Background(), d.authClientResp.Token.Payload.ID, d.shouldCallDAOFilter).
+					Return(d.countResp, d.countErr)
+			}
+
 			service := services.NewListUserVotesService(repository, authClient)
 
 			resp, err := service.List(context.Background(), d.tokenRaw, d.query)
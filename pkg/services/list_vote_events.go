@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	apiclients "github.com/a-novel/go-apis/clients"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type ListVoteEventsService interface {
+	List(ctx context.Context, tokenRaw string, query *models.ListVoteEventsQuery) ([]*models.VoteEvent, error)
+}
+
+func NewListVoteEventsService(
+	repository dao.VoteEventsRepository,
+	authClient apiclients.AuthClient,
+	permissionsClient apiclients.PermissionsClient,
+	moderationScope apiclients.Scope,
+) ListVoteEventsService {
+	return &listVoteEventsServiceImpl{
+		repository:        repository,
+		authClient:        authClient,
+		permissionsClient: permissionsClient,
+		moderationScope:   moderationScope,
+	}
+}
+
+type listVoteEventsServiceImpl struct {
+	repository        dao.VoteEventsRepository
+	authClient        apiclients.AuthClient
+	permissionsClient apiclients.PermissionsClient
+	moderationScope   apiclients.Scope
+}
+
+func (s *listVoteEventsServiceImpl) List(ctx context.Context, tokenRaw string, query *models.ListVoteEventsQuery) ([]*models.VoteEvent, error) {
+	if _, err := checkUserScope(ctx, s.authClient, s.permissionsClient, tokenRaw, s.moderationScope); err != nil {
+		return nil, err
+	}
+
+	if err := goframework.CheckMinMax(query.Limit, 1, MaxSearchLimit); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSearchLimit, err)
+	}
+
+	filter := dao.VoteEventsFilter{Target: query.Target}
+	if userID := query.UserID.Value(); userID != uuid.Nil {
+		filter.UserID = &userID
+	}
+	if targetID := query.TargetID.Value(); targetID != uuid.Nil {
+		// Target IDs are only unique within a target.
+		if query.Target == "" {
+			return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidTarget)
+		}
+
+		filter.TargetID = &targetID
+	}
+
+	// Listing the whole history is not supported, as it is not meant to be browsed without context.
+	if filter.UserID == nil && filter.TargetID == nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrMissingFilter)
+	}
+
+	events, err := s.repository.List(ctx, filter, query.Limit, query.Offset)
+	if err != nil {
+		return nil, goerrors.Join(ErrListVoteEvents, err)
+	}
+
+	return lo.Map(events, func(item *dao.VoteEventModel, _ int) *models.VoteEvent {
+		return adapters.VoteEventToModel(item)
+	}), nil
+}
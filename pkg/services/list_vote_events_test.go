@@ -0,0 +1,249 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/go-apis"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestListVoteEventsService(t *testing.T) {
+	moderationScope := apiclients.Scope("can_moderate_votes")
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		query    *models.ListVoteEventsQuery
+
+		authClientResp *apiclients.UserTokenStatus
+		authClientErr  error
+
+		shouldCallPermissions bool
+		permissionsErr        error
+
+		shouldCallDAO     bool
+		shouldCallDAOWith dao.VoteEventsFilter
+		daoResp           []*dao.VoteEventModel
+		daoErr            error
+
+		expect    []*models.VoteEvent
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			tokenRaw: "token",
+			query: &models.ListVoteEventsQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Target:   "target",
+				Limit:    10,
+				Offset:   5,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: dao.VoteEventsFilter{
+				TargetID: lo.ToPtr(goframework.NumberUUID(1)),
+				Target:   "target",
+			},
+			daoResp: []*dao.VoteEventModel{
+				{
+					ID:        2,
+					CreatedAt: updateTime,
+					Event:     models.VoteEventTypeFlip,
+					UserID:    goframework.NumberUUID(10),
+					TargetID:  goframework.NumberUUID(1),
+					Target:    "target",
+					OldVote:   lo.ToPtr(models.VoteValueUp),
+					NewVote:   lo.ToPtr(models.VoteValueDown),
+				},
+			},
+			expect: []*models.VoteEvent{
+				{
+					ID:        2,
+					CreatedAt: updateTime,
+					Event:     models.VoteEventTypeFlip,
+					UserID:    goframework.NumberUUID(10),
+					TargetID:  goframework.NumberUUID(1),
+					Target:    "target",
+					OldVote:   lo.ToPtr(models.VoteValueUp),
+					NewVote:   lo.ToPtr(models.VoteValueDown),
+				},
+			},
+		},
+		{
+			name:     "Success/User",
+			tokenRaw: "token",
+			query: &models.ListVoteEventsQuery{
+				UserID: apis.StringUUID(goframework.NumberUUID(10).String()),
+				Limit:  10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: dao.VoteEventsFilter{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			daoResp: []*dao.VoteEventModel{},
+			expect:  []*models.VoteEvent{},
+		},
+		{
+			name:     "Error/DAOFailure",
+			tokenRaw: "token",
+			query: &models.ListVoteEventsQuery{
+				UserID: apis.StringUUID(goframework.NumberUUID(10).String()),
+				Limit:  10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: dao.VoteEventsFilter{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			daoErr:    fooErr,
+			expectErr: fooErr,
+		},
+		{
+			name:     "Error/NoFilter",
+			tokenRaw: "token",
+			query: &models.ListVoteEventsQuery{
+				Target: "target",
+				Limit:  10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			expectErr:             services.ErrMissingFilter,
+		},
+		{
+			name:     "Error/TargetIDWithoutTarget",
+			tokenRaw: "token",
+			query: &models.ListVoteEventsQuery{
+				TargetID: apis.StringUUID(goframework.NumberUUID(1).String()),
+				Limit:    10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			expectErr:             goframework.ErrInvalidEntity,
+		},
+		{
+			name:     "Error/NoLimit",
+			tokenRaw: "token",
+			query: &models.ListVoteEventsQuery{
+				UserID: apis.StringUUID(goframework.NumberUUID(10).String()),
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			expectErr:             goframework.ErrInvalidEntity,
+		},
+		{
+			name:     "Error/MissingPermission",
+			tokenRaw: "token",
+			query: &models.ListVoteEventsQuery{
+				UserID: apis.StringUUID(goframework.NumberUUID(10).String()),
+				Limit:  10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			permissionsErr:        fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:     "Error/NotAuthenticated",
+			tokenRaw: "token",
+			query: &models.ListVoteEventsQuery{
+				UserID: apis.StringUUID(goframework.NumberUUID(10).String()),
+				Limit:  10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{},
+			expectErr:      goframework.ErrInvalidCredentials,
+		},
+		{
+			name:     "Error/AuthClientFailure",
+			tokenRaw: "token",
+			query: &models.ListVoteEventsQuery{
+				UserID: apis.StringUUID(goframework.NumberUUID(10).String()),
+				Limit:  10,
+			},
+			authClientErr: fooErr,
+			expectErr:     fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewVoteEventsRepository(t)
+			authClient := apiclientsmocks.NewAuthClient(t)
+			permissionsClient := apiclientsmocks.NewPermissionsClient(t)
+
+			authClient.On("IntrospectToken", context.Background(), d.tokenRaw).Return(d.authClientResp, d.authClientErr)
+
+			if d.shouldCallPermissions {
+				permissionsClient.
+					On("HasUserScope", context.Background(), apiclients.HasUserScopeQuery{
+						UserID: d.authClientResp.Token.Payload.ID,
+						Scope:  moderationScope,
+					}).
+					Return(d.permissionsErr)
+			}
+
+			if d.shouldCallDAO {
+				repository.
+					On("List", context.Background(), d.shouldCallDAOWith, d.query.Limit, d.query.Offset).
+					Return(d.daoResp, d.daoErr)
+			}
+
+			service := services.NewListVoteEventsService(repository, authClient, permissionsClient, moderationScope)
+			res, err := service.List(context.Background(), d.tokenRaw, d.query)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+			authClient.AssertExpectations(t)
+			permissionsClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	apiclients "github.com/a-novel/go-apis/clients"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/samber/lo"
+)
+
+type ListVoteFlagsService interface {
+	List(ctx context.Context, tokenRaw string, query *models.ListVoteFlagsQuery) ([]*models.VoteFlag, error)
+}
+
+func NewListVoteFlagsService(
+	repository dao.VoteFlagsRepository,
+	authClient apiclients.AuthClient,
+	permissionsClient apiclients.PermissionsClient,
+	moderationScope apiclients.Scope,
+) ListVoteFlagsService {
+	return &listVoteFlagsServiceImpl{
+		repository:        repository,
+		authClient:        authClient,
+		permissionsClient: permissionsClient,
+		moderationScope:   moderationScope,
+	}
+}
+
+type listVoteFlagsServiceImpl struct {
+	repository        dao.VoteFlagsRepository
+	authClient        apiclients.AuthClient
+	permissionsClient apiclients.PermissionsClient
+	moderationScope   apiclients.Scope
+}
+
+func (s *listVoteFlagsServiceImpl) List(ctx context.Context, tokenRaw string, query *models.ListVoteFlagsQuery) ([]*models.VoteFlag, error) {
+	if _, err := checkUserScope(ctx, s.authClient, s.permissionsClient, tokenRaw, s.moderationScope); err != nil {
+		return nil, err
+	}
+
+	if err := goframework.CheckMinMax(query.Limit, 1, MaxSearchLimit); err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidSearchLimit, err)
+	}
+
+	err := goframework.CheckRestricted(
+		query.Kind, "", models.VoteFlagKindCoVoting, models.VoteFlagKindNewVoters, models.VoteFlagKindFlipBurst,
+	)
+	if err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, err)
+	}
+
+	err = goframework.CheckRestricted(
+		query.Status, "", models.VoteFlagStatusPending, models.VoteFlagStatusConfirmed, models.VoteFlagStatusDismissed,
+	)
+	if err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, err)
+	}
+
+	flags, err := s.repository.List(ctx, dao.VoteFlagsFilter{Kind: query.Kind, Status: query.Status}, query.Limit, query.Offset)
+	if err != nil {
+		return nil, goerrors.Join(ErrListVoteFlags, err)
+	}
+
+	return lo.Map(flags, func(item *dao.VoteFlagModel, _ int) *models.VoteFlag {
+		return adapters.VoteFlagToModel(item)
+	}), nil
+}
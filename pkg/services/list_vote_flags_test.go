@@ -0,0 +1,236 @@
+package services_test
+
+import (
+	"context"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestListVoteFlagsService(t *testing.T) {
+	moderationScope := apiclients.Scope("can_moderate_votes")
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		query    *models.ListVoteFlagsQuery
+
+		authClientResp *apiclients.UserTokenStatus
+		authClientErr  error
+
+		shouldCallPermissions bool
+		permissionsErr        error
+
+		shouldCallDAO     bool
+		shouldCallDAOWith dao.VoteFlagsFilter
+		daoResp           []*dao.VoteFlagModel
+		daoErr            error
+
+		expect    []*models.VoteFlag
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			tokenRaw: "token",
+			query: &models.ListVoteFlagsQuery{
+				Kind:   models.VoteFlagKindCoVoting,
+				Status: models.VoteFlagStatusPending,
+				Limit:  10,
+				Offset: 5,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: dao.VoteFlagsFilter{
+				Kind:   models.VoteFlagKindCoVoting,
+				Status: models.VoteFlagStatusPending,
+			},
+			daoResp: []*dao.VoteFlagModel{
+				{
+					ID:          goframework.NumberUUID(1),
+					CreatedAt:   baseTime,
+					Kind:        models.VoteFlagKindCoVoting,
+					Target:      "target",
+					TargetIDs:   []uuid.UUID{goframework.NumberUUID(10), goframework.NumberUUID(11)},
+					UserIDs:     []uuid.UUID{goframework.NumberUUID(20), goframework.NumberUUID(21)},
+					Occurrences: 2,
+					Fingerprint: "fingerprint",
+					Status:      models.VoteFlagStatusPending,
+				},
+			},
+			expect: []*models.VoteFlag{
+				{
+					ID:          goframework.NumberUUID(1),
+					CreatedAt:   baseTime,
+					Kind:        models.VoteFlagKindCoVoting,
+					Target:      "target",
+					TargetIDs:   []uuid.UUID{goframework.NumberUUID(10), goframework.NumberUUID(11)},
+					UserIDs:     []uuid.UUID{goframework.NumberUUID(20), goframework.NumberUUID(21)},
+					Occurrences: 2,
+					Status:      models.VoteFlagStatusPending,
+				},
+			},
+		},
+		{
+			name:     "Success/NoFilter",
+			tokenRaw: "token",
+			query: &models.ListVoteFlagsQuery{
+				Limit: 10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			daoResp:               []*dao.VoteFlagModel{},
+			expect:                []*models.VoteFlag{},
+		},
+		{
+			name:     "Error/DAOFailure",
+			tokenRaw: "token",
+			query: &models.ListVoteFlagsQuery{
+				Limit: 10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			daoErr:                fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:     "Error/InvalidKind",
+			tokenRaw: "token",
+			query: &models.ListVoteFlagsQuery{
+				Kind:  "foo",
+				Limit: 10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			expectErr:             goframework.ErrInvalidEntity,
+		},
+		{
+			name:     "Error/InvalidStatus",
+			tokenRaw: "token",
+			query: &models.ListVoteFlagsQuery{
+				Status: "foo",
+				Limit:  10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			expectErr:             goframework.ErrInvalidEntity,
+		},
+		{
+			name:     "Error/NoLimit",
+			tokenRaw: "token",
+			query:    &models.ListVoteFlagsQuery{},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			expectErr:             goframework.ErrInvalidEntity,
+		},
+		{
+			name:     "Error/MissingPermission",
+			tokenRaw: "token",
+			query: &models.ListVoteFlagsQuery{
+				Limit: 10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			permissionsErr:        fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:     "Error/NotAuthenticated",
+			tokenRaw: "token",
+			query: &models.ListVoteFlagsQuery{
+				Limit: 10,
+			},
+			authClientResp: &apiclients.UserTokenStatus{},
+			expectErr:      goframework.ErrInvalidCredentials,
+		},
+		{
+			name:     "Error/AuthClientFailure",
+			tokenRaw: "token",
+			query: &models.ListVoteFlagsQuery{
+				Limit: 10,
+			},
+			authClientErr: fooErr,
+			expectErr:     fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewVoteFlagsRepository(t)
+			authClient := apiclientsmocks.NewAuthClient(t)
+			permissionsClient := apiclientsmocks.NewPermissionsClient(t)
+
+			authClient.On("IntrospectToken", context.Background(), d.tokenRaw).Return(d.authClientResp, d.authClientErr)
+
+			if d.shouldCallPermissions {
+				permissionsClient.
+					On("HasUserScope", context.Background(), apiclients.HasUserScopeQuery{
+						UserID: d.authClientResp.Token.Payload.ID,
+						Scope:  moderationScope,
+					}).
+					Return(d.permissionsErr)
+			}
+
+			if d.shouldCallDAO {
+				repository.
+					On("List", context.Background(), d.shouldCallDAOWith, d.query.Limit, d.query.Offset).
+					Return(d.daoResp, d.daoErr)
+			}
+
+			service := services.NewListVoteFlagsService(repository, authClient, permissionsClient, moderationScope)
+			res, err := service.List(context.Background(), d.tokenRaw, d.query)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+			authClient.AssertExpectations(t)
+			permissionsClient.AssertExpectations(t)
+		})
+	}
+}
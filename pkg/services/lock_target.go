@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	apiclients "github.com/a-novel/go-apis/clients"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/samber/lo"
+	"time"
+)
+
+type LockTargetService interface {
+	// Lock stops a target from accepting votes, right away or at the date set in the form. Locking a target again
+	// replaces its previous lock.
+	Lock(ctx context.Context, tokenRaw string, form models.LockTargetForm, now time.Time) (*models.TargetLock, error)
+}
+
+func NewLockTargetService(
+	repository dao.TargetLocksRepository,
+	authClient apiclients.AuthClient,
+	permissionsClient apiclients.PermissionsClient,
+	targets map[string]*models.Target,
+	moderationScope apiclients.Scope,
+) LockTargetService {
+	return &lockTargetServiceImpl{
+		repository:        repository,
+		authClient:        authClient,
+		permissionsClient: permissionsClient,
+		targets:           targets,
+		moderationScope:   moderationScope,
+	}
+}
+
+type lockTargetServiceImpl struct {
+	repository        dao.TargetLocksRepository
+	authClient        apiclients.AuthClient
+	permissionsClient apiclients.PermissionsClient
+
+	targets         map[string]*models.Target
+	moderationScope apiclients.Scope
+}
+
+func (s *lockTargetServiceImpl) Lock(ctx context.Context, tokenRaw string, form models.LockTargetForm, now time.Time) (*models.TargetLock, error) {
+	userID, err := checkUserScope(ctx, s.authClient, s.permissionsClient, tokenRaw, s.moderationScope)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.targets[form.Target] == nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidTarget)
+	}
+
+	lock, err := s.repository.Lock(ctx, form.TargetID, form.Target, userID, lo.FromPtrOr(form.ClosesAt, now), now)
+	if err != nil {
+		return nil, goerrors.Join(ErrLockTarget, err)
+	}
+
+	return adapters.TargetLockToModel(lock), nil
+}
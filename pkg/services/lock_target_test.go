@@ -0,0 +1,198 @@
+package services_test
+
+import (
+	"context"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestLockTargetService(t *testing.T) {
+	moderationScope := apiclients.Scope("can_moderate_votes")
+
+	targets := map[string]*models.Target{
+		"target": {Name: "target", Open: true},
+	}
+
+	moderatorToken := &apiclients.UserTokenStatus{
+		OK: true,
+		Token: &apiclients.UserToken{
+			Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		form     models.LockTargetForm
+
+		authClientResp *apiclients.UserTokenStatus
+		authClientErr  error
+
+		shouldCallPermissions bool
+		permissionsErr        error
+
+		shouldCallDAO         bool
+		shouldCallDAOClosesAt time.Time
+		daoResp               *dao.TargetLockModel
+		daoErr                error
+
+		expect    *models.TargetLock
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			tokenRaw: "token",
+			form: models.LockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOClosesAt: baseTime,
+			daoResp: &dao.TargetLockModel{
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				CreatedAt: baseTime,
+				LockedBy:  goframework.NumberUUID(100),
+				ClosesAt:  baseTime,
+			},
+			expect: &models.TargetLock{
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				CreatedAt: baseTime,
+				LockedBy:  goframework.NumberUUID(100),
+				ClosesAt:  baseTime,
+			},
+		},
+		{
+			name:     "Success/Scheduled",
+			tokenRaw: "token",
+			form: models.LockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+				ClosesAt: lo.ToPtr(updateTime),
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOClosesAt: updateTime,
+			daoResp: &dao.TargetLockModel{
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				CreatedAt: baseTime,
+				LockedBy:  goframework.NumberUUID(100),
+				ClosesAt:  updateTime,
+			},
+			expect: &models.TargetLock{
+				TargetID:  goframework.NumberUUID(1),
+				Target:    "target",
+				CreatedAt: baseTime,
+				LockedBy:  goframework.NumberUUID(100),
+				ClosesAt:  updateTime,
+			},
+		},
+		{
+			name:     "Error/DAOFailure",
+			tokenRaw: "token",
+			form: models.LockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOClosesAt: baseTime,
+			daoErr:                fooErr,
+			expectErr:             services.ErrLockTarget,
+		},
+		{
+			name:     "Error/UnknownTarget",
+			tokenRaw: "token",
+			form: models.LockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "foo",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidTarget,
+		},
+		{
+			name:     "Error/MissingPermission",
+			tokenRaw: "token",
+			form: models.LockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			permissionsErr:        fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:     "Error/NotAuthenticated",
+			tokenRaw: "token",
+			form: models.LockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			authClientResp: &apiclients.UserTokenStatus{},
+			expectErr:      goframework.ErrInvalidCredentials,
+		},
+		{
+			name:     "Error/AuthClientFailure",
+			tokenRaw: "token",
+			form: models.LockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			authClientErr: fooErr,
+			expectErr:     fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewTargetLocksRepository(t)
+			authClient := apiclientsmocks.NewAuthClient(t)
+			permissionsClient := apiclientsmocks.NewPermissionsClient(t)
+
+			authClient.On("IntrospectToken", context.Background(), d.tokenRaw).Return(d.authClientResp, d.authClientErr)
+
+			if d.shouldCallPermissions {
+				permissionsClient.
+					On("HasUserScope", context.Background(), apiclients.HasUserScopeQuery{
+						UserID: d.authClientResp.Token.Payload.ID,
+						Scope:  moderationScope,
+					}).
+					Return(d.permissionsErr)
+			}
+
+			if d.shouldCallDAO {
+				repository.
+					On("Lock", context.Background(), d.form.TargetID, d.form.Target, d.authClientResp.Token.Payload.ID, d.shouldCallDAOClosesAt, baseTime).
+					Return(d.daoResp, d.daoErr)
+			}
+
+			service := services.NewLockTargetService(repository, authClient, permissionsClient, targets, moderationScope)
+			res, err := service.Lock(context.Background(), d.tokenRaw, d.form, baseTime)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+			authClient.AssertExpectations(t)
+			permissionsClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// CastBallotService is an autogenerated mock type for the CastBallotService type
+type CastBallotService struct {
+	mock.Mock
+}
+
+type CastBallotService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CastBallotService) EXPECT() *CastBallotService_Expecter {
+	return &CastBallotService_Expecter{mock: &_m.Mock}
+}
+
+// Cast provides a mock function with given fields: ctx, tokenRaw, form, now
+func (_m *CastBallotService) Cast(ctx context.Context, tokenRaw string, form models.CastBallotForm, now time.Time) (*models.PollBallot, error) {
+	ret := _m.Called(ctx, tokenRaw, form, now)
+
+	var r0 *models.PollBallot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.CastBallotForm, time.Time) (*models.PollBallot, error)); ok {
+		return rf(ctx, tokenRaw, form, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.CastBallotForm, time.Time) *models.PollBallot); ok {
+		r0 = rf(ctx, tokenRaw, form, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PollBallot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.CastBallotForm, time.Time) error); ok {
+		r1 = rf(ctx, tokenRaw, form, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CastBallotService_Cast_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cast'
+type CastBallotService_Cast_Call struct {
+	*mock.Call
+}
+
+// Cast is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - form models.CastBallotForm
+//   - now time.Time
+func (_e *CastBallotService_Expecter) Cast(ctx interface{}, tokenRaw interface{}, form interface{}, now interface{}) *CastBallotService_Cast_Call {
+	return &CastBallotService_Cast_Call{Call: _e.mock.On("Cast", ctx, tokenRaw, form, now)}
+}
+
+func (_c *CastBallotService_Cast_Call) Run(run func(ctx context.Context, tokenRaw string, form models.CastBallotForm, now time.Time)) *CastBallotService_Cast_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.CastBallotForm), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CastBallotService_Cast_Call) Return(_a0 *models.PollBallot, _a1 error) *CastBallotService_Cast_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CastBallotService_Cast_Call) RunAndReturn(run func(context.Context, string, models.CastBallotForm, time.Time) (*models.PollBallot, error)) *CastBallotService_Cast_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCastBallotService creates a new instance of CastBallotService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCastBallotService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CastBallotService {
+	mock := &CastBallotService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// CastRankingService is an autogenerated mock type for the CastRankingService type
+type CastRankingService struct {
+	mock.Mock
+}
+
+type CastRankingService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CastRankingService) EXPECT() *CastRankingService_Expecter {
+	return &CastRankingService_Expecter{mock: &_m.Mock}
+}
+
+// Cast provides a mock function with given fields: ctx, tokenRaw, form, now
+func (_m *CastRankingService) Cast(ctx context.Context, tokenRaw string, form models.CastRankingForm, now time.Time) (*models.PollRanking, error) {
+	ret := _m.Called(ctx, tokenRaw, form, now)
+
+	var r0 *models.PollRanking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.CastRankingForm, time.Time) (*models.PollRanking, error)); ok {
+		return rf(ctx, tokenRaw, form, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.CastRankingForm, time.Time) *models.PollRanking); ok {
+		r0 = rf(ctx, tokenRaw, form, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PollRanking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.CastRankingForm, time.Time) error); ok {
+		r1 = rf(ctx, tokenRaw, form, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CastRankingService_Cast_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cast'
+type CastRankingService_Cast_Call struct {
+	*mock.Call
+}
+
+// Cast is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - form models.CastRankingForm
+//   - now time.Time
+func (_e *CastRankingService_Expecter) Cast(ctx interface{}, tokenRaw interface{}, form interface{}, now interface{}) *CastRankingService_Cast_Call {
+	return &CastRankingService_Cast_Call{Call: _e.mock.On("Cast", ctx, tokenRaw, form, now)}
+}
+
+func (_c *CastRankingService_Cast_Call) Run(run func(ctx context.Context, tokenRaw string, form models.CastRankingForm, now time.Time)) *CastRankingService_Cast_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.CastRankingForm), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CastRankingService_Cast_Call) Return(_a0 *models.PollRanking, _a1 error) *CastRankingService_Cast_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CastRankingService_Cast_Call) RunAndReturn(run func(context.Context, string, models.CastRankingForm, time.Time) (*models.PollRanking, error)) *CastRankingService_Cast_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCastRankingService creates a new instance of CastRankingService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCastRankingService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CastRankingService {
+	mock := &CastRankingService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
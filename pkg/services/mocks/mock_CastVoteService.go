@@ -26,25 +26,25 @@ func (_m *CastVoteService) EXPECT() *CastVoteService_Expecter {
 	return &CastVoteService_Expecter{mock: &_m.Mock}
 }
 
-// Cast provides a mock function with given fields: ctx, tokenRaw, form, id, now
-func (_m *CastVoteService) Cast(ctx context.Context, tokenRaw string, form models.VoteForm, id uuid.UUID, now time.Time) (*models.VotesSummary, error) {
-	ret := _m.Called(ctx, tokenRaw, form, id, now)
+// Cast provides a mock function with given fields: ctx, tokenRaw, form, idempotencyKey, id, now
+func (_m *CastVoteService) Cast(ctx context.Context, tokenRaw string, form models.VoteForm, idempotencyKey string, id uuid.UUID, now time.Time) (*models.VotesSummary, error) {
+	ret := _m.Called(ctx, tokenRaw, form, idempotencyKey, id, now)
 
 	var r0 *models.VotesSummary
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, models.VoteForm, uuid.UUID, time.Time) (*models.VotesSummary, error)); ok {
-		return rf(ctx, tokenRaw, form, id, now)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VoteForm, string, uuid.UUID, time.Time) (*models.VotesSummary, error)); ok {
+		return rf(ctx, tokenRaw, form, idempotencyKey, id, now)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, models.VoteForm, uuid.UUID, time.Time) *models.VotesSummary); ok {
-		r0 = rf(ctx, tokenRaw, form, id, now)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VoteForm, string, uuid.UUID, time.Time) *models.VotesSummary); ok {
+		r0 = rf(ctx, tokenRaw, form, idempotencyKey, id, now)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.VotesSummary)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, models.VoteForm, uuid.UUID, time.Time) error); ok {
-		r1 = rf(ctx, tokenRaw, form, id, now)
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.VoteForm, string, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, tokenRaw, form, idempotencyKey, id, now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -61,15 +61,16 @@ type CastVoteService_Cast_Call struct {
 //   - ctx context.Context
 //   - tokenRaw string
 //   - form models.VoteForm
+//   - idempotencyKey string
 //   - id uuid.UUID
 //   - now time.Time
-func (_e *CastVoteService_Expecter) Cast(ctx interface{}, tokenRaw interface{}, form interface{}, id interface{}, now interface{}) *CastVoteService_Cast_Call {
-	return &CastVoteService_Cast_Call{Call: _e.mock.On("Cast", ctx, tokenRaw, form, id, now)}
+func (_e *CastVoteService_Expecter) Cast(ctx interface{}, tokenRaw interface{}, form interface{}, idempotencyKey interface{}, id interface{}, now interface{}) *CastVoteService_Cast_Call {
+	return &CastVoteService_Cast_Call{Call: _e.mock.On("Cast", ctx, tokenRaw, form, idempotencyKey, id, now)}
 }
 
-func (_c *CastVoteService_Cast_Call) Run(run func(ctx context.Context, tokenRaw string, form models.VoteForm, id uuid.UUID, now time.Time)) *CastVoteService_Cast_Call {
+func (_c *CastVoteService_Cast_Call) Run(run func(ctx context.Context, tokenRaw string, form models.VoteForm, idempotencyKey string, id uuid.UUID, now time.Time)) *CastVoteService_Cast_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(models.VoteForm), args[3].(uuid.UUID), args[4].(time.Time))
+		run(args[0].(context.Context), args[1].(string), args[2].(models.VoteForm), args[3].(string), args[4].(uuid.UUID), args[5].(time.Time))
 	})
 	return _c
 }
@@ -79,7 +80,7 @@ func (_c *CastVoteService_Cast_Call) Return(_a0 *models.VotesSummary, _a1 error)
 	return _c
 }
 
-func (_c *CastVoteService_Cast_Call) RunAndReturn(run func(context.Context, string, models.VoteForm, uuid.UUID, time.Time) (*models.VotesSummary, error)) *CastVoteService_Cast_Call {
+func (_c *CastVoteService_Cast_Call) RunAndReturn(run func(context.Context, string, models.VoteForm, string, uuid.UUID, time.Time) (*models.VotesSummary, error)) *CastVoteService_Cast_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,99 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// CreatePollService is an autogenerated mock type for the CreatePollService type
+type CreatePollService struct {
+	mock.Mock
+}
+
+type CreatePollService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CreatePollService) EXPECT() *CreatePollService_Expecter {
+	return &CreatePollService_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, tokenRaw, form, id, now
+func (_m *CreatePollService) Create(ctx context.Context, tokenRaw string, form models.CreatePollForm, id uuid.UUID, now time.Time) (*models.Poll, error) {
+	ret := _m.Called(ctx, tokenRaw, form, id, now)
+
+	var r0 *models.Poll
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.CreatePollForm, uuid.UUID, time.Time) (*models.Poll, error)); ok {
+		return rf(ctx, tokenRaw, form, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.CreatePollForm, uuid.UUID, time.Time) *models.Poll); ok {
+		r0 = rf(ctx, tokenRaw, form, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Poll)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.CreatePollForm, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, tokenRaw, form, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePollService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type CreatePollService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - form models.CreatePollForm
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *CreatePollService_Expecter) Create(ctx interface{}, tokenRaw interface{}, form interface{}, id interface{}, now interface{}) *CreatePollService_Create_Call {
+	return &CreatePollService_Create_Call{Call: _e.mock.On("Create", ctx, tokenRaw, form, id, now)}
+}
+
+func (_c *CreatePollService_Create_Call) Run(run func(ctx context.Context, tokenRaw string, form models.CreatePollForm, id uuid.UUID, now time.Time)) *CreatePollService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.CreatePollForm), args[3].(uuid.UUID), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *CreatePollService_Create_Call) Return(_a0 *models.Poll, _a1 error) *CreatePollService_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CreatePollService_Create_Call) RunAndReturn(run func(context.Context, string, models.CreatePollForm, uuid.UUID, time.Time) (*models.Poll, error)) *CreatePollService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCreatePollService creates a new instance of CreatePollService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCreatePollService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CreatePollService {
+	mock := &CreatePollService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// DispatchOutboxService is an autogenerated mock type for the DispatchOutboxService type
+type DispatchOutboxService struct {
+	mock.Mock
+}
+
+type DispatchOutboxService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DispatchOutboxService) EXPECT() *DispatchOutboxService_Expecter {
+	return &DispatchOutboxService_Expecter{mock: &_m.Mock}
+}
+
+// Dispatch provides a mock function with given fields: ctx, now
+func (_m *DispatchOutboxService) Dispatch(ctx context.Context, now time.Time) (int, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DispatchOutboxService_Dispatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Dispatch'
+type DispatchOutboxService_Dispatch_Call struct {
+	*mock.Call
+}
+
+// Dispatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *DispatchOutboxService_Expecter) Dispatch(ctx interface{}, now interface{}) *DispatchOutboxService_Dispatch_Call {
+	return &DispatchOutboxService_Dispatch_Call{Call: _e.mock.On("Dispatch", ctx, now)}
+}
+
+func (_c *DispatchOutboxService_Dispatch_Call) Run(run func(ctx context.Context, now time.Time)) *DispatchOutboxService_Dispatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *DispatchOutboxService_Dispatch_Call) Return(_a0 int, _a1 error) *DispatchOutboxService_Dispatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DispatchOutboxService_Dispatch_Call) RunAndReturn(run func(context.Context, time.Time) (int, error)) *DispatchOutboxService_Dispatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDispatchOutboxService creates a new instance of DispatchOutboxService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDispatchOutboxService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DispatchOutboxService {
+	mock := &DispatchOutboxService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// GetPollResultsService is an autogenerated mock type for the GetPollResultsService type
+type GetPollResultsService struct {
+	mock.Mock
+}
+
+type GetPollResultsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *GetPollResultsService) EXPECT() *GetPollResultsService_Expecter {
+	return &GetPollResultsService_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: ctx, pollID, now
+func (_m *GetPollResultsService) Get(ctx context.Context, pollID uuid.UUID, now time.Time) (*models.PollResults, error) {
+	ret := _m.Called(ctx, pollID, now)
+
+	var r0 *models.PollResults
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*models.PollResults, error)); ok {
+		return rf(ctx, pollID, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *models.PollResults); ok {
+		r0 = rf(ctx, pollID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PollResults)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, pollID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPollResultsService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type GetPollResultsService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pollID uuid.UUID
+//   - now time.Time
+func (_e *GetPollResultsService_Expecter) Get(ctx interface{}, pollID interface{}, now interface{}) *GetPollResultsService_Get_Call {
+	return &GetPollResultsService_Get_Call{Call: _e.mock.On("Get", ctx, pollID, now)}
+}
+
+func (_c *GetPollResultsService_Get_Call) Run(run func(ctx context.Context, pollID uuid.UUID, now time.Time)) *GetPollResultsService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *GetPollResultsService_Get_Call) Return(_a0 *models.PollResults, _a1 error) *GetPollResultsService_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GetPollResultsService_Get_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (*models.PollResults, error)) *GetPollResultsService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewGetPollResultsService creates a new instance of GetPollResultsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGetPollResultsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GetPollResultsService {
+	mock := &GetPollResultsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
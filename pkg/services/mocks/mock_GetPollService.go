@@ -0,0 +1,94 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// GetPollService is an autogenerated mock type for the GetPollService type
+type GetPollService struct {
+	mock.Mock
+}
+
+type GetPollService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *GetPollService) EXPECT() *GetPollService_Expecter {
+	return &GetPollService_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *GetPollService) Get(ctx context.Context, id uuid.UUID) (*models.Poll, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Poll
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Poll, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Poll); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Poll)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPollService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type GetPollService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *GetPollService_Expecter) Get(ctx interface{}, id interface{}) *GetPollService_Get_Call {
+	return &GetPollService_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *GetPollService_Get_Call) Run(run func(ctx context.Context, id uuid.UUID)) *GetPollService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *GetPollService_Get_Call) Return(_a0 *models.Poll, _a1 error) *GetPollService_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GetPollService_Get_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Poll, error)) *GetPollService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewGetPollService creates a new instance of GetPollService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGetPollService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GetPollService {
+	mock := &GetPollService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
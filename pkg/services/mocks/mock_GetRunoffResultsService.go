@@ -0,0 +1,97 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// GetRunoffResultsService is an autogenerated mock type for the GetRunoffResultsService type
+type GetRunoffResultsService struct {
+	mock.Mock
+}
+
+type GetRunoffResultsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *GetRunoffResultsService) EXPECT() *GetRunoffResultsService_Expecter {
+	return &GetRunoffResultsService_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: ctx, pollID, now
+func (_m *GetRunoffResultsService) Get(ctx context.Context, pollID uuid.UUID, now time.Time) (*models.RunoffResults, error) {
+	ret := _m.Called(ctx, pollID, now)
+
+	var r0 *models.RunoffResults
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*models.RunoffResults, error)); ok {
+		return rf(ctx, pollID, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *models.RunoffResults); ok {
+		r0 = rf(ctx, pollID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RunoffResults)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, pollID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRunoffResultsService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type GetRunoffResultsService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pollID uuid.UUID
+//   - now time.Time
+func (_e *GetRunoffResultsService_Expecter) Get(ctx interface{}, pollID interface{}, now interface{}) *GetRunoffResultsService_Get_Call {
+	return &GetRunoffResultsService_Get_Call{Call: _e.mock.On("Get", ctx, pollID, now)}
+}
+
+func (_c *GetRunoffResultsService_Get_Call) Run(run func(ctx context.Context, pollID uuid.UUID, now time.Time)) *GetRunoffResultsService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *GetRunoffResultsService_Get_Call) Return(_a0 *models.RunoffResults, _a1 error) *GetRunoffResultsService_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GetRunoffResultsService_Get_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (*models.RunoffResults, error)) *GetRunoffResultsService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewGetRunoffResultsService creates a new instance of GetRunoffResultsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGetRunoffResultsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GetRunoffResultsService {
+	mock := &GetRunoffResultsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
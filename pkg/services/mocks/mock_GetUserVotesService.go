@@ -0,0 +1,96 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// GetUserVotesService is an autogenerated mock type for the GetUserVotesService type
+type GetUserVotesService struct {
+	mock.Mock
+}
+
+type GetUserVotesService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *GetUserVotesService) EXPECT() *GetUserVotesService_Expecter {
+	return &GetUserVotesService_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: ctx, tokenRaw, target, targetIDs
+func (_m *GetUserVotesService) Get(ctx context.Context, tokenRaw string, target string, targetIDs []uuid.UUID) (map[uuid.UUID]*models.Vote, error) {
+	ret := _m.Called(ctx, tokenRaw, target, targetIDs)
+
+	var r0 map[uuid.UUID]*models.Vote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []uuid.UUID) (map[uuid.UUID]*models.Vote, error)); ok {
+		return rf(ctx, tokenRaw, target, targetIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []uuid.UUID) map[uuid.UUID]*models.Vote); ok {
+		r0 = rf(ctx, tokenRaw, target, targetIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID]*models.Vote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []uuid.UUID) error); ok {
+		r1 = rf(ctx, tokenRaw, target, targetIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserVotesService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type GetUserVotesService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - target string
+//   - targetIDs []uuid.UUID
+func (_e *GetUserVotesService_Expecter) Get(ctx interface{}, tokenRaw interface{}, target interface{}, targetIDs interface{}) *GetUserVotesService_Get_Call {
+	return &GetUserVotesService_Get_Call{Call: _e.mock.On("Get", ctx, tokenRaw, target, targetIDs)}
+}
+
+func (_c *GetUserVotesService_Get_Call) Run(run func(ctx context.Context, tokenRaw string, target string, targetIDs []uuid.UUID)) *GetUserVotesService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *GetUserVotesService_Get_Call) Return(_a0 map[uuid.UUID]*models.Vote, _a1 error) *GetUserVotesService_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GetUserVotesService_Get_Call) RunAndReturn(run func(context.Context, string, string, []uuid.UUID) (map[uuid.UUID]*models.Vote, error)) *GetUserVotesService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewGetUserVotesService creates a new instance of GetUserVotesService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGetUserVotesService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GetUserVotesService {
+	mock := &GetUserVotesService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// GetVotesSummariesService is an autogenerated mock type for the GetVotesSummariesService type
+type GetVotesSummariesService struct {
+	mock.Mock
+}
+
+type GetVotesSummariesService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *GetVotesSummariesService) EXPECT() *GetVotesSummariesService_Expecter {
+	return &GetVotesSummariesService_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: ctx, target, targetIDs
+func (_m *GetVotesSummariesService) Get(ctx context.Context, target string, targetIDs []uuid.UUID) (map[uuid.UUID]*models.VotesSummary, error) {
+	ret := _m.Called(ctx, target, targetIDs)
+
+	var r0 map[uuid.UUID]*models.VotesSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []uuid.UUID) (map[uuid.UUID]*models.VotesSummary, error)); ok {
+		return rf(ctx, target, targetIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []uuid.UUID) map[uuid.UUID]*models.VotesSummary); ok {
+		r0 = rf(ctx, target, targetIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID]*models.VotesSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []uuid.UUID) error); ok {
+		r1 = rf(ctx, target, targetIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVotesSummariesService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type GetVotesSummariesService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - target string
+//   - targetIDs []uuid.UUID
+func (_e *GetVotesSummariesService_Expecter) Get(ctx interface{}, target interface{}, targetIDs interface{}) *GetVotesSummariesService_Get_Call {
+	return &GetVotesSummariesService_Get_Call{Call: _e.mock.On("Get", ctx, target, targetIDs)}
+}
+
+func (_c *GetVotesSummariesService_Get_Call) Run(run func(ctx context.Context, target string, targetIDs []uuid.UUID)) *GetVotesSummariesService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *GetVotesSummariesService_Get_Call) Return(_a0 map[uuid.UUID]*models.VotesSummary, _a1 error) *GetVotesSummariesService_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GetVotesSummariesService_Get_Call) RunAndReturn(run func(context.Context, string, []uuid.UUID) (map[uuid.UUID]*models.VotesSummary, error)) *GetVotesSummariesService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewGetVotesSummariesService creates a new instance of GetVotesSummariesService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGetVotesSummariesService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GetVotesSummariesService {
+	mock := &GetVotesSummariesService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
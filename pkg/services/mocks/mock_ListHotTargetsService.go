@@ -0,0 +1,95 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ListHotTargetsService is an autogenerated mock type for the ListHotTargetsService type
+type ListHotTargetsService struct {
+	mock.Mock
+}
+
+type ListHotTargetsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ListHotTargetsService) EXPECT() *ListHotTargetsService_Expecter {
+	return &ListHotTargetsService_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function with given fields: ctx, query, now
+func (_m *ListHotTargetsService) List(ctx context.Context, query *models.ListHotTargetsQuery, now time.Time) ([]*models.RankedTarget, error) {
+	ret := _m.Called(ctx, query, now)
+
+	var r0 []*models.RankedTarget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ListHotTargetsQuery, time.Time) ([]*models.RankedTarget, error)); ok {
+		return rf(ctx, query, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ListHotTargetsQuery, time.Time) []*models.RankedTarget); ok {
+		r0 = rf(ctx, query, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.RankedTarget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.ListHotTargetsQuery, time.Time) error); ok {
+		r1 = rf(ctx, query, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListHotTargetsService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type ListHotTargetsService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *models.ListHotTargetsQuery
+//   - now time.Time
+func (_e *ListHotTargetsService_Expecter) List(ctx interface{}, query interface{}, now interface{}) *ListHotTargetsService_List_Call {
+	return &ListHotTargetsService_List_Call{Call: _e.mock.On("List", ctx, query, now)}
+}
+
+func (_c *ListHotTargetsService_List_Call) Run(run func(ctx context.Context, query *models.ListHotTargetsQuery, now time.Time)) *ListHotTargetsService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ListHotTargetsQuery), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ListHotTargetsService_List_Call) Return(_a0 []*models.RankedTarget, _a1 error) *ListHotTargetsService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ListHotTargetsService_List_Call) RunAndReturn(run func(context.Context, *models.ListHotTargetsQuery, time.Time) ([]*models.RankedTarget, error)) *ListHotTargetsService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewListHotTargetsService creates a new instance of ListHotTargetsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewListHotTargetsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ListHotTargetsService {
+	mock := &ListHotTargetsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ListTargetVotesService is an autogenerated mock type for the ListTargetVotesService type
+type ListTargetVotesService struct {
+	mock.Mock
+}
+
+type ListTargetVotesService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ListTargetVotesService) EXPECT() *ListTargetVotesService_Expecter {
+	return &ListTargetVotesService_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function with given fields: ctx, tokenRaw, query
+func (_m *ListTargetVotesService) List(ctx context.Context, tokenRaw string, query *models.ListTargetVotesQuery) (*models.VotesPage, error) {
+	ret := _m.Called(ctx, tokenRaw, query)
+
+	var r0 *models.VotesPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ListTargetVotesQuery) (*models.VotesPage, error)); ok {
+		return rf(ctx, tokenRaw, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ListTargetVotesQuery) *models.VotesPage); ok {
+		r0 = rf(ctx, tokenRaw, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.VotesPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.ListTargetVotesQuery) error); ok {
+		r1 = rf(ctx, tokenRaw, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTargetVotesService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type ListTargetVotesService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - query *models.ListTargetVotesQuery
+func (_e *ListTargetVotesService_Expecter) List(ctx interface{}, tokenRaw interface{}, query interface{}) *ListTargetVotesService_List_Call {
+	return &ListTargetVotesService_List_Call{Call: _e.mock.On("List", ctx, tokenRaw, query)}
+}
+
+func (_c *ListTargetVotesService_List_Call) Run(run func(ctx context.Context, tokenRaw string, query *models.ListTargetVotesQuery)) *ListTargetVotesService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.ListTargetVotesQuery))
+	})
+	return _c
+}
+
+func (_c *ListTargetVotesService_List_Call) Return(_a0 *models.VotesPage, _a1 error) *ListTargetVotesService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ListTargetVotesService_List_Call) RunAndReturn(run func(context.Context, string, *models.ListTargetVotesQuery) (*models.VotesPage, error)) *ListTargetVotesService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewListTargetVotesService creates a new instance of ListTargetVotesService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewListTargetVotesService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ListTargetVotesService {
+	mock := &ListTargetVotesService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
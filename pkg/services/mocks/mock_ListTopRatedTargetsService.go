@@ -0,0 +1,92 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ListTopRatedTargetsService is an autogenerated mock type for the ListTopRatedTargetsService type
+type ListTopRatedTargetsService struct {
+	mock.Mock
+}
+
+type ListTopRatedTargetsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ListTopRatedTargetsService) EXPECT() *ListTopRatedTargetsService_Expecter {
+	return &ListTopRatedTargetsService_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function with given fields: ctx, query
+func (_m *ListTopRatedTargetsService) List(ctx context.Context, query *models.ListTopRatedTargetsQuery) ([]*models.RankedTarget, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*models.RankedTarget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ListTopRatedTargetsQuery) ([]*models.RankedTarget, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ListTopRatedTargetsQuery) []*models.RankedTarget); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.RankedTarget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.ListTopRatedTargetsQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTopRatedTargetsService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type ListTopRatedTargetsService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query *models.ListTopRatedTargetsQuery
+func (_e *ListTopRatedTargetsService_Expecter) List(ctx interface{}, query interface{}) *ListTopRatedTargetsService_List_Call {
+	return &ListTopRatedTargetsService_List_Call{Call: _e.mock.On("List", ctx, query)}
+}
+
+func (_c *ListTopRatedTargetsService_List_Call) Run(run func(ctx context.Context, query *models.ListTopRatedTargetsQuery)) *ListTopRatedTargetsService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ListTopRatedTargetsQuery))
+	})
+	return _c
+}
+
+func (_c *ListTopRatedTargetsService_List_Call) Return(_a0 []*models.RankedTarget, _a1 error) *ListTopRatedTargetsService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ListTopRatedTargetsService_List_Call) RunAndReturn(run func(context.Context, *models.ListTopRatedTargetsQuery) ([]*models.RankedTarget, error)) *ListTopRatedTargetsService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewListTopRatedTargetsService creates a new instance of ListTopRatedTargetsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewListTopRatedTargetsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ListTopRatedTargetsService {
+	mock := &ListTopRatedTargetsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
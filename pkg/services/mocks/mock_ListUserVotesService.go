@@ -23,19 +23,19 @@ func (_m *ListUserVotesService) EXPECT() *ListUserVotesService_Expecter {
 }
 
 // List provides a mock function with given fields: ctx, tokenRaw, query
-func (_m *ListUserVotesService) List(ctx context.Context, tokenRaw string, query *models.ListUserVotesQuery) ([]*models.Vote, error) {
+func (_m *ListUserVotesService) List(ctx context.Context, tokenRaw string, query *models.ListUserVotesQuery) (*models.VotesPage, error) {
 	ret := _m.Called(ctx, tokenRaw, query)
 
-	var r0 []*models.Vote
+	var r0 *models.VotesPage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ListUserVotesQuery) ([]*models.Vote, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ListUserVotesQuery) (*models.VotesPage, error)); ok {
 		return rf(ctx, tokenRaw, query)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ListUserVotesQuery) []*models.Vote); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ListUserVotesQuery) *models.VotesPage); ok {
 		r0 = rf(ctx, tokenRaw, query)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Vote)
+			r0 = ret.Get(0).(*models.VotesPage)
 		}
 	}
 
@@ -68,12 +68,12 @@ func (_c *ListUserVotesService_List_Call) Run(run func(ctx context.Context, toke
 	return _c
 }
 
-func (_c *ListUserVotesService_List_Call) Return(_a0 []*models.Vote, _a1 error) *ListUserVotesService_List_Call {
+func (_c *ListUserVotesService_List_Call) Return(_a0 *models.VotesPage, _a1 error) *ListUserVotesService_List_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ListUserVotesService_List_Call) RunAndReturn(run func(context.Context, string, *models.ListUserVotesQuery) ([]*models.Vote, error)) *ListUserVotesService_List_Call {
+func (_c *ListUserVotesService_List_Call) RunAndReturn(run func(context.Context, string, *models.ListUserVotesQuery) (*models.VotesPage, error)) *ListUserVotesService_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ListVoteEventsService is an autogenerated mock type for the ListVoteEventsService type
+type ListVoteEventsService struct {
+	mock.Mock
+}
+
+type ListVoteEventsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ListVoteEventsService) EXPECT() *ListVoteEventsService_Expecter {
+	return &ListVoteEventsService_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function with given fields: ctx, tokenRaw, query
+func (_m *ListVoteEventsService) List(ctx context.Context, tokenRaw string, query *models.ListVoteEventsQuery) ([]*models.VoteEvent, error) {
+	ret := _m.Called(ctx, tokenRaw, query)
+
+	var r0 []*models.VoteEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ListVoteEventsQuery) ([]*models.VoteEvent, error)); ok {
+		return rf(ctx, tokenRaw, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ListVoteEventsQuery) []*models.VoteEvent); ok {
+		r0 = rf(ctx, tokenRaw, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.VoteEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.ListVoteEventsQuery) error); ok {
+		r1 = rf(ctx, tokenRaw, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListVoteEventsService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type ListVoteEventsService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - query *models.ListVoteEventsQuery
+func (_e *ListVoteEventsService_Expecter) List(ctx interface{}, tokenRaw interface{}, query interface{}) *ListVoteEventsService_List_Call {
+	return &ListVoteEventsService_List_Call{Call: _e.mock.On("List", ctx, tokenRaw, query)}
+}
+
+func (_c *ListVoteEventsService_List_Call) Run(run func(ctx context.Context, tokenRaw string, query *models.ListVoteEventsQuery)) *ListVoteEventsService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.ListVoteEventsQuery))
+	})
+	return _c
+}
+
+func (_c *ListVoteEventsService_List_Call) Return(_a0 []*models.VoteEvent, _a1 error) *ListVoteEventsService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ListVoteEventsService_List_Call) RunAndReturn(run func(context.Context, string, *models.ListVoteEventsQuery) ([]*models.VoteEvent, error)) *ListVoteEventsService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewListVoteEventsService creates a new instance of ListVoteEventsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewListVoteEventsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ListVoteEventsService {
+	mock := &ListVoteEventsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ListVoteFlagsService is an autogenerated mock type for the ListVoteFlagsService type
+type ListVoteFlagsService struct {
+	mock.Mock
+}
+
+type ListVoteFlagsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ListVoteFlagsService) EXPECT() *ListVoteFlagsService_Expecter {
+	return &ListVoteFlagsService_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function with given fields: ctx, tokenRaw, query
+func (_m *ListVoteFlagsService) List(ctx context.Context, tokenRaw string, query *models.ListVoteFlagsQuery) ([]*models.VoteFlag, error) {
+	ret := _m.Called(ctx, tokenRaw, query)
+
+	var r0 []*models.VoteFlag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ListVoteFlagsQuery) ([]*models.VoteFlag, error)); ok {
+		return rf(ctx, tokenRaw, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.ListVoteFlagsQuery) []*models.VoteFlag); ok {
+		r0 = rf(ctx, tokenRaw, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.VoteFlag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.ListVoteFlagsQuery) error); ok {
+		r1 = rf(ctx, tokenRaw, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListVoteFlagsService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type ListVoteFlagsService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - query *models.ListVoteFlagsQuery
+func (_e *ListVoteFlagsService_Expecter) List(ctx interface{}, tokenRaw interface{}, query interface{}) *ListVoteFlagsService_List_Call {
+	return &ListVoteFlagsService_List_Call{Call: _e.mock.On("List", ctx, tokenRaw, query)}
+}
+
+func (_c *ListVoteFlagsService_List_Call) Run(run func(ctx context.Context, tokenRaw string, query *models.ListVoteFlagsQuery)) *ListVoteFlagsService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.ListVoteFlagsQuery))
+	})
+	return _c
+}
+
+func (_c *ListVoteFlagsService_List_Call) Return(_a0 []*models.VoteFlag, _a1 error) *ListVoteFlagsService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ListVoteFlagsService_List_Call) RunAndReturn(run func(context.Context, string, *models.ListVoteFlagsQuery) ([]*models.VoteFlag, error)) *ListVoteFlagsService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewListVoteFlagsService creates a new instance of ListVoteFlagsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewListVoteFlagsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ListVoteFlagsService {
+	mock := &ListVoteFlagsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// LockTargetService is an autogenerated mock type for the LockTargetService type
+type LockTargetService struct {
+	mock.Mock
+}
+
+type LockTargetService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LockTargetService) EXPECT() *LockTargetService_Expecter {
+	return &LockTargetService_Expecter{mock: &_m.Mock}
+}
+
+// Lock provides a mock function with given fields: ctx, tokenRaw, form, now
+func (_m *LockTargetService) Lock(ctx context.Context, tokenRaw string, form models.LockTargetForm, now time.Time) (*models.TargetLock, error) {
+	ret := _m.Called(ctx, tokenRaw, form, now)
+
+	var r0 *models.TargetLock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.LockTargetForm, time.Time) (*models.TargetLock, error)); ok {
+		return rf(ctx, tokenRaw, form, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.LockTargetForm, time.Time) *models.TargetLock); ok {
+		r0 = rf(ctx, tokenRaw, form, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TargetLock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.LockTargetForm, time.Time) error); ok {
+		r1 = rf(ctx, tokenRaw, form, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockTargetService_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type LockTargetService_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - form models.LockTargetForm
+//   - now time.Time
+func (_e *LockTargetService_Expecter) Lock(ctx interface{}, tokenRaw interface{}, form interface{}, now interface{}) *LockTargetService_Lock_Call {
+	return &LockTargetService_Lock_Call{Call: _e.mock.On("Lock", ctx, tokenRaw, form, now)}
+}
+
+func (_c *LockTargetService_Lock_Call) Run(run func(ctx context.Context, tokenRaw string, form models.LockTargetForm, now time.Time)) *LockTargetService_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.LockTargetForm), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LockTargetService_Lock_Call) Return(_a0 *models.TargetLock, _a1 error) *LockTargetService_Lock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LockTargetService_Lock_Call) RunAndReturn(run func(context.Context, string, models.LockTargetForm, time.Time) (*models.TargetLock, error)) *LockTargetService_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLockTargetService creates a new instance of LockTargetService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLockTargetService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LockTargetService {
+	mock := &LockTargetService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// PurgeIdempotencyKeysService is an autogenerated mock type for the PurgeIdempotencyKeysService type
+type PurgeIdempotencyKeysService struct {
+	mock.Mock
+}
+
+type PurgeIdempotencyKeysService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PurgeIdempotencyKeysService) EXPECT() *PurgeIdempotencyKeysService_Expecter {
+	return &PurgeIdempotencyKeysService_Expecter{mock: &_m.Mock}
+}
+
+// Purge provides a mock function with given fields: ctx, now
+func (_m *PurgeIdempotencyKeysService) Purge(ctx context.Context, now time.Time) (int, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeIdempotencyKeysService_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type PurgeIdempotencyKeysService_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *PurgeIdempotencyKeysService_Expecter) Purge(ctx interface{}, now interface{}) *PurgeIdempotencyKeysService_Purge_Call {
+	return &PurgeIdempotencyKeysService_Purge_Call{Call: _e.mock.On("Purge", ctx, now)}
+}
+
+func (_c *PurgeIdempotencyKeysService_Purge_Call) Run(run func(ctx context.Context, now time.Time)) *PurgeIdempotencyKeysService_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PurgeIdempotencyKeysService_Purge_Call) Return(_a0 int, _a1 error) *PurgeIdempotencyKeysService_Purge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PurgeIdempotencyKeysService_Purge_Call) RunAndReturn(run func(context.Context, time.Time) (int, error)) *PurgeIdempotencyKeysService_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPurgeIdempotencyKeysService creates a new instance of PurgeIdempotencyKeysService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPurgeIdempotencyKeysService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PurgeIdempotencyKeysService {
+	mock := &PurgeIdempotencyKeysService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
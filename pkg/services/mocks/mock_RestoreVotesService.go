@@ -0,0 +1,94 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RestoreVotesService is an autogenerated mock type for the RestoreVotesService type
+type RestoreVotesService struct {
+	mock.Mock
+}
+
+type RestoreVotesService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RestoreVotesService) EXPECT() *RestoreVotesService_Expecter {
+	return &RestoreVotesService_Expecter{mock: &_m.Mock}
+}
+
+// Restore provides a mock function with given fields: ctx, tokenRaw, form, now
+func (_m *RestoreVotesService) Restore(ctx context.Context, tokenRaw string, form models.ModerateVotesForm, now time.Time) (int, error) {
+	ret := _m.Called(ctx, tokenRaw, form, now)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ModerateVotesForm, time.Time) (int, error)); ok {
+		return rf(ctx, tokenRaw, form, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ModerateVotesForm, time.Time) int); ok {
+		r0 = rf(ctx, tokenRaw, form, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ModerateVotesForm, time.Time) error); ok {
+		r1 = rf(ctx, tokenRaw, form, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreVotesService_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type RestoreVotesService_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - form models.ModerateVotesForm
+//   - now time.Time
+func (_e *RestoreVotesService_Expecter) Restore(ctx interface{}, tokenRaw interface{}, form interface{}, now interface{}) *RestoreVotesService_Restore_Call {
+	return &RestoreVotesService_Restore_Call{Call: _e.mock.On("Restore", ctx, tokenRaw, form, now)}
+}
+
+func (_c *RestoreVotesService_Restore_Call) Run(run func(ctx context.Context, tokenRaw string, form models.ModerateVotesForm, now time.Time)) *RestoreVotesService_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.ModerateVotesForm), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RestoreVotesService_Restore_Call) Return(_a0 int, _a1 error) *RestoreVotesService_Restore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RestoreVotesService_Restore_Call) RunAndReturn(run func(context.Context, string, models.ModerateVotesForm, time.Time) (int, error)) *RestoreVotesService_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRestoreVotesService creates a new instance of RestoreVotesService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRestoreVotesService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RestoreVotesService {
+	mock := &RestoreVotesService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
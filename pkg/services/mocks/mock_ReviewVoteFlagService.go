@@ -0,0 +1,96 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ReviewVoteFlagService is an autogenerated mock type for the ReviewVoteFlagService type
+type ReviewVoteFlagService struct {
+	mock.Mock
+}
+
+type ReviewVoteFlagService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReviewVoteFlagService) EXPECT() *ReviewVoteFlagService_Expecter {
+	return &ReviewVoteFlagService_Expecter{mock: &_m.Mock}
+}
+
+// Review provides a mock function with given fields: ctx, tokenRaw, form, now
+func (_m *ReviewVoteFlagService) Review(ctx context.Context, tokenRaw string, form models.ReviewVoteFlagForm, now time.Time) (*models.VoteFlag, error) {
+	ret := _m.Called(ctx, tokenRaw, form, now)
+
+	var r0 *models.VoteFlag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ReviewVoteFlagForm, time.Time) (*models.VoteFlag, error)); ok {
+		return rf(ctx, tokenRaw, form, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ReviewVoteFlagForm, time.Time) *models.VoteFlag); ok {
+		r0 = rf(ctx, tokenRaw, form, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.VoteFlag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ReviewVoteFlagForm, time.Time) error); ok {
+		r1 = rf(ctx, tokenRaw, form, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewVoteFlagService_Review_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Review'
+type ReviewVoteFlagService_Review_Call struct {
+	*mock.Call
+}
+
+// Review is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - form models.ReviewVoteFlagForm
+//   - now time.Time
+func (_e *ReviewVoteFlagService_Expecter) Review(ctx interface{}, tokenRaw interface{}, form interface{}, now interface{}) *ReviewVoteFlagService_Review_Call {
+	return &ReviewVoteFlagService_Review_Call{Call: _e.mock.On("Review", ctx, tokenRaw, form, now)}
+}
+
+func (_c *ReviewVoteFlagService_Review_Call) Run(run func(ctx context.Context, tokenRaw string, form models.ReviewVoteFlagForm, now time.Time)) *ReviewVoteFlagService_Review_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.ReviewVoteFlagForm), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ReviewVoteFlagService_Review_Call) Return(_a0 *models.VoteFlag, _a1 error) *ReviewVoteFlagService_Review_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReviewVoteFlagService_Review_Call) RunAndReturn(run func(context.Context, string, models.ReviewVoteFlagForm, time.Time) (*models.VoteFlag, error)) *ReviewVoteFlagService_Review_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReviewVoteFlagService creates a new instance of ReviewVoteFlagService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReviewVoteFlagService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReviewVoteFlagService {
+	mock := &ReviewVoteFlagService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
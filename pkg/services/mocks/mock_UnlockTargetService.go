@@ -0,0 +1,81 @@
+// Code generated by mockery v2.33.2. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	models "github.com/a-novel/votes-service/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UnlockTargetService is an autogenerated mock type for the UnlockTargetService type
+type UnlockTargetService struct {
+	mock.Mock
+}
+
+type UnlockTargetService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UnlockTargetService) EXPECT() *UnlockTargetService_Expecter {
+	return &UnlockTargetService_Expecter{mock: &_m.Mock}
+}
+
+// Unlock provides a mock function with given fields: ctx, tokenRaw, form
+func (_m *UnlockTargetService) Unlock(ctx context.Context, tokenRaw string, form models.UnlockTargetForm) error {
+	ret := _m.Called(ctx, tokenRaw, form)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UnlockTargetForm) error); ok {
+		r0 = rf(ctx, tokenRaw, form)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlockTargetService_Unlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unlock'
+type UnlockTargetService_Unlock_Call struct {
+	*mock.Call
+}
+
+// Unlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenRaw string
+//   - form models.UnlockTargetForm
+func (_e *UnlockTargetService_Expecter) Unlock(ctx interface{}, tokenRaw interface{}, form interface{}) *UnlockTargetService_Unlock_Call {
+	return &UnlockTargetService_Unlock_Call{Call: _e.mock.On("Unlock", ctx, tokenRaw, form)}
+}
+
+func (_c *UnlockTargetService_Unlock_Call) Run(run func(ctx context.Context, tokenRaw string, form models.UnlockTargetForm)) *UnlockTargetService_Unlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.UnlockTargetForm))
+	})
+	return _c
+}
+
+func (_c *UnlockTargetService_Unlock_Call) Return(_a0 error) *UnlockTargetService_Unlock_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UnlockTargetService_Unlock_Call) RunAndReturn(run func(context.Context, string, models.UnlockTargetForm) error) *UnlockTargetService_Unlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUnlockTargetService creates a new instance of UnlockTargetService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUnlockTargetService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UnlockTargetService {
+	mock := &UnlockTargetService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
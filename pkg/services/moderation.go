@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"time"
+)
+
+// moderatedVotesFilter validates the votes selected by a moderation action. Acting on every vote of a target kind at
+// once is not supported, so a user or a target ID is required.
+func moderatedVotesFilter(targets map[string]*models.Target, form models.ModerateVotesForm) (dao.ModeratedVotesFilter, error) {
+	if form.Target != "" && targets[form.Target] == nil {
+		return dao.ModeratedVotesFilter{}, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidTarget)
+	}
+	// Target IDs are only unique within a target.
+	if form.TargetID != nil && form.Target == "" {
+		return dao.ModeratedVotesFilter{}, goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidTarget)
+	}
+	if form.UserID == nil && form.TargetID == nil {
+		return dao.ModeratedVotesFilter{}, goerrors.Join(goframework.ErrInvalidEntity, ErrMissingFilter)
+	}
+
+	return dao.ModeratedVotesFilter{UserID: form.UserID, TargetID: form.TargetID, Target: form.Target}, nil
+}
+
+// republishSummaries queues the corrected counters of every target the votes belong to, so their owners are notified
+// through the outbox. The moderator is reported as the author of the update.
+func republishSummaries(ctx context.Context, repository dao.VotesRepository, votes []*dao.VoteModel, moderatorID uuid.UUID, now time.Time) error {
+	targetIDs := make(map[string][]uuid.UUID)
+	targetNames := make([]string, 0)
+
+	for _, vote := range votes {
+		if _, ok := targetIDs[vote.Target]; !ok {
+			targetNames = append(targetNames, vote.Target)
+		}
+
+		targetIDs[vote.Target] = append(targetIDs[vote.Target], vote.TargetID)
+	}
+
+	for _, target := range targetNames {
+		summaries, err := repository.GetSummaries(ctx, target, lo.Uniq(targetIDs[target]))
+		if err != nil {
+			return goerrors.Join(ErrGetVotesSummaries, err)
+		}
+
+		for _, summary := range summaries {
+			if err = repository.QueueSummaryUpdate(ctx, moderatorID, summary, now); err != nil {
+				return goerrors.Join(ErrQueueSummaryUpdate, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	apiclients "github.com/a-novel/go-apis/clients"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/google/uuid"
+)
+
+// checkUserScope introspects the token, and makes sure its owner was granted at least one of the scopes. It returns
+// the ID of the user on success.
+func checkUserScope(
+	ctx context.Context,
+	authClient apiclients.AuthClient,
+	permissionsClient apiclients.PermissionsClient,
+	tokenRaw string,
+	scopes ...apiclients.Scope,
+) (uuid.UUID, error) {
+	userID, err := introspectUser(ctx, authClient, tokenRaw)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := checkScopes(ctx, permissionsClient, userID, scopes...); err != nil {
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
+
+// introspectUser returns the ID of the user a token belongs to.
+func introspectUser(ctx context.Context, authClient apiclients.AuthClient, tokenRaw string) (uuid.UUID, error) {
+	token, err := authClient.IntrospectToken(ctx, tokenRaw)
+	if err != nil {
+		return uuid.Nil, goerrors.Join(ErrIntrospectToken, err)
+	}
+	if !token.OK {
+		return uuid.Nil, goerrors.Join(goframework.ErrInvalidCredentials, ErrInvalidToken)
+	}
+
+	return token.Token.Payload.ID, nil
+}
+
+// checkScopes makes sure the user was granted at least one of the scopes.
+func checkScopes(ctx context.Context, permissionsClient apiclients.PermissionsClient, userID uuid.UUID, scopes ...apiclients.Scope) error {
+	errs := []error{ErrCheckPermissions}
+	for _, scope := range scopes {
+		err := permissionsClient.HasUserScope(ctx, apiclients.HasUserScopeQuery{
+			UserID: userID,
+			Scope:  scope,
+		})
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, err)
+	}
+
+	return goerrors.Join(errs...)
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"time"
+)
+
+type PurgeIdempotencyKeysService interface {
+	// Purge deletes the expired idempotency keys, and returns how many were deleted.
+	Purge(ctx context.Context, now time.Time) (int, error)
+}
+
+func NewPurgeIdempotencyKeysService(repository dao.VotesRepository) PurgeIdempotencyKeysService {
+	return &purgeIdempotencyKeysServiceImpl{
+		repository: repository,
+	}
+}
+
+type purgeIdempotencyKeysServiceImpl struct {
+	repository dao.VotesRepository
+}
+
+func (s *purgeIdempotencyKeysServiceImpl) Purge(ctx context.Context, now time.Time) (int, error) {
+	deleted, err := s.repository.PurgeIdempotencyKeys(ctx, now)
+	if err != nil {
+		return 0, goerrors.Join(ErrPurgeIdempotency, err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,48 @@
+package services_test
+
+import (
+	"context"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPurgeIdempotencyKeysService(t *testing.T) {
+	data := []struct {
+		name string
+
+		daoResp int
+		daoErr  error
+
+		expect    int
+		expectErr error
+	}{
+		{
+			name:    "Success",
+			daoResp: 3,
+			expect:  3,
+		},
+		{
+			name:      "Error/DAOFailure",
+			daoErr:    fooErr,
+			expectErr: fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewVotesRepository(t)
+
+			repository.On("PurgeIdempotencyKeys", context.Background(), baseTime).Return(d.daoResp, d.daoErr)
+
+			service := services.NewPurgeIdempotencyKeysService(repository)
+			res, err := service.Purge(context.Background(), baseTime)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	apiclients "github.com/a-novel/go-apis/clients"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"time"
+)
+
+type RestoreVotesService interface {
+	// Restore counts the selected invalidated votes in the summaries again, and returns the number of votes restored.
+	// The owners of the affected targets receive the corrected counters.
+	Restore(ctx context.Context, tokenRaw string, form models.ModerateVotesForm, now time.Time) (int, error)
+}
+
+func NewRestoreVotesService(
+	repository dao.VotesRepository,
+	authClient apiclients.AuthClient,
+	permissionsClient apiclients.PermissionsClient,
+	targets map[string]*models.Target,
+	moderationScope apiclients.Scope,
+) RestoreVotesService {
+	return &restoreVotesServiceImpl{
+		repository:        repository,
+		authClient:        authClient,
+		permissionsClient: permissionsClient,
+		targets:           targets,
+		moderationScope:   moderationScope,
+	}
+}
+
+type restoreVotesServiceImpl struct {
+	repository        dao.VotesRepository
+	authClient        apiclients.AuthClient
+	permissionsClient apiclients.PermissionsClient
+
+	targets         map[string]*models.Target
+	moderationScope apiclients.Scope
+}
+
+func (s *restoreVotesServiceImpl) Restore(ctx context.Context, tokenRaw string, form models.ModerateVotesForm, now time.Time) (int, error) {
+	moderatorID, err := checkUserScope(ctx, s.authClient, s.permissionsClient, tokenRaw, s.moderationScope)
+	if err != nil {
+		return 0, err
+	}
+
+	filter, err := moderatedVotesFilter(s.targets, form)
+	if err != nil {
+		return 0, err
+	}
+
+	var restored int
+
+	err = s.repository.RunInTx(ctx, func(ctx context.Context, txRepository dao.VotesRepository) error {
+		votes, err := txRepository.Restore(ctx, filter)
+		if err != nil {
+			return goerrors.Join(ErrRestoreVotes, err)
+		}
+
+		restored = len(votes)
+
+		return republishSummaries(ctx, txRepository, votes, moderatorID, now)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return restored, nil
+}
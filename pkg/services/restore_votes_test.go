@@ -0,0 +1,234 @@
+package services_test
+
+import (
+	"context"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRestoreVotesService(t *testing.T) {
+	moderationScope := apiclients.Scope("can_moderate_votes")
+
+	targets := map[string]*models.Target{
+		"target": {Name: "target", Open: true},
+	}
+
+	moderatorToken := &apiclients.UserTokenStatus{
+		OK: true,
+		Token: &apiclients.UserToken{
+			Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		form     models.ModerateVotesForm
+
+		authClientResp *apiclients.UserTokenStatus
+		authClientErr  error
+
+		shouldCallPermissions bool
+		permissionsErr        error
+
+		shouldCallDAO     bool
+		shouldCallDAOWith dao.ModeratedVotesFilter
+		daoResp           []*dao.VoteModel
+		daoErr            error
+
+		getSummaries []getSummariesCall
+		queue        []*dao.VotesSummaryModel
+		queueErr     error
+
+		expect    int
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+				Target: "target",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: dao.ModeratedVotesFilter{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+				Target: "target",
+			},
+			daoResp: []*dao.VoteModel{
+				{Vote: models.VoteValueUp, UserID: goframework.NumberUUID(10), TargetID: goframework.NumberUUID(1), Target: "target"},
+				{Vote: models.VoteValueDown, UserID: goframework.NumberUUID(10), TargetID: goframework.NumberUUID(2), Target: "target"},
+			},
+			getSummaries: []getSummariesCall{
+				{
+					target:    "target",
+					targetIDs: []uuid.UUID{goframework.NumberUUID(1), goframework.NumberUUID(2)},
+					resp: []*dao.VotesSummaryModel{
+						{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 5, DownVotes: 1},
+						{TargetID: goframework.NumberUUID(2), Target: "target", UpVotes: 0, DownVotes: 3},
+					},
+				},
+			},
+			queue: []*dao.VotesSummaryModel{
+				{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 5, DownVotes: 1},
+				{TargetID: goframework.NumberUUID(2), Target: "target", UpVotes: 0, DownVotes: 3},
+			},
+			expect: 2,
+		},
+		{
+			name:     "Success/NothingToRestore",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: dao.ModeratedVotesFilter{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			daoResp: []*dao.VoteModel{},
+		},
+		{
+			name:     "Error/QueueSummaryUpdateFailure",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: dao.ModeratedVotesFilter{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			daoResp: []*dao.VoteModel{
+				{Vote: models.VoteValueUp, UserID: goframework.NumberUUID(10), TargetID: goframework.NumberUUID(1), Target: "target"},
+			},
+			getSummaries: []getSummariesCall{
+				{
+					target:    "target",
+					targetIDs: []uuid.UUID{goframework.NumberUUID(1)},
+					resp: []*dao.VotesSummaryModel{
+						{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 5},
+					},
+				},
+			},
+			queue: []*dao.VotesSummaryModel{
+				{TargetID: goframework.NumberUUID(1), Target: "target", UpVotes: 5},
+			},
+			queueErr:  fooErr,
+			expectErr: services.ErrQueueSummaryUpdate,
+		},
+		{
+			name:     "Error/DAOFailure",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			shouldCallDAOWith: dao.ModeratedVotesFilter{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			daoErr:    fooErr,
+			expectErr: services.ErrRestoreVotes,
+		},
+		{
+			name:     "Error/NoFilter",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				Target: "target",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrMissingFilter,
+		},
+		{
+			name:     "Error/MissingPermission",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			permissionsErr:        fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:     "Error/NotAuthenticated",
+			tokenRaw: "token",
+			form: models.ModerateVotesForm{
+				UserID: lo.ToPtr(goframework.NumberUUID(10)),
+			},
+			authClientResp: &apiclients.UserTokenStatus{},
+			expectErr:      goframework.ErrInvalidCredentials,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewVotesRepository(t)
+			authClient := apiclientsmocks.NewAuthClient(t)
+			permissionsClient := apiclientsmocks.NewPermissionsClient(t)
+
+			authClient.On("IntrospectToken", context.Background(), d.tokenRaw).Return(d.authClientResp, d.authClientErr)
+
+			if d.shouldCallPermissions {
+				permissionsClient.
+					On("HasUserScope", context.Background(), apiclients.HasUserScopeQuery{
+						UserID: d.authClientResp.Token.Payload.ID,
+						Scope:  moderationScope,
+					}).
+					Return(d.permissionsErr)
+			}
+
+			if d.shouldCallDAO {
+				txCall := repository.On("RunInTx", context.Background(), mock.Anything)
+				txCall.Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context, dao.VotesRepository) error)
+					txCall.ReturnArguments = []interface{}{fn(context.Background(), repository)}
+				})
+
+				repository.
+					On("Restore", context.Background(), d.shouldCallDAOWith).
+					Return(d.daoResp, d.daoErr)
+			}
+
+			for _, call := range d.getSummaries {
+				repository.
+					On("GetSummaries", context.Background(), call.target, call.targetIDs).
+					Return(call.resp, call.err)
+			}
+
+			for _, summary := range d.queue {
+				repository.
+					On("QueueSummaryUpdate", context.Background(), d.authClientResp.Token.Payload.ID, summary, updateTime).
+					Return(d.queueErr)
+			}
+
+			service := services.NewRestoreVotesService(repository, authClient, permissionsClient, targets, moderationScope)
+			res, err := service.Restore(context.Background(), d.tokenRaw, d.form, updateTime)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+			authClient.AssertExpectations(t)
+			permissionsClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	apiclients "github.com/a-novel/go-apis/clients"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/adapters"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+	"time"
+)
+
+type ReviewVoteFlagService interface {
+	// Review records the decision of a moderator on a flag. A flag can be set back to pending, to reopen it, unless
+	// the detector already opened a new flag for the same finding.
+	Review(ctx context.Context, tokenRaw string, form models.ReviewVoteFlagForm, now time.Time) (*models.VoteFlag, error)
+}
+
+func NewReviewVoteFlagService(
+	repository dao.VoteFlagsRepository,
+	authClient apiclients.AuthClient,
+	permissionsClient apiclients.PermissionsClient,
+	moderationScope apiclients.Scope,
+) ReviewVoteFlagService {
+	return &reviewVoteFlagServiceImpl{
+		repository:        repository,
+		authClient:        authClient,
+		permissionsClient: permissionsClient,
+		moderationScope:   moderationScope,
+	}
+}
+
+type reviewVoteFlagServiceImpl struct {
+	repository        dao.VoteFlagsRepository
+	authClient        apiclients.AuthClient
+	permissionsClient apiclients.PermissionsClient
+	moderationScope   apiclients.Scope
+}
+
+func (s *reviewVoteFlagServiceImpl) Review(ctx context.Context, tokenRaw string, form models.ReviewVoteFlagForm, now time.Time) (*models.VoteFlag, error) {
+	userID, err := checkUserScope(ctx, s.authClient, s.permissionsClient, tokenRaw, s.moderationScope)
+	if err != nil {
+		return nil, err
+	}
+
+	err = goframework.CheckRestricted(
+		form.Status, models.VoteFlagStatusPending, models.VoteFlagStatusConfirmed, models.VoteFlagStatusDismissed,
+	)
+	if err != nil {
+		return nil, goerrors.Join(goframework.ErrInvalidEntity, err)
+	}
+
+	flag, err := s.repository.Review(ctx, form.ID, form.Status, userID, now)
+	if err != nil {
+		if goerrors.Is(err, dao.ErrVoteFlagPending) {
+			return nil, goerrors.Join(ErrVoteFlagPending, err)
+		}
+
+		return nil, goerrors.Join(ErrReviewVoteFlag, err)
+	}
+
+	return adapters.VoteFlagToModel(flag), nil
+}
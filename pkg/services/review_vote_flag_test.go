@@ -0,0 +1,206 @@
+package services_test
+
+import (
+	"context"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReviewVoteFlagService(t *testing.T) {
+	moderationScope := apiclients.Scope("can_moderate_votes")
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		form     models.ReviewVoteFlagForm
+
+		authClientResp *apiclients.UserTokenStatus
+		authClientErr  error
+
+		shouldCallPermissions bool
+		permissionsErr        error
+
+		shouldCallDAO bool
+		daoResp       *dao.VoteFlagModel
+		daoErr        error
+
+		expect    *models.VoteFlag
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			tokenRaw: "token",
+			form: models.ReviewVoteFlagForm{
+				ID:     goframework.NumberUUID(1),
+				Status: models.VoteFlagStatusConfirmed,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			daoResp: &dao.VoteFlagModel{
+				ID:          goframework.NumberUUID(1),
+				CreatedAt:   baseTime,
+				Kind:        models.VoteFlagKindFlipBurst,
+				Target:      "target",
+				TargetIDs:   []uuid.UUID{goframework.NumberUUID(10)},
+				UserIDs:     []uuid.UUID{goframework.NumberUUID(20)},
+				Occurrences: 30,
+				Fingerprint: "fingerprint",
+				Status:      models.VoteFlagStatusConfirmed,
+				ReviewedBy:  lo.ToPtr(goframework.NumberUUID(100)),
+				ReviewedAt:  lo.ToPtr(updateTime),
+			},
+			expect: &models.VoteFlag{
+				ID:          goframework.NumberUUID(1),
+				CreatedAt:   baseTime,
+				Kind:        models.VoteFlagKindFlipBurst,
+				Target:      "target",
+				TargetIDs:   []uuid.UUID{goframework.NumberUUID(10)},
+				UserIDs:     []uuid.UUID{goframework.NumberUUID(20)},
+				Occurrences: 30,
+				Status:      models.VoteFlagStatusConfirmed,
+				ReviewedBy:  lo.ToPtr(goframework.NumberUUID(100)),
+				ReviewedAt:  lo.ToPtr(updateTime),
+			},
+		},
+		{
+			name:     "Error/DAOFailure",
+			tokenRaw: "token",
+			form: models.ReviewVoteFlagForm{
+				ID:     goframework.NumberUUID(1),
+				Status: models.VoteFlagStatusDismissed,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			daoErr:                fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:     "Error/ReopenWhilePending",
+			tokenRaw: "token",
+			form: models.ReviewVoteFlagForm{
+				ID:     goframework.NumberUUID(1),
+				Status: models.VoteFlagStatusPending,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			daoErr:                dao.ErrVoteFlagPending,
+			expectErr:             services.ErrVoteFlagPending,
+		},
+		{
+			name:     "Error/InvalidStatus",
+			tokenRaw: "token",
+			form: models.ReviewVoteFlagForm{
+				ID:     goframework.NumberUUID(1),
+				Status: "foo",
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			expectErr:             goframework.ErrInvalidEntity,
+		},
+		{
+			name:     "Error/MissingPermission",
+			tokenRaw: "token",
+			form: models.ReviewVoteFlagForm{
+				ID:     goframework.NumberUUID(1),
+				Status: models.VoteFlagStatusConfirmed,
+			},
+			authClientResp: &apiclients.UserTokenStatus{
+				OK: true,
+				Token: &apiclients.UserToken{
+					Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+				},
+			},
+			shouldCallPermissions: true,
+			permissionsErr:        fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:     "Error/NotAuthenticated",
+			tokenRaw: "token",
+			form: models.ReviewVoteFlagForm{
+				ID:     goframework.NumberUUID(1),
+				Status: models.VoteFlagStatusConfirmed,
+			},
+			authClientResp: &apiclients.UserTokenStatus{},
+			expectErr:      goframework.ErrInvalidCredentials,
+		},
+		{
+			name:     "Error/AuthClientFailure",
+			tokenRaw: "token",
+			form: models.ReviewVoteFlagForm{
+				ID:     goframework.NumberUUID(1),
+				Status: models.VoteFlagStatusConfirmed,
+			},
+			authClientErr: fooErr,
+			expectErr:     fooErr,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewVoteFlagsRepository(t)
+			authClient := apiclientsmocks.NewAuthClient(t)
+			permissionsClient := apiclientsmocks.NewPermissionsClient(t)
+
+			authClient.On("IntrospectToken", context.Background(), d.tokenRaw).Return(d.authClientResp, d.authClientErr)
+
+			if d.shouldCallPermissions {
+				permissionsClient.
+					On("HasUserScope", context.Background(), apiclients.HasUserScopeQuery{
+						UserID: d.authClientResp.Token.Payload.ID,
+						Scope:  moderationScope,
+					}).
+					Return(d.permissionsErr)
+			}
+
+			if d.shouldCallDAO {
+				repository.
+					On("Review", context.Background(), d.form.ID, d.form.Status, d.authClientResp.Token.Payload.ID, updateTime).
+					Return(d.daoResp, d.daoErr)
+			}
+
+			service := services.NewReviewVoteFlagService(repository, authClient, permissionsClient, moderationScope)
+			res, err := service.Review(context.Background(), d.tokenRaw, d.form, updateTime)
+
+			require.ErrorIs(t, err, d.expectErr)
+			require.Equal(t, d.expect, res)
+
+			repository.AssertExpectations(t)
+			authClient.AssertExpectations(t)
+			permissionsClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"github.com/a-novel/votes-service/pkg/models"
+	"math"
+)
+
+// WilsonZScore is the quantile of the standard normal distribution used for the Wilson score interval. It
+// corresponds to a 95% confidence level.
+const WilsonZScore = 1.96
+
+// ComputeVotesScores computes the ranking scores for a given number of up and down votes.
+func ComputeVotesScores(upVotes, downVotes int) *models.VotesScores {
+	return &models.VotesScores{
+		Net:         upVotes - downVotes,
+		Ratio:       votesRatio(upVotes, downVotes),
+		Wilson:      wilsonLowerBound(upVotes, downVotes, WilsonZScore),
+		Controversy: controversy(upVotes, downVotes),
+	}
+}
+
+// withScores attaches the ranking scores to a summary.
+func withScores(summary *models.VotesSummary) *models.VotesSummary {
+	if summary == nil {
+		return nil
+	}
+
+	summary.Scores = ComputeVotesScores(summary.UpVotes, summary.DownVotes)
+	return summary
+}
+
+func votesRatio(upVotes, downVotes int) float64 {
+	total := upVotes + downVotes
+	if total <= 0 {
+		return 0
+	}
+
+	return float64(upVotes) / float64(total)
+}
+
+// https://www.evanmiller.org/how-not-to-sort-by-average-rating.html
+func wilsonLowerBound(upVotes, downVotes int, z float64) float64 {
+	n := float64(upVotes + downVotes)
+	if n <= 0 {
+		return 0
+	}
+
+	p := float64(upVotes) / n
+	z2 := z * z
+
+	return (p + z2/(2*n) - z*math.Sqrt((p*(1-p)+z2/(4*n))/n)) / (1 + z2/n)
+}
+
+// Same formula as Reddit: the magnitude of the votes, raised to the power of their balance.
+func controversy(upVotes, downVotes int) float64 {
+	if upVotes <= 0 || downVotes <= 0 {
+		return 0
+	}
+
+	magnitude := float64(upVotes + downVotes)
+	balance := float64(min(upVotes, downVotes)) / float64(max(upVotes, downVotes))
+
+	return math.Pow(magnitude, balance)
+}
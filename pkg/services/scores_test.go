@@ -0,0 +1,81 @@
+package services_test
+
+import (
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestComputeVotesScores(t *testing.T) {
+	data := []struct {
+		name string
+
+		upVotes   int
+		downVotes int
+
+		expect *models.VotesScores
+	}{
+		{
+			name:   "NoVotes",
+			expect: &models.VotesScores{},
+		},
+		{
+			name:    "OnlyUpVotes",
+			upVotes: 1,
+			expect: &models.VotesScores{
+				Net:    1,
+				Ratio:  1,
+				Wilson: 0.206543,
+			},
+		},
+		{
+			name:      "OnlyDownVotes",
+			downVotes: 3,
+			expect: &models.VotesScores{
+				Net: -3,
+			},
+		},
+		{
+			name:      "Balanced",
+			upVotes:   10,
+			downVotes: 10,
+			expect: &models.VotesScores{
+				Ratio:       0.5,
+				Wilson:      0.299295,
+				Controversy: 20,
+			},
+		},
+		{
+			name:      "Mixed",
+			upVotes:   10,
+			downVotes: 5,
+			expect: &models.VotesScores{
+				Net:         5,
+				Ratio:       0.666667,
+				Wilson:      0.417131,
+				Controversy: 3.872983,
+			},
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			res := services.ComputeVotesScores(d.upVotes, d.downVotes)
+
+			require.Equal(t, d.expect.Net, res.Net)
+			require.InDelta(t, d.expect.Ratio, res.Ratio, 1e-6)
+			require.InDelta(t, d.expect.Wilson, res.Wilson, 1e-6)
+			require.InDelta(t, d.expect.Controversy, res.Controversy, 1e-6)
+		})
+	}
+}
+
+func TestComputeVotesScores_WilsonOrdering(t *testing.T) {
+	// A single up vote should not outrank a target with a large majority of up votes.
+	require.Greater(
+		t,
+		services.ComputeVotesScores(95, 5).Wilson,
+		services.ComputeVotesScores(1, 0).Wilson,
+	)
+}
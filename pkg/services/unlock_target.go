@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	apiclients "github.com/a-novel/go-apis/clients"
+	goframework "github.com/a-novel/go-framework"
+	"github.com/a-novel/votes-service/pkg/dao"
+	"github.com/a-novel/votes-service/pkg/models"
+)
+
+type UnlockTargetService interface {
+	// Unlock lets a target accept votes again. It also cancels a scheduled close.
+	Unlock(ctx context.Context, tokenRaw string, form models.UnlockTargetForm) error
+}
+
+func NewUnlockTargetService(
+	repository dao.TargetLocksRepository,
+	authClient apiclients.AuthClient,
+	permissionsClient apiclients.PermissionsClient,
+	targets map[string]*models.Target,
+	moderationScope apiclients.Scope,
+) UnlockTargetService {
+	return &unlockTargetServiceImpl{
+		repository:        repository,
+		authClient:        authClient,
+		permissionsClient: permissionsClient,
+		targets:           targets,
+		moderationScope:   moderationScope,
+	}
+}
+
+type unlockTargetServiceImpl struct {
+	repository        dao.TargetLocksRepository
+	authClient        apiclients.AuthClient
+	permissionsClient apiclients.PermissionsClient
+
+	targets         map[string]*models.Target
+	moderationScope apiclients.Scope
+}
+
+func (s *unlockTargetServiceImpl) Unlock(ctx context.Context, tokenRaw string, form models.UnlockTargetForm) error {
+	if _, err := checkUserScope(ctx, s.authClient, s.permissionsClient, tokenRaw, s.moderationScope); err != nil {
+		return err
+	}
+
+	if s.targets[form.Target] == nil {
+		return goerrors.Join(goframework.ErrInvalidEntity, ErrInvalidTarget)
+	}
+
+	if err := s.repository.Unlock(ctx, form.TargetID, form.Target); err != nil {
+		return goerrors.Join(ErrUnlockTarget, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package services_test
+
+import (
+	"context"
+	"github.com/a-novel/bunovel"
+	apiclients "github.com/a-novel/go-apis/clients"
+	apiclientsmocks "github.com/a-novel/go-apis/clients/mocks"
+	goframework "github.com/a-novel/go-framework"
+	daomocks "github.com/a-novel/votes-service/pkg/dao/mocks"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/a-novel/votes-service/pkg/services"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestUnlockTargetService(t *testing.T) {
+	moderationScope := apiclients.Scope("can_moderate_votes")
+
+	targets := map[string]*models.Target{
+		"target": {Name: "target", Open: true},
+	}
+
+	moderatorToken := &apiclients.UserTokenStatus{
+		OK: true,
+		Token: &apiclients.UserToken{
+			Payload: apiclients.UserTokenPayload{ID: goframework.NumberUUID(100)},
+		},
+	}
+
+	data := []struct {
+		name string
+
+		tokenRaw string
+		form     models.UnlockTargetForm
+
+		authClientResp *apiclients.UserTokenStatus
+		authClientErr  error
+
+		shouldCallPermissions bool
+		permissionsErr        error
+
+		shouldCallDAO bool
+		daoErr        error
+
+		expectErr error
+	}{
+		{
+			name:     "Success",
+			tokenRaw: "token",
+			form: models.UnlockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+		},
+		{
+			name:     "Error/NotLocked",
+			tokenRaw: "token",
+			form: models.UnlockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			daoErr:                bunovel.ErrNotFound,
+			expectErr:             bunovel.ErrNotFound,
+		},
+		{
+			name:     "Error/DAOFailure",
+			tokenRaw: "token",
+			form: models.UnlockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			shouldCallDAO:         true,
+			daoErr:                fooErr,
+			expectErr:             services.ErrUnlockTarget,
+		},
+		{
+			name:     "Error/UnknownTarget",
+			tokenRaw: "token",
+			form: models.UnlockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "foo",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			expectErr:             services.ErrInvalidTarget,
+		},
+		{
+			name:     "Error/MissingPermission",
+			tokenRaw: "token",
+			form: models.UnlockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			authClientResp:        moderatorToken,
+			shouldCallPermissions: true,
+			permissionsErr:        fooErr,
+			expectErr:             fooErr,
+		},
+		{
+			name:     "Error/NotAuthenticated",
+			tokenRaw: "token",
+			form: models.UnlockTargetForm{
+				TargetID: goframework.NumberUUID(1),
+				Target:   "target",
+			},
+			authClientResp: &apiclients.UserTokenStatus{},
+			expectErr:      goframework.ErrInvalidCredentials,
+		},
+	}
+
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			repository := daomocks.NewTargetLocksRepository(t)
+			authClient := apiclientsmocks.NewAuthClient(t)
+			permissionsClient := apiclientsmocks.NewPermissionsClient(t)
+
+			authClient.On("IntrospectToken", context.Background(), d.tokenRaw).Return(d.authClientResp, d.authClientErr)
+
+			if d.shouldCallPermissions {
+				permissionsClient.
+					On("HasUserScope", context.Background(), apiclients.HasUserScopeQuery{
+						UserID: d.authClientResp.Token.Payload.ID,
+						Scope:  moderationScope,
+					}).
+					Return(d.permissionsErr)
+			}
+
+			if d.shouldCallDAO {
+				repository.
+					On("Unlock", context.Background(), d.form.TargetID, d.form.Target).
+					Return(d.daoErr)
+			}
+
+			service := services.NewUnlockTargetService(repository, authClient, permissionsClient, targets, moderationScope)
+			err := service.Unlock(context.Background(), d.tokenRaw, d.form)
+
+			require.ErrorIs(t, err, d.expectErr)
+
+			repository.AssertExpectations(t)
+			authClient.AssertExpectations(t)
+			permissionsClient.AssertExpectations(t)
+		})
+	}
+}
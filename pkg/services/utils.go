@@ -8,16 +8,89 @@ var (
 	ErrInvalidToken       = goerrors.New("(data) invalid tokenRaw")
 	ErrInvalidSearchLimit = goerrors.New("(data) invalid search limit")
 	ErrInvalidTarget      = goerrors.New("(data) invalid target")
+	ErrTargetClosed       = goerrors.New("(data) target does not accept votes")
+	ErrTargetLocked       = goerrors.New("(data) target is locked")
+	ErrTooManyTargets     = goerrors.New("(data) too many targets")
+	ErrMissingFilter      = goerrors.New("(data) missing user or target filter")
+	ErrInvalidCursor      = goerrors.New("(data) invalid cursor")
+	ErrInvalidDateRange   = goerrors.New("(data) invalid date range")
+	ErrInvalidIdempotency = goerrors.New("(data) invalid idempotency key")
+	ErrIdempotencyReused  = goerrors.New("(data) idempotency key was already used for a different request")
+	ErrVoteConflict       = goerrors.New("(data) current vote does not match the expected one")
+	ErrRateLimited        = goerrors.New("(data) too many votes")
+	ErrInvalidReason      = goerrors.New("(data) invalid invalidation reason")
+	ErrInvalidVoteValue   = goerrors.New("(data) invalid vote value")
+	ErrInvalidScore       = goerrors.New("(data) invalid rating score")
+	ErrNotRatedTarget     = goerrors.New("(data) target is not rated")
+	ErrRatedTarget        = goerrors.New("(data) target is rated")
+	ErrInvalidQuestion    = goerrors.New("(data) invalid poll question")
+	ErrInvalidOptions     = goerrors.New("(data) invalid poll options")
+	ErrInvalidPollDates   = goerrors.New("(data) invalid poll dates")
+	ErrPollNotOpen        = goerrors.New("(data) poll does not accept ballots")
+	ErrInvalidChoices     = goerrors.New("(data) invalid poll choices")
+	ErrBallotAlreadyCast  = goerrors.New("(data) ballot was already cast on this poll")
+	ErrVoteFlagPending    = goerrors.New("(data) a pending flag already covers this finding")
+	ErrPollResultsHidden  = goerrors.New("(data) poll results are hidden until the poll closes")
+	ErrInvalidPollKind    = goerrors.New("(data) ranked polls cannot be multi-select")
+	ErrPollRanked         = goerrors.New("(data) poll takes ranked ballots")
+	ErrPollNotRanked      = goerrors.New("(data) poll does not take ranked ballots")
+	ErrInvalidRanking     = goerrors.New("(data) invalid poll ranking")
+	ErrVoteInvalidated    = goerrors.New("(data) invalidated votes cannot be retracted")
 
 	ErrIntrospectToken  = goerrors.New("(dep) failed to introspect tokenRaw")
+	ErrCheckVoteTarget  = goerrors.New("(dep) failed to check vote on target")
 	ErrSendVoteToTarget = goerrors.New("(dep) failed to send vote to target")
+	ErrCheckPermissions = goerrors.New("(dep) failed to check user permissions")
+	ErrCheckRateLimit   = goerrors.New("(dep) failed to check rate limit")
+	ErrResolveWeight    = goerrors.New("(dep) failed to resolve vote weight")
+	ErrCheckOwnership   = goerrors.New("(dep) failed to check target ownership")
 
-	ErrGetVote         = goerrors.New("(dao) failed to get vote")
-	ErrListUserVotes   = goerrors.New("(dao) failed to list user votes")
-	ErrCastVote        = goerrors.New("(dao) failed to cast vote")
-	ErrGetVotesSummary = goerrors.New("(dao) failed to get votes summary")
+	ErrGetVote            = goerrors.New("(dao) failed to get vote")
+	ErrGetUserVotes       = goerrors.New("(dao) failed to get user votes")
+	ErrListUserVotes      = goerrors.New("(dao) failed to list user votes")
+	ErrCountUserVotes     = goerrors.New("(dao) failed to count user votes")
+	ErrListTargetVotes    = goerrors.New("(dao) failed to list target votes")
+	ErrCountTargetVotes   = goerrors.New("(dao) failed to count target votes")
+	ErrCastVote           = goerrors.New("(dao) failed to cast vote")
+	ErrGetVotesSummary    = goerrors.New("(dao) failed to get votes summary")
+	ErrGetVotesSummaries  = goerrors.New("(dao) failed to get votes summaries")
+	ErrListHotTargets     = goerrors.New("(dao) failed to list hot targets")
+	ErrListTopRated       = goerrors.New("(dao) failed to list top rated targets")
+	ErrListVoteEvents     = goerrors.New("(dao) failed to list vote events")
+	ErrQueueSummaryUpdate = goerrors.New("(dao) failed to queue summary update")
+	ErrClaimOutbox        = goerrors.New("(dao) failed to claim outbox messages")
+	ErrUpdateOutbox       = goerrors.New("(dao) failed to update outbox message")
+	ErrGetIdempotency     = goerrors.New("(dao) failed to get idempotency key")
+	ErrClaimIdempotency   = goerrors.New("(dao) failed to claim idempotency key")
+	ErrSaveIdempotency    = goerrors.New("(dao) failed to save idempotent response")
+	ErrPurgeIdempotency   = goerrors.New("(dao) failed to purge idempotency keys")
+	ErrDetectVoteFlags    = goerrors.New("(dao) failed to detect suspicious votes")
+	ErrSaveVoteFlags      = goerrors.New("(dao) failed to save vote flags")
+	ErrListVoteFlags      = goerrors.New("(dao) failed to list vote flags")
+	ErrReviewVoteFlag     = goerrors.New("(dao) failed to review vote flag")
+	ErrInvalidateVotes    = goerrors.New("(dao) failed to invalidate votes")
+	ErrRestoreVotes       = goerrors.New("(dao) failed to restore votes")
+	ErrGetTargetLock      = goerrors.New("(dao) failed to get target lock")
+	ErrLockTarget         = goerrors.New("(dao) failed to lock target")
+	ErrUnlockTarget       = goerrors.New("(dao) failed to unlock target")
+	ErrCreatePoll         = goerrors.New("(dao) failed to create poll")
+	ErrGetPoll            = goerrors.New("(dao) failed to get poll")
+	ErrCastBallot         = goerrors.New("(dao) failed to cast ballot")
+	ErrGetPollResults     = goerrors.New("(dao) failed to get poll results")
+	ErrCastRanking        = goerrors.New("(dao) failed to cast ranked ballot")
+	ErrGetRunoffResults   = goerrors.New("(dao) failed to get runoff results")
 )
 
 const (
-	MaxSearchLimit = 100
+	MaxSearchLimit  = 100
+	MaxBatchTargets = 100
+	// MaxIdempotencyKeyLength is large enough for any UUID or hash based key.
+	MaxIdempotencyKeyLength = 255
+	// MaxInvalidationReasonLength leaves room for a short explanation, for the record.
+	MaxInvalidationReasonLength = 1024
+	// MaxPollQuestionLength and MaxPollOptionLength are counted in bytes.
+	MaxPollQuestionLength = 512
+	MaxPollOptionLength   = 256
+	MinPollOptions        = 2
+	MaxPollOptions        = 20
 )
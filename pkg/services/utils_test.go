@@ -1,7 +1,10 @@
 package services_test
 
 import (
+	"context"
 	"fmt"
+	"github.com/a-novel/votes-service/pkg/models"
+	"github.com/google/uuid"
 	"time"
 )
 
@@ -13,3 +16,74 @@ var (
 	baseTime   = time.Date(2020, time.May, 4, 8, 0, 0, 0, time.UTC)
 	updateTime = time.Date(2020, time.May, 4, 9, 0, 0, 0, time.UTC)
 )
+
+// fakeTargetHandler is a local implementation of models.TargetHandler. It records the counters it receives, and fails
+// for the targets listed in failures. The authors of the targets are listed in owners.
+type fakeTargetHandler struct {
+	authorizeErr error
+
+	owners   map[uuid.UUID]uuid.UUID
+	ownerErr error
+
+	failures map[uuid.UUID]error
+	received map[uuid.UUID]*models.TargetUpdate
+}
+
+func (handler *fakeTargetHandler) Authorize(_ context.Context, _, _ uuid.UUID) error {
+	return handler.authorizeErr
+}
+
+func (handler *fakeTargetHandler) Publish(_ context.Context, update *models.TargetUpdate) error {
+	if err := handler.failures[update.TargetID]; err != nil {
+		return err
+	}
+
+	if handler.received == nil {
+		handler.received = map[uuid.UUID]*models.TargetUpdate{}
+	}
+
+	handler.received[update.TargetID] = update
+	return nil
+}
+
+func (handler *fakeTargetHandler) IsOwner(_ context.Context, userID, targetID uuid.UUID) (bool, error) {
+	if handler.ownerErr != nil {
+		return false, handler.ownerErr
+	}
+
+	owner, ok := handler.owners[targetID]
+	return ok && owner == userID, nil
+}
+
+// fakeWeightResolver is a local implementation of models.WeightResolver. It gives the same weight to every vote.
+type fakeWeightResolver struct {
+	weight int
+	err    error
+}
+
+func (resolver *fakeWeightResolver) Resolve(_ context.Context, _, _ uuid.UUID) (int, error) {
+	return resolver.weight, resolver.err
+}
+
+// fakeRateLimiter is a local implementation of models.RateLimiter. It rejects the keys listed in limited, and records
+// the keys it was asked about.
+type fakeRateLimiter struct {
+	err error
+
+	limited map[string]time.Duration
+	checked []string
+}
+
+func (limiter *fakeRateLimiter) Allow(_ context.Context, buckets []models.RateLimitBucket, _ time.Time) (time.Duration, error) {
+	var retryAfter time.Duration
+	for _, bucket := range buckets {
+		limiter.checked = append(limiter.checked, bucket.Key)
+		retryAfter = max(retryAfter, limiter.limited[bucket.Key])
+	}
+
+	return retryAfter, limiter.err
+}
+
+func (limiter *fakeRateLimiter) Purge(_ context.Context, _ time.Time) error {
+	return nil
+}